@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -12,8 +13,40 @@ import (
 	"github.com/ubuntu/zsys/internal/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
 )
 
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// printStructured prints v on stdout in the machine readable format (json or yaml).
+func printStructured(format string, v interface{}) error {
+	// Go through json for both formats to honor generated protobuf field names and skip their internal fields.
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't convert to %s: %v"), format, err)
+	}
+
+	switch format {
+	case formatJSON:
+	case formatYAML:
+		var content interface{}
+		if err := yaml.Unmarshal(b, &content); err != nil {
+			return fmt.Errorf(i18n.G("couldn't convert to %s: %v"), format, err)
+		}
+		if b, err = yaml.Marshal(content); err != nil {
+			return fmt.Errorf(i18n.G("couldn't convert to %s: %v"), format, err)
+		}
+	default:
+		return fmt.Errorf(i18n.G("unknown output format %q"), format)
+	}
+
+	fmt.Println(string(b))
+	return nil
+}
+
 // newClient returns a new zsys client object
 func newClient() (*zsys.ZsysLogClient, error) {
 	// TODO: allow change socket address
@@ -9,6 +9,7 @@ import (
 	"github.com/ubuntu/zsys/cmd/zsysd/cmdhandler"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/machines"
 	"github.com/ubuntu/zsys/internal/streamlogger"
 )
 
@@ -24,19 +25,21 @@ var (
 		Use:   "show [MachineID]",
 		Short: i18n.G("Shows the status of the machine."),
 		Args:  cobra.MaximumNArgs(1),
-		Run:   func(cmd *cobra.Command, args []string) { cmdErr = show(args) },
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = show(args, machineFormat) },
 	}
 
 	listCmd = &cobra.Command{
 		Use:   "list",
 		Short: i18n.G("List all the machines and basic information."),
 		Args:  cobra.NoArgs,
-		Run:   func(cmd *cobra.Command, args []string) { cmdErr = list(args) },
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = list(args, machineFormat) },
 	}
 )
 
 var (
-	fullInfo bool
+	fullInfo      bool
+	machineFormat string
+	machineSort   string
 )
 
 func init() {
@@ -45,12 +48,23 @@ func init() {
 	machineCmd.AddCommand(listCmd)
 
 	showCmd.Flags().BoolVarP(&fullInfo, "full", "", false, i18n.G("Give more detail informations on each machine."))
+	showCmd.Flags().StringVarP(&machineFormat, "format", "", formatTable, i18n.G("Output format: table, json or yaml"))
+	showCmd.Flags().StringVarP(&machineSort, "sort", "", machines.SortByLastUsed,
+		fmt.Sprintf(i18n.G("Sort history states by %s or %s"), machines.SortByLastUsed, machines.SortByReclaimable))
+	listCmd.Flags().StringVarP(&machineFormat, "format", "", formatTable, i18n.G("Output format: table, json or yaml"))
 
 	cmdhandler.RegisterAlias(listCmd, rootCmd)
 	cmdhandler.RegisterAlias(showCmd, rootCmd)
 }
 
-func show(args []string) error {
+// formatTable is the default, human readable, output format of machine commands.
+const formatTable = "table"
+
+func show(args []string, format string) error {
+	if err := checkMachineFormat(format); err != nil {
+		return err
+	}
+
 	var machineID string
 	if len(args) > 0 {
 		machineID = args[0]
@@ -64,7 +78,12 @@ func show(args []string) error {
 	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
 	defer cancel()
 
-	stream, err := client.MachineShow(ctx, &zsys.MachineShowRequest{MachineId: machineID, Full: fullInfo})
+	stream, err := client.MachineShow(ctx, &zsys.MachineShowRequest{
+		MachineId:  machineID,
+		Full:       fullInfo,
+		Structured: format != formatTable,
+		Sort:       machineSort,
+	})
 
 	if err = checkConn(err, reset); err != nil {
 		return err
@@ -82,12 +101,23 @@ func show(args []string) error {
 		if err != nil {
 			return err
 		}
+		if format != formatTable {
+			if err := printStructured(format, r.GetMachine()); err != nil {
+				return err
+			}
+			continue
+		}
 		fmt.Printf(r.GetMachineInfo())
 	}
 
 	return nil
 }
-func list(args []string) error {
+
+func list(args []string, format string) error {
+	if err := checkMachineFormat(format); err != nil {
+		return err
+	}
+
 	client, err := newClient()
 	if err != nil {
 		return err
@@ -97,7 +127,7 @@ func list(args []string) error {
 	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
 	defer cancel()
 
-	stream, err := client.MachineList(ctx, &zsys.Empty{})
+	stream, err := client.MachineList(ctx, &zsys.MachineListRequest{Structured: format != formatTable})
 
 	if err = checkConn(err, reset); err != nil {
 		return err
@@ -115,8 +145,27 @@ func list(args []string) error {
 		if err != nil {
 			return err
 		}
+		if format != formatTable {
+			summaries := r.GetMachines().GetMachines()
+			if summaries == nil {
+				summaries = []*zsys.MachineSummary{}
+			}
+			if err := printStructured(format, summaries); err != nil {
+				return err
+			}
+			continue
+		}
 		fmt.Printf(r.GetMachineList())
 	}
 
 	return nil
 }
+
+// checkMachineFormat returns an error if format isn't supported by machine commands.
+func checkMachineFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return nil
+	}
+	return fmt.Errorf(i18n.G("unknown output format %q"), format)
+}
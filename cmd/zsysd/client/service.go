@@ -1,12 +1,17 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/ubuntu/zsys"
@@ -66,11 +71,53 @@ var (
 		Args:  cobra.NoArgs,
 		Run:   func(cmd *cobra.Command, args []string) { cmdErr = reloadConfig() },
 	}
+	configCmd = &cobra.Command{
+		Use:   "config COMMAND",
+		Short: i18n.G("Daemon configuration management"),
+		Args:  cmdhandler.SubcommandsRequiredWithSuggestions,
+		Run:   cmdhandler.NoCmd,
+	}
+	configCheckCmd = &cobra.Command{
+		Use:   "check [FILE]",
+		Short: i18n.G("Checks a configuration file, merged with its fragments, without loading it. Default is the daemon one."),
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := config.DefaultPath
+			if len(args) > 0 {
+				path = args[0]
+			}
+			cmdErr = checkConfig(path)
+		},
+	}
 	gcCmd = &cobra.Command{
 		Use:   "gc",
 		Short: i18n.G("Run daemon state saves garbage collection."),
 		Args:  cobra.NoArgs,
-		Run:   func(cmd *cobra.Command, args []string) { cmdErr = gc(gcAll) },
+		Run: func(cmd *cobra.Command, args []string) {
+			if gcSimulate {
+				cmdErr = gcSimulation(gcAll, gcDryRun, gcDays, gcRate, gcFormat)
+				return
+			}
+			cmdErr = gc(gcAll, gcDryRun, gcFormat)
+		},
+	}
+	scheduleCmd = &cobra.Command{
+		Use:   "schedule",
+		Short: i18n.G("Saves the states of due schedule rules, then runs garbage collection."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = runSchedule() },
+	}
+	auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: i18n.G("Prints the operations which changed the system, who requested them, the datasets they affected and their outcome."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = auditLog(auditSince, auditUser, auditFormat) },
+	}
+	watchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: i18n.G("Prints daemon events, like states creation and removal, until interrupted."),
+		Args:  cobra.NoArgs,
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = watch(watchMachine, watchUser) },
 	}
 )
 
@@ -79,6 +126,16 @@ var (
 	traceType     string
 	traceDuration int
 	gcAll         bool
+	gcDryRun      bool
+	gcFormat      string
+	gcSimulate    bool
+	gcDays        int
+	gcRate        float64
+	auditSince    string
+	auditUser     string
+	auditFormat   string
+	watchMachine  string
+	watchUser     string
 )
 
 func init() {
@@ -89,7 +146,12 @@ func init() {
 	serviceCmd.AddCommand(refreshCmd)
 	serviceCmd.AddCommand(traceCmd)
 	serviceCmd.AddCommand(reloadCmd)
+	serviceCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configCheckCmd)
 	serviceCmd.AddCommand(gcCmd)
+	serviceCmd.AddCommand(scheduleCmd)
+	serviceCmd.AddCommand(auditCmd)
+	serviceCmd.AddCommand(watchCmd)
 
 	traceCmd.Flags().StringVarP(&traceOutput, "output", "o", "", i18n.G("Dump the trace to a file. Default is ./zsys.<trace-type>.pprof"))
 	traceCmd.Flags().StringVarP(&traceType, "type", "t", "cpu", i18n.G("Type of profiling cpu or mem. Default is cpu."))
@@ -98,6 +160,18 @@ func init() {
 	serviceCmd.AddCommand(statusCmd)
 
 	gcCmd.Flags().BoolVarP(&gcAll, "all", "a", false, i18n.G("Collects all the datasets including manual snapshots and clones."))
+	gcCmd.Flags().BoolVarP(&gcDryRun, "dry-run", "", false, i18n.G("Only print what would be collected and why, without removing anything."))
+	gcCmd.Flags().StringVarP(&gcFormat, "format", "", formatTable, i18n.G("Output format of the dry run plan or simulation: table, json or yaml"))
+	gcCmd.Flags().BoolVarP(&gcSimulate, "simulate", "", false, i18n.G("Preview how the history rules retain current and future states over time, without removing anything."))
+	gcCmd.Flags().IntVarP(&gcDays, "days", "", 30, i18n.G("Number of days to simulate."))
+	gcCmd.Flags().Float64VarP(&gcRate, "rate", "", 1, i18n.G("Number of states saved per day during the simulation."))
+
+	auditCmd.Flags().StringVarP(&auditSince, "since", "", "", i18n.G("Only prints operations since this date (YYYY-MM-DD [HH:MM:SS]) or for this duration (like 12h or 7d)."))
+	auditCmd.Flags().StringVarP(&auditUser, "user", "u", "", i18n.G("Only prints operations requested by this user or acting on it."))
+	auditCmd.Flags().StringVarP(&auditFormat, "format", "", formatTable, i18n.G("Output format: table, json or yaml"))
+
+	watchCmd.Flags().StringVarP(&watchMachine, "machine", "m", "", i18n.G("Only prints events of this machine."))
+	watchCmd.Flags().StringVarP(&watchUser, "user", "u", "", i18n.G("Only prints events of this user."))
 }
 
 func daemonStop() error {
@@ -234,6 +308,93 @@ func refresh() error {
 	return nil
 }
 
+func runSchedule() error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.RunSchedule(ctx, &zsys.Empty{})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func watch(machine, user string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Events can be far apart: don't time out between them.
+	ctx, cancel := context.WithCancel(client.Ctx)
+	defer cancel()
+	connected := make(chan struct{}, 1)
+
+	stream, err := client.Subscribe(ctx, &zsys.SubscribeRequest{MachineId: machine, UserName: user})
+	if err = checkConn(err, connected); err != nil {
+		return err
+	}
+
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		e := r.GetEvent()
+		if e == nil {
+			continue
+		}
+		fmt.Println(formatEvent(e))
+	}
+
+	return nil
+}
+
+// formatEvent returns a one line description of e, with only its non empty fields.
+func formatEvent(e *zsys.Event) string {
+	r := fmt.Sprintf("%s %s", time.Unix(e.GetTime(), 0).Format("2006-01-02 15:04:05"),
+		strings.ReplaceAll(strings.ToLower(e.GetType().String()), "_", "-"))
+	for _, f := range []struct{ name, value string }{
+		{"machine", e.GetMachine()},
+		{"user", e.GetUser()},
+		{"state", e.GetState()},
+	} {
+		if f.value != "" {
+			r += fmt.Sprintf(" %s=%s", f.name, f.value)
+		}
+	}
+	return r
+}
+
 func trace() error {
 	switch traceType {
 	case "":
@@ -375,7 +536,27 @@ func reloadConfig() error {
 	return nil
 }
 
-func gc(gcAll bool) error {
+// checkConfig loads the configuration at path, with its fragments, and reports any error.
+func checkConfig(path string) error {
+	c, err := config.Load(context.Background(), path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(i18n.G("Configuration %s is valid\n"), path)
+	for _, p := range c.DropIns {
+		fmt.Printf(i18n.G("  merged with %s\n"), p)
+	}
+	return nil
+}
+
+func gc(gcAll, dryRun bool, format string) error {
+	if err := checkMachineFormat(format); err != nil {
+		return err
+	}
+	if format != formatTable && !dryRun {
+		return errors.New(i18n.G("output format can only be set with --dry-run or --simulate"))
+	}
+
 	client, err := newClient()
 	if err != nil {
 		return err
@@ -385,13 +566,14 @@ func gc(gcAll bool) error {
 	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
 	defer cancel()
 
-	stream, err := client.GC(ctx, &zsys.GCRequest{All: gcAll})
+	stream, err := client.GC(ctx, &zsys.GCRequest{All: gcAll, DryRun: dryRun})
 	if err = checkConn(err, reset); err != nil {
 		return err
 	}
 
+	decisions := []*zsys.GCDecision{}
 	for {
-		_, err := stream.Recv()
+		r, err := stream.Recv()
 		if err == streamlogger.ErrLogMsg {
 			reset <- struct{}{}
 			continue
@@ -402,7 +584,224 @@ func gc(gcAll bool) error {
 		if err != nil {
 			return err
 		}
+		if d := r.GetDecision(); d != nil {
+			decisions = append(decisions, d)
+		}
 	}
 
-	return nil
+	if !dryRun {
+		return nil
+	}
+	if format != formatTable {
+		return printStructured(format, decisions)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.G("STATE\tUSER\tLAST USED\tBUCKET\tACTION\tREASON"))
+	for _, d := range decisions {
+		var lastUsed string
+		if d.GetLastUsed() != 0 {
+			lastUsed = time.Unix(d.GetLastUsed(), 0).Format("2006-01-02 15:04:05")
+		}
+		action := i18n.G("keep")
+		if d.GetRemove() {
+			action = i18n.G("remove")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", d.GetState(), d.GetUser(), lastUsed, d.GetBucket(), action, d.GetReason())
+	}
+	return w.Flush()
+}
+
+func gcSimulation(gcAll, dryRun bool, days int, rate float64, format string) error {
+	if err := checkMachineFormat(format); err != nil {
+		return err
+	}
+	if gcAll || dryRun {
+		return errors.New(i18n.G("--simulate can't be used with --all or --dry-run"))
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.GCSimulate(ctx, &zsys.GCSimulateRequest{Days: int32(days), Rate: rate})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	var sim *zsys.GCSimulation
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if s := r.GetSimulation(); s != nil {
+			sim = s
+		}
+	}
+	if sim == nil {
+		return errors.New(i18n.G("no simulation received from the daemon"))
+	}
+
+	if format != formatTable {
+		return printStructured(format, sim)
+	}
+
+	var users []string
+	seen := make(map[string]bool)
+	for _, d := range sim.GetDays() {
+		for u := range d.GetUsers() {
+			if !seen[u] {
+				seen[u] = true
+				users = append(users, u)
+			}
+		}
+	}
+	sort.Strings(users)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.G("Retention by day:"))
+	fmt.Fprint(w, i18n.G("DATE\tSAVED\tREMOVED\tSYSTEM"))
+	for _, u := range users {
+		fmt.Fprintf(w, "\t%s", strings.ToUpper(u))
+	}
+	fmt.Fprintln(w)
+	for _, d := range sim.GetDays() {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d", time.Unix(d.GetDate(), 0).Format("2006-01-02"), d.GetSaved(), d.GetRemoved(), d.GetSystem())
+		for _, u := range users {
+			fmt.Fprintf(w, "\t%d", d.GetUsers()[u])
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, i18n.G("Current states removed:"))
+	fmt.Fprintln(w, i18n.G("STATE\tUSER\tLAST USED\tREMOVED ON\tREASON"))
+	for _, r := range sim.GetRemovals() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.GetState(), r.GetUser(), time.Unix(r.GetLastUsed(), 0).Format("2006-01-02 15:04:05"),
+			time.Unix(r.GetRemovedOn(), 0).Format("2006-01-02"), r.GetReason())
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, i18n.G("States left by bucket at the end of the simulation:"))
+	fmt.Fprintln(w, i18n.G("USER\tBUCKET\tSTATES"))
+	for _, b := range sim.GetBuckets() {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", b.GetUser(), b.GetBucket(), b.GetStates())
+	}
+	return w.Flush()
+}
+
+func auditLog(since, user, format string) error {
+	if err := checkMachineFormat(format); err != nil {
+		return err
+	}
+	req := &zsys.AuditRequest{User: user}
+	if since != "" {
+		t, err := parseSince(since, time.Now())
+		if err != nil {
+			return err
+		}
+		req.Since = t.Unix()
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.Audit(ctx, req)
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	entries := []*zsys.AuditEntry{}
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if e := r.GetEntry(); e != nil {
+			entries = append(entries, e)
+		}
+	}
+
+	if format != formatTable {
+		return printStructured(format, entries)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.G("TIME\tOPERATION\tUID\tPID\tACTION\tARGUMENTS\tDATASETS\tOUTCOME"))
+	for _, e := range entries {
+		var args []string
+		for k, v := range e.GetArguments() {
+			args = append(args, k+"="+v)
+		}
+		sort.Strings(args)
+
+		var changes []string
+		count := make(map[string]int)
+		for _, d := range e.GetDatasets() {
+			if count[d.GetChange()] == 0 {
+				changes = append(changes, d.GetChange())
+			}
+			count[d.GetChange()]++
+		}
+		sort.Strings(changes)
+		var datasets []string
+		for _, c := range changes {
+			datasets = append(datasets, fmt.Sprintf("%d %s", count[c], c))
+		}
+
+		outcome := i18n.G("success")
+		if !e.GetSuccess() {
+			outcome = fmt.Sprintf(i18n.G("failed: %s"), e.GetError())
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n", time.Unix(e.GetTime(), 0).Format("2006-01-02 15:04:05"), e.GetOperation(),
+			e.GetUid(), e.GetPid(), e.GetAction(), strings.Join(args, ","), strings.Join(datasets, ", "), outcome)
+	}
+	return w.Flush()
+}
+
+// parseSince returns the time from which to print audit entries, given as a local date or as a duration before now.
+// Durations accept days, like 7d, in addition to the time.ParseDuration units.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	if strings.HasSuffix(since, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(since, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	d, err := time.ParseDuration(since)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf(i18n.G("invalid --since %q: expecting a date like 2006-01-02 or a duration like 12h or 7d"), since)
+	}
+	return now.Add(-d), nil
 }
@@ -7,7 +7,11 @@ import (
 	"io"
 	"os"
 	"os/user"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/ubuntu/zsys"
@@ -31,7 +35,15 @@ var (
 		Short: i18n.G("Saves the current state of the machine. By default it saves only the user state. state_id is generated if not provided."),
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			cmdErr = saveState(args, system, userName, noUpdateBootMenu, saveAuto)
+			cmdErr = saveState(args, system, userName, noUpdateBootMenu, saveAuto, stateDescription, stateLabels)
+		},
+	}
+	statelistCmd = &cobra.Command{
+		Use:   "list",
+		Short: i18n.G("List the states of the machine, optionally filtered by description and labels. By default it lists only the user states."),
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdErr = listStates(system, userName, stateDescription, stateLabels, listFormat)
 		},
 	}
 	stateremoveCmd = &cobra.Command{
@@ -40,6 +52,56 @@ var (
 		Args:  cobra.MaximumNArgs(1),
 		Run:   func(cmd *cobra.Command, args []string) { cmdErr = removeState(args) },
 	}
+	staterevertCmd = &cobra.Command{
+		Use:   "revert [state id]",
+		Short: i18n.G("Revert the machine to a previous state on next boot. By default it reverts only the user state."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = revertState(args, system, userName, revertUserData) },
+	}
+	staterestoreCmd = &cobra.Command{
+		Use:   "restore [state id]",
+		Short: i18n.G("Restore immediately a user state, without rebooting."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = restoreState(args, userName, force) },
+	}
+	statepinCmd = &cobra.Command{
+		Use:   "pin [state id]",
+		Short: i18n.G("Pin a state so that it's never garbage collected, nor removed without confirmation. By default it pins only the user state."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = pinState(args, system, userName, true) },
+	}
+	stateunpinCmd = &cobra.Command{
+		Use:   "unpin [state id]",
+		Short: i18n.G("Unpin a state, so that it can be garbage collected again. By default it unpins only the user state."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = pinState(args, system, userName, false) },
+	}
+	staterenameCmd = &cobra.Command{
+		Use:   "rename [state id] [new name]",
+		Short: i18n.G("Rename a saved state. By default it renames only the user state."),
+		Args:  cobra.ExactArgs(2),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = renameState(args, system, userName) },
+	}
+	stateexportCmd = &cobra.Command{
+		Use:   "export [state id]",
+		Short: i18n.G("Export a saved state to a dataset or a file, with the users states linked to a system state. By default it exports only the user state."),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = exportState(args, system, userName, exportTarget) },
+	}
+	stateimportCmd = &cobra.Command{
+		Use:   "import source [state id]",
+		Short: i18n.G("Import a state exported to a file, or to a dataset in which case the state id is required."),
+		Args:  cobra.RangeArgs(1, 2),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = importState(args) },
+	}
+	statediffCmd = &cobra.Command{
+		Use:   "diff from [to]",
+		Short: i18n.G("Show files changed between two states. By default it compares to the current state."),
+		Args:  cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdErr = diffStates(args, userName, diffWithUsers, diffPath, diffFormat)
+		},
+	}
 )
 
 var (
@@ -49,17 +111,42 @@ var (
 	userName         string
 	force            bool
 	dryrun           bool
+	revertUserData   bool
+	diffWithUsers    bool
+	diffPath         string
+	diffFormat       string
+	stateDescription string
+	stateLabels      []string
+	listFormat       string
+	exportTarget     string
 )
 
 func init() {
 	rootCmd.AddCommand(stateCmd)
 	stateCmd.AddCommand(statesaveCmd)
+	stateCmd.AddCommand(statelistCmd)
 	stateCmd.AddCommand(stateremoveCmd)
+	stateCmd.AddCommand(staterevertCmd)
+	stateCmd.AddCommand(staterestoreCmd)
+	stateCmd.AddCommand(statediffCmd)
+	stateCmd.AddCommand(statepinCmd)
+	stateCmd.AddCommand(stateunpinCmd)
+	stateCmd.AddCommand(staterenameCmd)
+	stateCmd.AddCommand(stateexportCmd)
+	stateCmd.AddCommand(stateimportCmd)
 
 	statesaveCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Save complete system state (users and system)"))
 	statesaveCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Save the state for a given user or current user if empty"))
 	statesaveCmd.Flags().BoolVarP(&noUpdateBootMenu, "no-update-bootmenu", "", false, i18n.G("Do not update bootmenu on system state save"))
 	statesaveCmd.Flags().BoolVarP(&saveAuto, "auto", "", false, i18n.G("Signal this is an automated request triggered by script"))
+	statesaveCmd.Flags().StringVarP(&stateDescription, "description", "", "", i18n.G("Free-form description of the state"))
+	statesaveCmd.Flags().StringArrayVarP(&stateLabels, "label", "", nil, i18n.G("Label to attach to the state, as key=value. Can be repeated"))
+
+	statelistCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("List system states"))
+	statelistCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("List the states of a given user or current user if empty"))
+	statelistCmd.Flags().StringVarP(&stateDescription, "description", "", "", i18n.G("Only list states whose description contains this text"))
+	statelistCmd.Flags().StringArrayVarP(&stateLabels, "label", "", nil, i18n.G("Only list states having this label, as key or key=value. Can be repeated"))
+	statelistCmd.Flags().StringVarP(&listFormat, "format", "", formatTable, i18n.G("Output format: table, json or yaml"))
 
 	// user name and system or exclusive: TODO
 	stateremoveCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Remove system state (system and users linked to it)"))
@@ -67,10 +154,35 @@ func init() {
 	stateremoveCmd.Flags().BoolVarP(&force, "force", "f", false, i18n.G("Force removing, even if dependencies are found"))
 	stateremoveCmd.Flags().BoolVarP(&dryrun, "dry-run", "", false, i18n.G("Dry run, will not remove anything"))
 
+	staterevertCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Revert system state (system and optionally users linked to it)"))
+	staterevertCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Revert the state for a given user or current user if empty"))
+	staterevertCmd.Flags().BoolVarP(&revertUserData, "userdata", "", false, i18n.G("Revert user data attached to the system state as well"))
+
+	staterestoreCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Restore the state for a given user or current user if empty"))
+	staterestoreCmd.Flags().BoolVarP(&force, "force", "f", false, i18n.G("Force restoring, even if the user is logged in"))
+
+	statepinCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Pin system state (system and users linked to it)"))
+	statepinCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Pin the state for a given user or current user if empty"))
+
+	stateunpinCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Unpin system state (system and users linked to it)"))
+	stateunpinCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Unpin the state for a given user or current user if empty"))
+
+	staterenameCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Rename system state (system and users linked to it)"))
+	staterenameCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Rename the state for a given user or current user if empty"))
+
+	stateexportCmd.Flags().BoolVarP(&system, "system", "s", false, i18n.G("Export system state (system and users linked to it)"))
+	stateexportCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Export the state for a given user or current user if empty"))
+	stateexportCmd.Flags().StringVarP(&exportTarget, "to", "", "", i18n.G("New dataset, or dataset of a previous export, to replicate the state to, or file path, starting with / or ., to write it to"))
+
+	statediffCmd.Flags().StringVarP(&userName, "user", "u", "", i18n.G("Compare the states of a given user instead of system states"))
+	statediffCmd.Flags().BoolVarP(&diffWithUsers, "with-users", "", false, i18n.G("Compare user datasets attached to the system states as well"))
+	statediffCmd.Flags().StringVarP(&diffPath, "path", "p", "", i18n.G("Only show changes under this path"))
+	statediffCmd.Flags().StringVarP(&diffFormat, "format", "", "text", i18n.G("Output format: text, json or yaml"))
+
 	cmdhandler.RegisterAlias(statesaveCmd, rootCmd)
 }
 
-func saveState(args []string, system bool, userName string, noUpdateBootMenu, saveAuto bool) (err error) {
+func saveState(args []string, system bool, userName string, noUpdateBootMenu, saveAuto bool, description string, labelArgs []string) (err error) {
 
 	if system && userName != "" {
 		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
@@ -78,6 +190,10 @@ func saveState(args []string, system bool, userName string, noUpdateBootMenu, sa
 	if !system && noUpdateBootMenu {
 		return errors.New(i18n.G("you can't provide no-update-bootmenu option on user state save"))
 	}
+	labels, err := parseLabels(labelArgs, true)
+	if err != nil {
+		return err
+	}
 
 	var stateName string
 	if len(args) > 0 {
@@ -98,6 +214,8 @@ func saveState(args []string, system bool, userName string, noUpdateBootMenu, sa
 			StateName:      stateName,
 			UpdateBootMenu: !noUpdateBootMenu,
 			Autosave:       saveAuto,
+			Description:    description,
+			Labels:         labels,
 		})
 
 		if err = checkConn(err, reset); err != nil {
@@ -128,7 +246,12 @@ func saveState(args []string, system bool, userName string, noUpdateBootMenu, sa
 			userName = user.Username
 		}
 
-		stream, err := client.SaveUserState(ctx, &zsys.SaveUserStateRequest{UserName: userName, StateName: stateName})
+		stream, err := client.SaveUserState(ctx, &zsys.SaveUserStateRequest{
+			UserName:    userName,
+			StateName:   stateName,
+			Description: description,
+			Labels:      labels,
+		})
 
 		if err = checkConn(err, reset); err != nil {
 			return err
@@ -286,3 +409,491 @@ func removeStateGRPC(client *zsys.ZsysLogClient, force, dryrun, system bool, use
 
 	return err
 }
+
+func revertState(args []string, system bool, userName string, revertUserData bool) (err error) {
+	if system && userName != "" {
+		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
+	}
+	if !system && revertUserData {
+		return errors.New(i18n.G("you can't provide userdata option on user state revert"))
+	}
+
+	stateName := args[0]
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	var stream interface {
+		Recv() (*zsys.LogResponse, error)
+	}
+	if system {
+		stream, err = client.RevertSystemState(ctx, &zsys.RevertSystemStateRequest{
+			StateName:      stateName,
+			RevertUserData: revertUserData,
+		})
+	} else {
+		if userName == "" {
+			user, err := user.Current()
+			if err != nil {
+				return fmt.Errorf("Couldn’t determine current user name: %v", err)
+			}
+			userName = user.Username
+		}
+
+		stream, err = client.RevertUserState(ctx, &zsys.RevertUserStateRequest{UserName: userName, StateName: stateName})
+	}
+
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func restoreState(args []string, userName string, force bool) (err error) {
+	stateName := args[0]
+
+	if userName == "" {
+		user, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("Couldn’t determine current user name: %v", err)
+		}
+		userName = user.Username
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.RestoreUserState(ctx, &zsys.RestoreUserStateRequest{UserName: userName, StateName: stateName, Force: force})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func pinState(args []string, system bool, userName string, pin bool) (err error) {
+	if system && userName != "" {
+		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
+	}
+
+	stateName := args[0]
+
+	if !system && userName == "" {
+		user, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("Couldn’t determine current user name: %v", err)
+		}
+		userName = user.Username
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	var stream interface {
+		Recv() (*zsys.LogResponse, error)
+	}
+	if pin {
+		stream, err = client.PinState(ctx, &zsys.PinStateRequest{StateName: stateName, UserName: userName})
+	} else {
+		stream, err = client.UnpinState(ctx, &zsys.UnpinStateRequest{StateName: stateName, UserName: userName})
+	}
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renameState(args []string, system bool, userName string) (err error) {
+	if system && userName != "" {
+		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
+	}
+
+	stateName, newName := args[0], args[1]
+
+	if !system && userName == "" {
+		user, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("Couldn’t determine current user name: %v", err)
+		}
+		userName = user.Username
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.RenameState(ctx, &zsys.RenameStateRequest{StateName: stateName, UserName: userName, NewName: newName})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func exportState(args []string, system bool, userName, target string) (err error) {
+	if system && userName != "" {
+		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
+	}
+
+	if target == "" {
+		return errors.New(i18n.G("you need to provide a target to export to"))
+	}
+
+	stateName := args[0]
+
+	if !system && userName == "" {
+		user, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("Couldn’t determine current user name: %v", err)
+		}
+		userName = user.Username
+	}
+
+	if target, err = filePathToAbs(target); err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTransferTimeout)
+	defer cancel()
+
+	stream, err := client.ExportState(ctx, &zsys.ExportStateRequest{StateName: stateName, UserName: userName, Target: target})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func importState(args []string) (err error) {
+	source, err := filePathToAbs(args[0])
+	if err != nil {
+		return err
+	}
+	var stateName string
+	if len(args) > 1 {
+		stateName = args[1]
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTransferTimeout)
+	defer cancel()
+
+	stream, err := client.ImportState(ctx, &zsys.ImportStateRequest{Source: source, StateName: stateName})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filePathToAbs returns p as an absolute path if it's a relative file path, starting with ".", as the daemon
+// doesn't share our working directory. Datasets and absolute paths are returned as is.
+func filePathToAbs(p string) (string, error) {
+	if !strings.HasPrefix(p, ".") {
+		return p, nil
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", fmt.Errorf(i18n.G("couldn't resolve path %q: %v"), p, err)
+	}
+	return abs, nil
+}
+
+func diffStates(args []string, userName string, withUsers bool, path, format string) (err error) {
+	if format != "text" && format != formatJSON && format != formatYAML {
+		return fmt.Errorf(i18n.G("unknown output format %q"), format)
+	}
+	if userName != "" && withUsers {
+		return errors.New(i18n.G("you can't provide with-users option on user states comparison"))
+	}
+
+	from := args[0]
+	var to string
+	if len(args) > 1 {
+		to = args[1]
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.StateDiff(ctx, &zsys.StateDiffRequest{
+		From:      from,
+		To:        to,
+		UserName:  userName,
+		WithUsers: withUsers,
+		Path:      path,
+	})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	diffs := []*zsys.DatasetDiff{}
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// Changes of a dataset can be split in consecutive replies.
+		d := r.GetDiff()
+		continued := len(diffs) > 0 && diffs[len(diffs)-1].GetFrom() == d.GetFrom() && diffs[len(diffs)-1].GetTo() == d.GetTo()
+		if format != "text" {
+			if continued {
+				diffs[len(diffs)-1].Changes = append(diffs[len(diffs)-1].Changes, d.GetChanges()...)
+				continue
+			}
+			diffs = append(diffs, d)
+			continue
+		}
+		if !continued {
+			fmt.Printf(i18n.G("%s -> %s\n"), d.GetFrom(), d.GetTo())
+			diffs = append(diffs[:0], d)
+		}
+		for _, c := range d.GetChanges() {
+			if c.GetNewPath() != "" {
+				fmt.Printf("%s\t%s -> %s\n", c.GetChange(), c.GetPath(), c.GetNewPath())
+				continue
+			}
+			fmt.Printf("%s\t%s\n", c.GetChange(), c.GetPath())
+		}
+	}
+
+	if format != "text" {
+		return printStructured(format, diffs)
+	}
+
+	return nil
+}
+
+func listStates(system bool, userName, description string, labelArgs []string, format string) (err error) {
+	if err := checkMachineFormat(format); err != nil {
+		return err
+	}
+	if system && userName != "" {
+		return errors.New(i18n.G("you can't provide system and user flags at the same time"))
+	}
+	labels, err := parseLabels(labelArgs, false)
+	if err != nil {
+		return err
+	}
+
+	if !system && userName == "" {
+		user, err := user.Current()
+		if err != nil {
+			return fmt.Errorf("Couldn’t determine current user name: %v", err)
+		}
+		userName = user.Username
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.ListStates(ctx, &zsys.ListStatesRequest{
+		UserName:    userName,
+		Description: description,
+		Labels:      labels,
+	})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	states := []*zsys.State{}
+	for {
+		r, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		states = append(states, r.GetStates().GetStates()...)
+	}
+
+	if format != formatTable {
+		return printStructured(format, states)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.G("ID\tLAST USED\tPINNED\tDESCRIPTION\tLABELS"))
+	for _, s := range states {
+		lastUsed := time.Unix(s.GetLastUsed(), 0).Format("2006-01-02 15:04:05")
+		if s.GetCurrent() {
+			lastUsed = i18n.G("current")
+		}
+		var pinned string
+		if s.GetPinned() {
+			pinned = i18n.G("yes")
+		}
+		var l []string
+		for k, v := range s.GetLabels() {
+			l = append(l, k+"="+v)
+		}
+		sort.Strings(l)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.GetId(), lastUsed, pinned, s.GetDescription(), strings.Join(l, ", "))
+	}
+	return w.Flush()
+}
+
+// parseLabels converts key=value arguments to a labels map.
+// If requireValue is false, a key alone is accepted and gets an empty value.
+func parseLabels(args []string, requireValue bool) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string)
+	for _, a := range args {
+		kv := strings.SplitN(a, "=", 2)
+		if kv[0] == "" || (requireValue && len(kv) != 2) {
+			return nil, fmt.Errorf(i18n.G("invalid label %q: expected key=value"), a)
+		}
+		var v string
+		if len(kv) == 2 {
+			v = kv[1]
+		}
+		labels[kv[0]] = v
+	}
+	return labels, nil
+}
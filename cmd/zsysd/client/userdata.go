@@ -1,7 +1,13 @@
 package client
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/ubuntu/zsys"
@@ -22,8 +28,13 @@ var (
 	userdataCreateCmd = &cobra.Command{
 		Use:   "create USER HOME_DIRECTORY",
 		Short: i18n.G("Create a new home user dataset via an user dataset (if doesn't exist) creation"),
-		Args:  cobra.ExactArgs(2),
-		Run:   func(cmd *cobra.Command, args []string) { cmdErr = createUserData(args[0], args[1]) },
+		Long: i18n.G(`Create a new home user dataset via an user dataset (if doesn't exist) creation.
+With --encrypt, the passphrase of the new user dataset is read from the first line of standard input. Use
+--passphrase-stdin to read it as well when only the configuration encrypts new user datasets by default.`),
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdErr = createUserData(args[0], args[1], encrypt, noEncrypt, passphraseStdin)
+		},
 	}
 	userdataRenameCmd = &cobra.Command{
 		Use:   "set-home OLD_HOME NEW_HOME",
@@ -37,10 +48,26 @@ var (
 		Args:  cobra.ExactArgs(1),
 		Run:   func(cmd *cobra.Command, args []string) { cmdErr = dissociateUser(args[0], removeHome) },
 	}
+	userdataQuotaCmd = &cobra.Command{
+		Use:   "quota USER SIZE",
+		Short: i18n.G("Set the maximum space the user dataset can use, like 10G, or none"),
+		Args:  cobra.ExactArgs(2),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = setUserQuota(args[0], args[1], reservation) },
+	}
+	userdataUnlockCmd = &cobra.Command{
+		Use:   "unlock USER",
+		Short: i18n.G("Unlock encrypted user datasets, reading the passphrase from standard input"),
+		Args:  cobra.ExactArgs(1),
+		Run:   func(cmd *cobra.Command, args []string) { cmdErr = unlockUserData(args[0]) },
+	}
 )
 
 var (
-	removeHome bool
+	removeHome      bool
+	encrypt         bool
+	noEncrypt       bool
+	passphraseStdin bool
+	reservation     string
 )
 
 func init() {
@@ -48,12 +75,39 @@ func init() {
 	userdataCmd.AddCommand(userdataCreateCmd)
 	userdataCmd.AddCommand(userdataRenameCmd)
 	userdataCmd.AddCommand(userdataDissociateCmd)
+	userdataCmd.AddCommand(userdataUnlockCmd)
+	userdataCmd.AddCommand(userdataQuotaCmd)
 	userdataDissociateCmd.Flags().BoolVarP(&removeHome, "remove", "r", false, i18n.G("Empty home directory content if not associated to any machine state"))
+	userdataCreateCmd.Flags().BoolVarP(&encrypt, "encrypt", "e", false, i18n.G("Encrypt the new user dataset, whatever the configuration default is"))
+	userdataCreateCmd.Flags().BoolVarP(&noEncrypt, "no-encrypt", "", false, i18n.G("Don't encrypt the new user dataset, whatever the configuration default is"))
+	userdataCreateCmd.Flags().BoolVarP(&passphraseStdin, "passphrase-stdin", "", false, i18n.G("Read the passphrase from standard input, used if the new user dataset is encrypted"))
+	userdataQuotaCmd.Flags().StringVarP(&reservation, "reservation", "r", "none", i18n.G("Space guaranteed to the user dataset, like 1G, or none"))
 }
 
 // createUserData creates a new userdata for user and set it to homepath on current zsys system.
 // if the user already exists for a dataset attached to the current system, set its mountpoint to homepath.
-func createUserData(user, homepath string) (err error) {
+func createUserData(user, homepath string, encrypt, noEncrypt, passphraseStdin bool) (err error) {
+	if encrypt && noEncrypt {
+		return errors.New(i18n.G("--encrypt and --no-encrypt can't be used together"))
+	}
+	if passphraseStdin && noEncrypt {
+		return errors.New(i18n.G("--passphrase-stdin and --no-encrypt can't be used together"))
+	}
+	req := &zsys.CreateUserDataRequest{User: user, Homepath: homepath}
+	switch {
+	case encrypt:
+		req.Encryption = zsys.CreateUserDataRequest_ENCRYPTED
+	case noEncrypt:
+		req.Encryption = zsys.CreateUserDataRequest_NOT_ENCRYPTED
+	}
+	// Only read standard input when asked to: it can hold anything from the caller, which must not be sent as a
+	// passphrase.
+	if encrypt || passphraseStdin {
+		if req.Passphrase, err = readPassphrase(os.Stdin); err != nil {
+			return err
+		}
+	}
+
 	client, err := newClient()
 	if err != nil {
 		return err
@@ -63,7 +117,7 @@ func createUserData(user, homepath string) (err error) {
 	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
 	defer cancel()
 
-	stream, err := client.CreateUserData(ctx, &zsys.CreateUserDataRequest{User: user, Homepath: homepath})
+	stream, err := client.CreateUserData(ctx, req)
 	if err = checkConn(err, reset); err != nil {
 		return err
 	}
@@ -150,3 +204,116 @@ func dissociateUser(user string, removeHome bool) (err error) {
 
 	return nil
 }
+
+// unlockUserData loads the keys of user encrypted datasets from the passphrase read on stdin.
+func unlockUserData(user string) (err error) {
+	passphrase, err := readPassphrase(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.UnlockUserData(ctx, &zsys.UnlockUserDataRequest{User: user, Passphrase: passphrase})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setUserQuota sets the maximum space and the space reserved, in human readable sizes, for the current user dataset.
+func setUserQuota(user, quota, reservation string) (err error) {
+	q, err := parseSize(quota)
+	if err != nil {
+		return err
+	}
+	r, err := parseSize(reservation)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel, reset := contextWithResettableTimeout(client.Ctx, config.DefaultClientTimeout)
+	defer cancel()
+
+	stream, err := client.SetUserQuota(ctx, &zsys.SetUserQuotaRequest{User: user, Quota: q, Reservation: r})
+	if err = checkConn(err, reset); err != nil {
+		return err
+	}
+
+	for {
+		_, err := stream.Recv()
+		if err == streamlogger.ErrLogMsg {
+			reset <- struct{}{}
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseSize returns the number of bytes of a size with an optional binary prefix, like zfs accepts (1.5G, 512M…).
+// "none" is 0.
+func parseSize(size string) (uint64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	if s == "NONE" {
+		return 0, nil
+	}
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "B"), "I")
+
+	mult := uint64(1)
+	if s != "" {
+		if i := strings.IndexByte("KMGTPE", s[len(s)-1]); i >= 0 {
+			mult <<= 10 * uint(i+1)
+			s = s[:len(s)-1]
+		}
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf(i18n.G("invalid size %q"), size)
+	}
+	return uint64(v * float64(mult)), nil
+}
+
+// readPassphrase returns the first line of r, without its line ending.
+func readPassphrase(r io.Reader) ([]byte, error) {
+	l, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf(i18n.G("couldn't read passphrase: %v"), err)
+	}
+	return []byte(strings.TrimRight(l, "\r\n")), nil
+}
@@ -0,0 +1,228 @@
+// Package audit records the operations changing the system in an append-only log.
+// Each entry tells who requested the operation, the polkit action authorizing it, its arguments, the datasets
+// it affected and its outcome. Entries are also sent to the journal as structured fields.
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-systemd/journal"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+const (
+	// DatasetCreated is the change of a dataset or snapshot created by an operation.
+	DatasetCreated = "created"
+	// DatasetDestroyed is the change of a dataset or snapshot destroyed by an operation.
+	DatasetDestroyed = "destroyed"
+	// DatasetModified is the change of a dataset or snapshot which properties were modified by an operation.
+	DatasetModified = "modified"
+)
+
+// Entry is the record of an operation.
+type Entry struct {
+	Time time.Time
+	// RequestID is the id of the request, as printed in the daemon logs.
+	RequestID string `json:",omitempty"`
+	// UID and PID are the credentials of the requester.
+	UID uint32
+	PID int32
+	// Action is the polkit action checked for the requester.
+	Action    string
+	Operation string
+	Arguments map[string]string `json:",omitempty"`
+	Datasets  []DatasetChange   `json:",omitempty"`
+	Success   bool
+	// Error is the reason of a failed operation.
+	Error string `json:",omitempty"`
+}
+
+// DatasetChange is a dataset or snapshot affected by an operation.
+type DatasetChange struct {
+	Name   string
+	Change string
+}
+
+// Filter selects entries of the log.
+type Filter struct {
+	// Since only selects entries recorded from this time. Zero selects all entries.
+	Since time.Time
+	// User only selects entries requested by this user or acting on it.
+	User string
+}
+
+// Log is an append-only audit log.
+type Log struct {
+	path    string
+	journal bool
+
+	mu sync.Mutex
+}
+
+// WithoutJournal doesn't send entries to the journal.
+func WithoutJournal() func(*Log) {
+	return func(l *Log) {
+		l.journal = false
+	}
+}
+
+// New returns an audit log appending entries to path.
+func New(path string, options ...func(*Log)) *Log {
+	l := &Log{
+		path:    path,
+		journal: true,
+	}
+	for _, option := range options {
+		option(l)
+	}
+	return l
+}
+
+// Record appends e to the log file and sends it to the journal when available.
+func (l *Log) Record(ctx context.Context, e Entry) error {
+	log.Debugf(ctx, i18n.G("Recording %s in audit log"), e.Operation)
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't convert audit entry to json: %v"), err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf(i18n.G("couldn't create audit log directory: %v"), err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't open audit log: %v"), err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write to audit log: %v"), err)
+	}
+
+	if !l.journal || !journal.Enabled() {
+		return nil
+	}
+	if err := journal.Send(e.message(), journal.PriNotice, e.journalFields()); err != nil {
+		return fmt.Errorf(i18n.G("couldn't send audit entry to journal: %v"), err)
+	}
+	return nil
+}
+
+// Entries returns the entries of the log matching f, from the oldest to the most recent.
+// Lines which can't be read, like a partial line written on a crash, are skipped.
+func (l *Log) Entries(ctx context.Context, f Filter) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't open audit log: %v"), err)
+	}
+	defer file.Close()
+
+	uid := -1
+	if f.User != "" {
+		if u, err := user.Lookup(f.User); err == nil {
+			if id, err := strconv.Atoi(u.Uid); err == nil {
+				uid = id
+			}
+		}
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			log.Warningf(ctx, i18n.G("ignoring line %d of audit log: %v"), n, err)
+			continue
+		}
+		if e.Time.Before(f.Since) {
+			continue
+		}
+		if f.User != "" && (uid < 0 || e.UID != uint32(uid)) && e.Arguments["user"] != f.User {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't read audit log: %v"), err)
+	}
+	return entries, nil
+}
+
+// DatasetsChanges returns the datasets and snapshots created, destroyed or modified between before and after,
+// sorted by name.
+func DatasetsChanges(before, after map[string]zfs.DatasetProp) (changes []DatasetChange) {
+	for name, b := range before {
+		a, ok := after[name]
+		if !ok {
+			changes = append(changes, DatasetChange{Name: name, Change: DatasetDestroyed})
+		} else if !reflect.DeepEqual(a, b) {
+			changes = append(changes, DatasetChange{Name: name, Change: DatasetModified})
+		}
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			changes = append(changes, DatasetChange{Name: name, Change: DatasetCreated})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// message is the human readable summary of e sent to the journal.
+func (e Entry) message() string {
+	if !e.Success {
+		return fmt.Sprintf(i18n.G("%s requested by uid %d failed: %s"), e.Operation, e.UID, e.Error)
+	}
+	return fmt.Sprintf(i18n.G("%s requested by uid %d succeeded, %d dataset(s) affected"), e.Operation, e.UID, len(e.Datasets))
+}
+
+// journalFields returns the structured journal fields of e.
+func (e Entry) journalFields() map[string]string {
+	vars := map[string]string{
+		"ZSYS_AUDIT_OPERATION": e.Operation,
+		"ZSYS_AUDIT_UID":       strconv.FormatUint(uint64(e.UID), 10),
+		"ZSYS_AUDIT_PID":       strconv.FormatInt(int64(e.PID), 10),
+		"ZSYS_AUDIT_ACTION":    e.Action,
+		"ZSYS_AUDIT_SUCCESS":   strconv.FormatBool(e.Success),
+	}
+	if e.RequestID != "" {
+		vars["ZSYS_AUDIT_REQUEST_ID"] = e.RequestID
+	}
+	if e.Error != "" {
+		vars["ZSYS_AUDIT_ERROR"] = e.Error
+	}
+	if len(e.Datasets) > 0 {
+		var datasets []string
+		for _, d := range e.Datasets {
+			datasets = append(datasets, d.Change+" "+d.Name)
+		}
+		vars["ZSYS_AUDIT_DATASETS"] = strings.Join(datasets, "\n")
+	}
+	for k, v := range e.Arguments {
+		vars["ZSYS_AUDIT_ARG_"+strings.ToUpper(k)] = v
+	}
+	return vars
+}
@@ -0,0 +1,158 @@
+package audit_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/audit"
+	"github.com/ubuntu/zsys/internal/testutils"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	recorded := []audit.Entry{
+		{Time: start, UID: 0, PID: 10, Action: "com.ubuntu.zsys.system-write", Operation: "SaveSystemState",
+			Arguments: map[string]string{"state": "foo"},
+			Datasets:  []audit.DatasetChange{{Name: "rpool/ROOT/ubuntu_1234@foo", Change: audit.DatasetCreated}},
+			Success:   true},
+		{Time: start.Add(time.Hour), UID: 1000, PID: 20, Action: "com.ubuntu.zsys.user-write-self", Operation: "RemoveUserState",
+			Arguments: map[string]string{"user": "user1", "state": "bar"}, Error: "state bar not found"},
+		{Time: start.Add(2 * time.Hour), UID: 1000, PID: 30, Action: "always-allowed", Operation: "GC", Success: true},
+	}
+
+	tests := map[string]struct {
+		filter audit.Filter
+
+		want []audit.Entry
+	}{
+		"All entries":             {want: recorded},
+		"Since time":              {filter: audit.Filter{Since: start.Add(time.Hour)}, want: recorded[1:]},
+		"Since after all entries": {filter: audit.Filter{Since: start.Add(3 * time.Hour)}},
+		"Requested by user":       {filter: audit.Filter{User: "root"}, want: recorded[:1]},
+		"Acting on user":          {filter: audit.Filter{User: "user1"}, want: recorded[1:2]},
+		"Unknown user":            {filter: audit.Filter{User: "doesntexist"}},
+		"User and since":          {filter: audit.Filter{User: "user1", Since: start.Add(2 * time.Hour)}},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			l := audit.New(filepath.Join(dir, "zsys", "audit.log"), audit.WithoutJournal())
+			for _, e := range recorded {
+				if err := l.Record(context.Background(), e); err != nil {
+					t.Fatalf("expected no error recording entry but got: %v", err)
+				}
+			}
+
+			got, err := l.Entries(context.Background(), tc.filter)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			for i := range got {
+				got[i].Time = got[i].Time.UTC()
+			}
+			assert.Equal(t, tc.want, got, "Entries should match")
+		})
+	}
+}
+
+func TestEntriesSkipsInvalidLines(t *testing.T) {
+	t.Parallel()
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, "audit.log")
+	l := audit.New(path, audit.WithoutJournal())
+	if err := l.Record(context.Background(), audit.Entry{Operation: "GC", Success: true}); err != nil {
+		t.Fatalf("expected no error recording entry but got: %v", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("couldn't open audit log: %v", err)
+	}
+	if _, err := f.WriteString(`{"Operation": "Partial`); err != nil {
+		t.Fatalf("couldn't write partial entry: %v", err)
+	}
+	f.Close()
+
+	got, err := l.Entries(context.Background(), audit.Filter{})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	assert.Len(t, got, 1, "Only valid entries are returned")
+	assert.Equal(t, "GC", got[0].Operation, "Valid entry should be returned")
+}
+
+func TestEntriesWithoutLog(t *testing.T) {
+	t.Parallel()
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	got, err := audit.New(filepath.Join(dir, "audit.log"), audit.WithoutJournal()).Entries(context.Background(), audit.Filter{})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	assert.Empty(t, got, "No entries without log")
+}
+
+func TestRecordError(t *testing.T) {
+	t.Parallel()
+
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	// The log directory is a file.
+	notDir := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(notDir, nil, 0600); err != nil {
+		t.Fatalf("couldn't create file: %v", err)
+	}
+
+	err := audit.New(filepath.Join(notDir, "audit.log"), audit.WithoutJournal()).Record(context.Background(), audit.Entry{Operation: "GC"})
+	assert.Error(t, err, "Record should fail when the log can't be created")
+}
+
+func TestDatasetsChanges(t *testing.T) {
+	t.Parallel()
+
+	before := map[string]zfs.DatasetProp{
+		"rpool/ROOT/ubuntu_1234":           {Mountpoint: "/", CanMount: "on"},
+		"rpool/ROOT/ubuntu_1234@removed":   {},
+		"rpool/ROOT/ubuntu_1234@pinned":    {},
+		"rpool/USERDATA/user1_abcd":        {Mountpoint: "/home/user1", Labels: map[string]string{"a": "b"}},
+		"rpool/USERDATA/user1_abcd@same":   {Description: "same"},
+		"rpool/USERDATA/user1_abcd@labels": {Labels: map[string]string{"a": "b"}},
+	}
+	after := map[string]zfs.DatasetProp{
+		"rpool/ROOT/ubuntu_1234":           {Mountpoint: "/", CanMount: "on"},
+		"rpool/ROOT/ubuntu_1234@pinned":    {Pinned: true},
+		"rpool/ROOT/ubuntu_1234@created":   {},
+		"rpool/USERDATA/user1_abcd":        {Mountpoint: "/home/user1", Labels: map[string]string{"a": "b"}},
+		"rpool/USERDATA/user1_abcd@same":   {Description: "same"},
+		"rpool/USERDATA/user1_abcd@labels": {Labels: map[string]string{"a": "c"}},
+	}
+
+	assert.Equal(t, []audit.DatasetChange{
+		{Name: "rpool/ROOT/ubuntu_1234@created", Change: audit.DatasetCreated},
+		{Name: "rpool/ROOT/ubuntu_1234@pinned", Change: audit.DatasetModified},
+		{Name: "rpool/ROOT/ubuntu_1234@removed", Change: audit.DatasetDestroyed},
+		{Name: "rpool/USERDATA/user1_abcd@labels", Change: audit.DatasetModified},
+	}, audit.DatasetsChanges(before, after), "Changes should match")
+
+	assert.Empty(t, audit.DatasetsChanges(before, before), "No changes on identical datasets")
+}
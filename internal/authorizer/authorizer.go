@@ -159,6 +159,39 @@ func (a Authorizer) IsAllowedFromContext(ctx context.Context, action Action) (er
 	return a.isAllowed(ctx, action, pci.pid, pci.uid, actionUID)
 }
 
+// PolkitAction returns the polkit action checked for the caller of ctx to perform action.
+// ActionUserWrite is converted to the self or others action, depending on the user attached to ctx.
+func (a Authorizer) PolkitAction(ctx context.Context, action Action) Action {
+	if action != ActionUserWrite {
+		return action
+	}
+	uid, _, ok := PeerCredsFromContext(ctx)
+	if !ok {
+		return action
+	}
+	userName, ok := ctx.Value(OnUserKey).(string)
+	if !ok {
+		return action
+	}
+	u, err := a.userLookup(userName)
+	if err != nil {
+		return action
+	}
+	actionUID, err := strconv.Atoi(u.Uid)
+	if err != nil {
+		return action
+	}
+	return userWriteAction(uid, uint32(actionUID))
+}
+
+// userWriteAction returns the polkit action for uid to write on datasets of the user with actionUID.
+func userWriteAction(uid, actionUID uint32) Action {
+	if actionUID == uid {
+		return actionUserWriteSelf
+	}
+	return actionUserWriteOthers
+}
+
 // isAllowed returns nil if the user is allowed to perform an operation.
 // ActionUID is only used for ActionUserWrite which will be converted to corresponding polkit action
 // (self or others)
@@ -170,10 +203,7 @@ func (a Authorizer) isAllowed(ctx context.Context, action Action, pid int32, uid
 		log.Debug(ctx, i18n.G("Any user always authorized"))
 		return nil
 	} else if action == ActionUserWrite {
-		action = actionUserWriteOthers
-		if actionUID == uid {
-			action = actionUserWriteSelf
-		}
+		action = userWriteAction(uid, actionUID)
 	}
 
 	f, err := os.Open(filepath.Join(a.root, fmt.Sprintf("proc/%d/stat", pid)))
@@ -110,6 +110,37 @@ func TestIsAllowedFromContextWithInvalidPeerCreds(t *testing.T) {
 	assert.Equal(t, false, errAllowed == nil, "IsAllowedFromContext must deny with an unexpected peer creds info type")
 }
 
+func TestIsAllowedFromContextWithPeerCreds(t *testing.T) {
+	t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		uid uint32
+
+		wantAuthorized bool
+	}{
+		"Root is authorized":          {uid: 0, wantAuthorized: true},
+		"Polkit decides for any user": {uid: 1000, wantAuthorized: false},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &authorizer.DbusMock{IsAuthorized: tc.wantAuthorized}
+			a, err := authorizer.New(authorizer.WithAuthority(d), authorizer.WithRoot("testdata"))
+			if err != nil {
+				t.Fatalf("Failed to create authorizer: %v", err)
+			}
+
+			ctx := authorizer.ContextWithPeerCreds(context.Background(), tc.uid, 10000)
+			errAllowed := a.IsAllowedFromContext(ctx, authorizer.ActionSystemWrite)
+
+			assert.Equal(t, tc.wantAuthorized, errAllowed == nil, "IsAllowedFromContext returned state match expectations")
+		})
+	}
+}
+
 func TestIsAllowedFromContextWithoutUserKey(t *testing.T) {
 	t.Parallel()
 	defer testutils.StartLocalSystemBus(t)()
@@ -131,3 +162,53 @@ func TestIsAllowedFromContextWithoutUserKey(t *testing.T) {
 type invalidPeerCredsInfo struct{}
 
 func (invalidPeerCredsInfo) AuthType() string { return "" }
+
+func TestPolkitAction(t *testing.T) {
+	t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		action   authorizer.Action
+		noPeer   bool
+		noUser   bool
+		userUID  string
+		lookupKO bool
+
+		want authorizer.Action
+	}{
+		"System action is unchanged":       {action: authorizer.ActionSystemWrite, want: authorizer.ActionSystemWrite},
+		"User action on own user":          {action: authorizer.ActionUserWrite, userUID: "1000", want: "com.ubuntu.zsys.user-write-self"},
+		"User action on other user":        {action: authorizer.ActionUserWrite, userUID: "999", want: "com.ubuntu.zsys.user-write-others"},
+		"User action without peer":         {action: authorizer.ActionUserWrite, noPeer: true, want: authorizer.ActionUserWrite},
+		"User action without user":         {action: authorizer.ActionUserWrite, noUser: true, want: authorizer.ActionUserWrite},
+		"User action with unknown user":    {action: authorizer.ActionUserWrite, lookupKO: true, want: authorizer.ActionUserWrite},
+		"User action with user invalid id": {action: authorizer.ActionUserWrite, userUID: "NaN", want: authorizer.ActionUserWrite},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			userLookup := func(string) (*user.User, error) {
+				if tc.lookupKO {
+					return nil, errors.New("User error requested")
+				}
+				return &user.User{Uid: tc.userUID}, nil
+			}
+			a, err := authorizer.New(authorizer.WithAuthority(&authorizer.DbusMock{}), authorizer.WithUserLookup(userLookup))
+			if err != nil {
+				t.Fatalf("Failed to create authorizer: %v", err)
+			}
+
+			ctx := context.Background()
+			if !tc.noPeer {
+				ctx = authorizer.ContextWithPeerCreds(ctx, 1000, 10000)
+			}
+			if !tc.noUser {
+				ctx = context.WithValue(ctx, authorizer.OnUserKey, "foo")
+			}
+
+			assert.Equal(t, tc.want, a.PolkitAction(ctx, tc.action), "PolkitAction returns expected action")
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 // WithUnixPeerCreds returns the credentials of the caller
@@ -59,6 +60,12 @@ func (serverPeerCreds) Info() credentials.ProtocolInfo          { return credent
 func (serverPeerCreds) Clone() credentials.TransportCredentials { return nil }
 func (serverPeerCreds) OverrideServerName(s string) error       { return nil }
 
+// ContextWithPeerCreds attaches uid and pid of a caller which isn't connected to the grpc socket, like a D-Bus client,
+// so that its requests are authorized with IsAllowedFromContext.
+func ContextWithPeerCreds(ctx context.Context, uid uint32, pid int32) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: peerCredsInfo{uid: uid, pid: pid}})
+}
+
 type peerCredsInfo struct {
 	uid uint32
 	pid int32
@@ -68,3 +75,16 @@ type peerCredsInfo struct {
 func (p peerCredsInfo) AuthType() string {
 	return fmt.Sprintf("uid: %d, pid: %d", p.uid, p.pid)
 }
+
+// PeerCredsFromContext returns uid and pid of the caller attached to ctx.
+func PeerCredsFromContext(ctx context.Context) (uid uint32, pid int32, ok bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return 0, 0, false
+	}
+	pci, ok := p.AuthInfo.(peerCredsInfo)
+	if !ok {
+		return 0, 0, false
+	}
+	return pci.uid, pci.pid, true
+}
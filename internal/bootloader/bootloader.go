@@ -0,0 +1,107 @@
+// Package bootloader handles the various bootloaders zsys can generate menu entries and select next boot for.
+package bootloader
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+const (
+	// Grub is the backend name for GRUB
+	Grub = config.BootloaderGrub
+	// SystemdBoot is the backend name for systemd-boot
+	SystemdBoot = config.BootloaderSystemdBoot
+	// ZFSBootMenu is the backend name for ZFSBootMenu
+	ZFSBootMenu = config.BootloaderZFSBootMenu
+
+	defaultESP = "/boot/efi"
+)
+
+// Backend is a bootloader able to boot on zsys system states.
+type Backend interface {
+	// GenerateMenu regenerates the boot menu with states.
+	GenerateMenu(ctx context.Context, states []State) error
+	// SetNextBoot selects the system state stateID for next boot only.
+	// revertUserData requests to revert user datasets attached to this state as well.
+	// Backends without one-shot selection, like ZFSBootMenu, keep stateID selected for all subsequent boots.
+	SetNextBoot(ctx context.Context, stateID string, revertUserData bool) error
+	// Entries lists the current boot menu entries.
+	Entries(ctx context.Context) ([]Entry, error)
+}
+
+// State is a bootable system state.
+type State struct {
+	// ID is the path to the root system dataset for this State.
+	ID string
+	// Kernel is the last kernel this state was booted with.
+	Kernel string
+	// LastUsed is the last time this state was used.
+	LastUsed time.Time
+}
+
+// Entry is a boot menu entry.
+type Entry struct {
+	// ID is the bootloader identifier to select this entry.
+	ID string
+	// Title is the displayed entry name.
+	Title string
+}
+
+// New returns the bootloader backend selected in configuration. GRUB is used if none is set.
+func New(conf config.ZConfig) (Backend, error) {
+	switch conf.Bootloader.Backend {
+	case "", Grub:
+		return grub{cfgPath: grubCfgPath}, nil
+	case SystemdBoot:
+		esp := conf.Bootloader.ESP
+		if esp == "" {
+			esp = defaultESP
+		}
+		return systemdBoot{esp: esp}, nil
+	case ZFSBootMenu:
+		return zfsBootMenu{}, nil
+	}
+	return nil, fmt.Errorf(i18n.G("unknown bootloader backend %q"), conf.Bootloader.Backend)
+}
+
+// execCommand is the command runner. It can be overridden in tests.
+var execCommand = exec.CommandContext
+
+// run executes name with args, redirecting its output to debug logs.
+func run(ctx context.Context, name string, args ...string) error {
+	cmd := execCommand(ctx, name, args...)
+	logger := &logWriter{ctx: ctx}
+	cmd.Stdout = logger
+	cmd.Stderr = logger
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf(i18n.G("%q returned an error: ")+config.ErrorFormat, strings.Join(append([]string{name}, args...), " "), err)
+	}
+	return nil
+}
+
+// output executes name with args and returns its standard output.
+func output(ctx context.Context, name string, args ...string) (string, error) {
+	cmd := execCommand(ctx, name, args...)
+	cmd.Stderr = &logWriter{ctx: ctx}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf(i18n.G("%q returned an error: ")+config.ErrorFormat, strings.Join(append([]string{name}, args...), " "), err)
+	}
+	return string(out), nil
+}
+
+type logWriter struct {
+	ctx context.Context
+}
+
+func (lw logWriter) Write(p []byte) (n int, err error) {
+	log.Debug(lw.ctx, string(p))
+	return len(p), nil
+}
@@ -0,0 +1,217 @@
+package bootloader_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/bootloader"
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		backend string
+
+		wantErr bool
+	}{
+		"Default to grub": {},
+		"Grub":            {backend: "grub"},
+		"Systemd-boot":    {backend: "systemd-boot"},
+		"ZFSBootMenu":     {backend: "zfsbootmenu"},
+
+		"Error on unknown backend": {backend: "lilo", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var c config.ZConfig
+			c.Bootloader.Backend = tc.backend
+
+			b, err := bootloader.New(c)
+			if tc.wantErr {
+				assert.Error(t, err, "New should have failed")
+				return
+			}
+			assert.NoError(t, err, "New shouldn't have failed")
+			assert.NotNil(t, b, "New should return a backend")
+		})
+	}
+}
+
+func TestGrubEntries(t *testing.T) {
+	t.Parallel()
+
+	b := bootloader.NewGrub(filepath.Join("testdata", "grub.cfg"))
+	entries, err := b.Entries(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	want := []bootloader.Entry{
+		{ID: "gnulinux-rpool/ROOT/ubuntu_1234-5.4.0-24-generic", Title: "Ubuntu 20.04 LTS"},
+		{ID: "gnulinux-advanced-rpool/ROOT/ubuntu_1234>gnulinux-rpool/ROOT/ubuntu_1234-5.4.0-24-generic-recovery", Title: "Ubuntu 20.04 LTS, with Linux 5.4.0-24-generic (recovery mode)"},
+		{ID: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@snap1>gnulinux-rpool/ROOT/ubuntu_1234@snap1-5.4.0-24-generic", Title: "Revert system only"},
+		{ID: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@snap1>gnulinux-rpool/ROOT/ubuntu_1234@snap1-5.4.0-24-generic-userdata", Title: "Revert system and user data"},
+		{ID: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-rpool/ROOT/ubuntu_5678-5.4.0-21-generic", Title: "Ubuntu 20.04 LTS (rpool/ROOT/ubuntu_5678)"},
+	}
+	assert.Equal(t, want, entries, "GRUB entries")
+}
+
+func TestGrubSetNextBoot(t *testing.T) {
+	tests := map[string]struct {
+		cfg            string
+		stateID        string
+		revertUserData bool
+
+		wantEntry string
+		wantErr   bool
+	}{
+		"Main state":                     {stateID: "rpool/ROOT/ubuntu_1234", wantEntry: "gnulinux-rpool/ROOT/ubuntu_1234-5.4.0-24-generic"},
+		"Snapshot":                       {stateID: "rpool/ROOT/ubuntu_1234@snap1", wantEntry: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@snap1>gnulinux-rpool/ROOT/ubuntu_1234@snap1-5.4.0-24-generic"},
+		"Snapshot with user data revert": {stateID: "rpool/ROOT/ubuntu_1234@snap1", revertUserData: true, wantEntry: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-history-rpool/ROOT/ubuntu_1234@snap1>gnulinux-rpool/ROOT/ubuntu_1234@snap1-5.4.0-24-generic-userdata"},
+		"Clone":                          {stateID: "rpool/ROOT/ubuntu_5678", wantEntry: "gnulinux-history-rpool/ROOT/ubuntu_1234>gnulinux-rpool/ROOT/ubuntu_5678-5.4.0-21-generic"},
+
+		"Error on unknown state":             {stateID: "rpool/ROOT/ubuntu_9999", wantErr: true},
+		"Error on no user data revert entry": {stateID: "rpool/ROOT/ubuntu_1234", revertUserData: true, wantErr: true},
+		"Error on missing grub config file":  {cfg: "doesntexist.cfg", stateID: "rpool/ROOT/ubuntu_1234", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls [][]string
+			defer bootloader.WithFakeCommands(&calls)()
+
+			cfg := tc.cfg
+			if cfg == "" {
+				cfg = "grub.cfg"
+			}
+			b := bootloader.NewGrub(filepath.Join("testdata", cfg))
+
+			err := b.SetNextBoot(context.Background(), tc.stateID, tc.revertUserData)
+			if tc.wantErr {
+				assert.Error(t, err, "SetNextBoot should have failed")
+				assert.Empty(t, calls, "no command should have been run")
+				return
+			}
+			assert.NoError(t, err, "SetNextBoot shouldn't have failed")
+			assert.Equal(t, [][]string{{"grub-reboot", tc.wantEntry}}, calls, "grub-reboot call")
+		})
+	}
+}
+
+func TestSystemdBoot(t *testing.T) {
+	var calls [][]string
+	defer bootloader.WithFakeCommands(&calls)()
+
+	esp, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	var c config.ZConfig
+	c.Bootloader.Backend = bootloader.SystemdBoot
+	c.Bootloader.ESP = esp
+	b, err := bootloader.New(c)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	states := []bootloader.State{
+		{ID: "rpool/ROOT/ubuntu_1234", Kernel: "vmlinuz-5.4.0-24-generic"},
+		{ID: "rpool/ROOT/ubuntu_1234@snap1", Kernel: "vmlinuz-5.4.0-21-generic", LastUsed: time.Date(2020, 4, 18, 10, 0, 0, 0, time.UTC)},
+		{ID: "rpool/ROOT/ubuntu_5678"},
+	}
+	// Regenerating the menu twice replaces previous entries
+	for i := 0; i < 2; i++ {
+		if err := b.GenerateMenu(context.Background(), states); err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+	}
+
+	entries, err := b.Entries(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	assert.Equal(t, []bootloader.Entry{
+		{ID: "zsys-rpool_ROOT_ubuntu_1234-snap1", Title: "rpool/ROOT/ubuntu_1234@snap1 (last used 2020-04-18 10:00)"},
+		{ID: "zsys-rpool_ROOT_ubuntu_1234", Title: "rpool/ROOT/ubuntu_1234"},
+		{ID: "zsys-rpool_ROOT_ubuntu_5678", Title: "rpool/ROOT/ubuntu_5678"},
+	}, entries, "systemd-boot entries")
+
+	content, err := ioutil.ReadFile(filepath.Join(esp, "loader", "entries", "zsys-rpool_ROOT_ubuntu_1234-snap1.conf"))
+	if err != nil {
+		t.Fatalf("couldn't read entry: %v", err)
+	}
+	assert.Equal(t, `title   rpool/ROOT/ubuntu_1234@snap1 (last used 2020-04-18 10:00)
+linux   /vmlinuz-5.4.0-21-generic
+initrd  /initrd.img-5.4.0-21-generic
+options root=ZFS=rpool/ROOT/ubuntu_1234@snap1 ro
+`, string(content), "entry content")
+
+	// Select next boot
+	if err := b.SetNextBoot(context.Background(), "rpool/ROOT/ubuntu_5678", false); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if err := b.SetNextBoot(context.Background(), "rpool/ROOT/ubuntu_1234@snap1", true); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	assert.Error(t, b.SetNextBoot(context.Background(), "rpool/ROOT/ubuntu_9999", false), "SetNextBoot on unknown state should fail")
+	assert.Equal(t, [][]string{
+		{"bootctl", "--esp-path=" + esp, "set-oneshot", "zsys-rpool_ROOT_ubuntu_5678.conf"},
+		{"bootctl", "--esp-path=" + esp, "set-oneshot", "zsys-next-boot.conf"},
+	}, calls, "bootctl calls")
+
+	content, err = ioutil.ReadFile(filepath.Join(esp, "loader", "entries", "zsys-next-boot.conf"))
+	if err != nil {
+		t.Fatalf("couldn't read entry: %v", err)
+	}
+	assert.Equal(t, `title   rpool/ROOT/ubuntu_1234@snap1 (revert user data)
+linux   /vmlinuz-5.4.0-21-generic
+initrd  /initrd.img-5.4.0-21-generic
+options root=ZFS=rpool/ROOT/ubuntu_1234@snap1 ro zsys-revert=userdata
+`, string(content), "one shot entry content")
+}
+
+func TestZFSBootMenuSetNextBoot(t *testing.T) {
+	tests := map[string]struct {
+		stateID        string
+		revertUserData bool
+
+		wantCalls [][]string
+		wantErr   bool
+	}{
+		"Clone": {stateID: "rpool/ROOT/ubuntu_5678", wantCalls: [][]string{{"zpool", "set", "bootfs=rpool/ROOT/ubuntu_5678", "rpool"}}},
+
+		"Error on snapshot":            {stateID: "rpool/ROOT/ubuntu_1234@snap1", wantErr: true},
+		"Error on reverting user data": {stateID: "rpool/ROOT/ubuntu_5678", revertUserData: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls [][]string
+			defer bootloader.WithFakeCommands(&calls)()
+
+			var c config.ZConfig
+			c.Bootloader.Backend = bootloader.ZFSBootMenu
+			b, err := bootloader.New(c)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			err = b.SetNextBoot(context.Background(), tc.stateID, tc.revertUserData)
+			assert.Equal(t, tc.wantCalls, calls, "zpool calls")
+			if tc.wantErr {
+				assert.Error(t, err, "SetNextBoot should have failed")
+				return
+			}
+			assert.NoError(t, err, "SetNextBoot shouldn't have failed")
+		})
+	}
+}
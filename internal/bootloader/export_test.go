@@ -0,0 +1,31 @@
+package bootloader
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// NewGrub returns a GRUB backend reading its menu from cfgPath.
+func NewGrub(cfgPath string) Backend {
+	return grub{cfgPath: cfgPath}
+}
+
+// WithFakeCommands replaces all external commands by a no-op one, recording their arguments.
+// It returns a function restoring the original command runner.
+func WithFakeCommands(calls *[][]string) func() {
+	return WithFakeCommandsOutput(calls, nil)
+}
+
+// WithFakeCommandsOutput replaces all external commands by a fake one, recording their arguments.
+// Commands print the value of outputs matching their space separated command line.
+// It returns a function restoring the original command runner.
+func WithFakeCommandsOutput(calls *[][]string, outputs map[string]string) func() {
+	orig := execCommand
+	execCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		cmd := append([]string{name}, args...)
+		*calls = append(*calls, cmd)
+		return exec.CommandContext(ctx, "printf", "%s", outputs[strings.Join(cmd, " ")])
+	}
+	return func() { execCommand = orig }
+}
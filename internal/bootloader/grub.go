@@ -0,0 +1,158 @@
+package bootloader
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+const (
+	grubCfgPath      = "/boot/grub/grub.cfg"
+	updateGrubCmd    = "update-grub"
+	grubRebootCmd    = "grub-reboot"
+	grubEntryIDDelim = ">"
+	// grubUserDataEntrySuffix ends the ID of entries reverting user data along with the system.
+	grubUserDataEntrySuffix = "-userdata"
+)
+
+// grub generates its menu with update-grub, which lists zsys states through its own zfs script.
+type grub struct {
+	cfgPath string
+}
+
+// GenerateMenu regenerates the grub menu. States are discovered by grub scripts themselves.
+func (g grub) GenerateMenu(ctx context.Context, states []State) error {
+	log.RemotePrintln(ctx, i18n.G("ZSys is adding automatic system snapshot to GRUB menu"))
+	return run(ctx, updateGrubCmd)
+}
+
+// SetNextBoot selects the first grub entry booting on stateID with grub-reboot.
+// This requires GRUB_DEFAULT=saved in grub configuration.
+func (g grub) SetNextBoot(ctx context.Context, stateID string, revertUserData bool) error {
+	entries, err := g.Entries(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Match the entry itself, not the submenus it belongs to.
+	var selected *Entry
+	for i, e := range entries {
+		leaf := e.ID[strings.LastIndex(e.ID, grubEntryIDDelim)+1:]
+		if entryMatchesState(leaf, stateID) && strings.HasSuffix(leaf, grubUserDataEntrySuffix) == revertUserData {
+			selected = &entries[i]
+			break
+		}
+	}
+	if selected == nil {
+		if revertUserData {
+			return fmt.Errorf(i18n.G("couldn't find any GRUB entry for %q reverting user data"), stateID)
+		}
+		return fmt.Errorf(i18n.G("couldn't find any GRUB entry for %q"), stateID)
+	}
+
+	log.Infof(ctx, i18n.G("Selecting GRUB entry %q for next boot"), selected.Title)
+	return run(ctx, grubRebootCmd, selected.ID)
+}
+
+// Entries lists menu entries from grub configuration file. Entries in submenus are prefixed by their parent ID,
+// as expected by grub-reboot.
+func (g grub) Entries(ctx context.Context) ([]Entry, error) {
+	f, err := os.Open(g.cfgPath)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't open GRUB configuration: %v"), err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	// parents is the stack of opened blocks, with their IDs if they are submenus.
+	var parents []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if fields[0] == "}" {
+			if len(parents) > 0 {
+				parents = parents[:len(parents)-1]
+			}
+			continue
+		}
+
+		if fields[0] != "menuentry" && fields[0] != "submenu" {
+			if strings.HasSuffix(line, "{") {
+				parents = append(parents, "")
+			}
+			continue
+		}
+
+		quoted := quotedStrings(line)
+		if len(quoted) == 0 {
+			continue
+		}
+		title, id := quoted[0], quoted[0]
+		if len(quoted) > 1 {
+			id = quoted[len(quoted)-1]
+		}
+
+		var path []string
+		for _, p := range parents {
+			if p != "" {
+				path = append(path, p)
+			}
+		}
+		path = append(path, id)
+
+		if fields[0] == "submenu" {
+			parents = append(parents, id)
+			continue
+		}
+		entries = append(entries, Entry{ID: strings.Join(path, grubEntryIDDelim), Title: title})
+		parents = append(parents, "")
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't read GRUB configuration: %v"), err)
+	}
+
+	return entries, nil
+}
+
+// quotedStrings returns all single quoted strings in line.
+func quotedStrings(line string) []string {
+	var r []string
+	for {
+		start := strings.Index(line, "'")
+		if start == -1 {
+			return r
+		}
+		end := strings.Index(line[start+1:], "'")
+		if end == -1 {
+			return r
+		}
+		r = append(r, line[start+1:start+1+end])
+		line = line[start+end+2:]
+	}
+}
+
+// entryMatchesState returns if the entry ID refers to stateID, and not only to one of its snapshots or children.
+func entryMatchesState(id, stateID string) bool {
+	for i := strings.Index(id, stateID); i != -1; {
+		end := i + len(stateID)
+		if end == len(id) || !strings.ContainsAny(id[end:end+1], "@/") {
+			return true
+		}
+		next := strings.Index(id[end:], stateID)
+		if next == -1 {
+			return false
+		}
+		i = end + next
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package bootloader
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+const (
+	bootctlCmd = "bootctl"
+
+	// zsysEntryPrefix is the prefix of all boot loader entries files managed by zsys.
+	zsysEntryPrefix = "zsys-"
+	// oneShotEntry is the entry name used for next boot when user data needs to be reverted as well.
+	oneShotEntry = zsysEntryPrefix + "next-boot"
+)
+
+// systemdBoot writes Boot Loader Specification entries in the EFI system partition.
+// Kernels and initrds are expected to be installed at the root of the ESP.
+type systemdBoot struct {
+	esp string
+}
+
+func (b systemdBoot) entriesDir() string {
+	return filepath.Join(b.esp, "loader", "entries")
+}
+
+// GenerateMenu replaces all zsys boot loader entries by one per state.
+func (b systemdBoot) GenerateMenu(ctx context.Context, states []State) error {
+	log.RemotePrintln(ctx, i18n.G("ZSys is adding automatic system snapshot to systemd-boot menu"))
+
+	dir := b.entriesDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf(i18n.G("couldn't create boot loader entries directory: %v"), err)
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, zsysEntryPrefix+"*.conf"))
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't list existing boot loader entries: %v"), err)
+	}
+	for _, p := range existing {
+		if err := os.Remove(p); err != nil {
+			return fmt.Errorf(i18n.G("couldn't remove old boot loader entry: %v"), err)
+		}
+	}
+
+	for _, s := range states {
+		title := s.ID
+		if !s.LastUsed.IsZero() {
+			title = fmt.Sprintf(i18n.G("%s (last used %s)"), s.ID, s.LastUsed.Format("2006-01-02 15:04"))
+		}
+		if err := b.writeEntry(entryName(s.ID), title, s, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetNextBoot selects the entry for stateID on next boot with bootctl.
+// A dedicated entry is written if user data needs to be reverted too.
+func (b systemdBoot) SetNextBoot(ctx context.Context, stateID string, revertUserData bool) error {
+	name := entryName(stateID)
+	if revertUserData {
+		s, err := b.readEntry(name)
+		if err != nil {
+			return fmt.Errorf(i18n.G("couldn't find boot loader entry for %q: %v"), stateID, err)
+		}
+		title := fmt.Sprintf(i18n.G("%s (revert user data)"), stateID)
+		if err := b.writeEntry(oneShotEntry, title, s, true); err != nil {
+			return err
+		}
+		name = oneShotEntry
+	} else if _, err := os.Stat(filepath.Join(b.entriesDir(), name+".conf")); err != nil {
+		return fmt.Errorf(i18n.G("couldn't find boot loader entry for %q: %v"), stateID, err)
+	}
+
+	log.Infof(ctx, i18n.G("Selecting boot loader entry %q for next boot"), name)
+	return run(ctx, bootctlCmd, "--esp-path="+b.esp, "set-oneshot", name+".conf")
+}
+
+// Entries lists zsys boot loader entries.
+func (b systemdBoot) Entries(ctx context.Context) ([]Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(b.entriesDir(), zsysEntryPrefix+"*.conf"))
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't list boot loader entries: %v"), err)
+	}
+
+	var entries []Entry
+	for _, p := range paths {
+		id := strings.TrimSuffix(filepath.Base(p), ".conf")
+		title, err := readEntryKey(p, "title")
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{ID: id, Title: title})
+	}
+	return entries, nil
+}
+
+// writeEntry writes a boot loader entry named name booting on s.
+func (b systemdBoot) writeEntry(name, title string, s State, revertUserData bool) error {
+	linux, initrd := "/vmlinuz", "/initrd.img"
+	if s.Kernel != "" {
+		linux = "/" + s.Kernel
+		initrd = "/" + strings.Replace(s.Kernel, "vmlinuz", "initrd.img", 1)
+	}
+	options := "root=ZFS=" + s.ID + " ro"
+	if revertUserData {
+		options += " zsys-revert=userdata"
+	}
+
+	content := fmt.Sprintf("title   %s\nlinux   %s\ninitrd  %s\noptions %s\n", title, linux, initrd, options)
+	p := filepath.Join(b.entriesDir(), name+".conf")
+	if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write boot loader entry %q: %v"), p, err)
+	}
+	return nil
+}
+
+// readEntry returns the state a zsys boot loader entry boots on.
+func (b systemdBoot) readEntry(name string) (State, error) {
+	p := filepath.Join(b.entriesDir(), name+".conf")
+	linux, err := readEntryKey(p, "linux")
+	if err != nil {
+		return State{}, err
+	}
+	options, err := readEntryKey(p, "options")
+	if err != nil {
+		return State{}, err
+	}
+
+	var s State
+	if linux != "/vmlinuz" {
+		s.Kernel = strings.TrimPrefix(linux, "/")
+	}
+	for _, o := range strings.Fields(options) {
+		if strings.HasPrefix(o, "root=ZFS=") {
+			s.ID = strings.TrimPrefix(o, "root=ZFS=")
+		}
+	}
+	return s, nil
+}
+
+// readEntryKey returns the value of key in boot loader entry file p.
+func readEntryKey(p, key string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf(i18n.G("couldn't open boot loader entry: %v"), err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.SplitN(strings.TrimSpace(scanner.Text()), " ", 2)
+		if fields[0] == key && len(fields) == 2 {
+			return strings.TrimSpace(fields[1]), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf(i18n.G("couldn't read boot loader entry: %v"), err)
+	}
+	return "", nil
+}
+
+// entryName returns the boot loader entry name for stateID.
+func entryName(stateID string) string {
+	return zsysEntryPrefix + strings.NewReplacer("/", "_", "@", "-").Replace(stateID)
+}
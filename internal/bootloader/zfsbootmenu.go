@@ -0,0 +1,69 @@
+package bootloader
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+const (
+	zfsCmd   = "zfs"
+	zpoolCmd = "zpool"
+
+	zbmKernelProp = "org.zfsbootmenu:kernel"
+)
+
+// zfsBootMenu discovers boot environments itself. We only set its properties on system datasets.
+type zfsBootMenu struct{}
+
+// GenerateMenu pins the kernel ZFSBootMenu boots each filesystem state with.
+// Snapshots are listed and cloned by ZFSBootMenu itself.
+func (zfsBootMenu) GenerateMenu(ctx context.Context, states []State) error {
+	for _, s := range states {
+		if strings.Contains(s.ID, "@") || s.Kernel == "" {
+			continue
+		}
+		if err := run(ctx, zfsCmd, "set", fmt.Sprintf("%s=%s", zbmKernelProp, s.Kernel), s.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetNextBoot sets stateID as the pool default boot environment.
+// ZFSBootMenu has no one-shot selection: stateID stays the default boot environment until another state is selected.
+// A snapshot can't be booted without ZFSBootMenu cloning it interactively, so only filesystem states are accepted.
+// Reverting user data isn't supported, as ZFSBootMenu doesn't let us pass the revert tag to the next boot only.
+func (zfsBootMenu) SetNextBoot(ctx context.Context, stateID string, revertUserData bool) error {
+	if revertUserData {
+		return fmt.Errorf(i18n.G("ZFSBootMenu can't revert user data on next boot, select %q in its menu instead"), stateID)
+	}
+	if strings.Contains(stateID, "@") {
+		return fmt.Errorf(i18n.G("ZFSBootMenu can't boot directly on snapshot %q, select it in its menu to clone it"), stateID)
+	}
+
+	log.Infof(ctx, i18n.G("Selecting %q as ZFSBootMenu default boot environment"), stateID)
+	pool := strings.SplitN(stateID, "/", 2)[0]
+	return run(ctx, zpoolCmd, "set", "bootfs="+stateID, pool)
+}
+
+// Entries lists datasets ZFSBootMenu has a kernel set on.
+func (zfsBootMenu) Entries(ctx context.Context) ([]Entry, error) {
+	out, err := output(ctx, zfsCmd, "get", "-H", "-o", "name,value", "-s", "local", "-t", "filesystem", zbmKernelProp)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, l := range strings.Split(out, "\n") {
+		fields := strings.Fields(l)
+		if len(fields) < 2 {
+			continue
+		}
+		entries = append(entries, Entry{ID: fields[0], Title: fmt.Sprintf("%s (%s)", fields[0], fields[1])})
+	}
+	return entries, nil
+}
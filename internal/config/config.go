@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
@@ -24,24 +25,262 @@ var ErrorFormat = "%v"
 
 // ZConfig stores the configuration of zsys
 type ZConfig struct {
-	History HistoryRules
-	General struct {
+	History  HistoryConfig
+	GC       GCRules
+	Schedule []ScheduleRule
+	General  struct {
 		Timeout          int
 		MinFreePoolSpace int
 	}
-	Path string
+	Bootloader struct {
+		Backend string
+		ESP     string
+	}
+	Hooks struct {
+		Dir     string
+		Timeout int
+	}
+	Pools    PoolsRules
+	UserData UserDataRules
+	Path     string
+	// DropIns are the configuration fragments merged over the file at Path, in order.
+	DropIns []string `yaml:"-"`
+}
+
+// PoolsRules restrict which pools hold zsys containers. Any pool is considered for an empty list.
+type PoolsRules struct {
+	// Root are the pools holding system datasets, in their ROOT container.
+	Root []string
+	// Boot are the pools holding boot datasets, in their BOOT container.
+	Boot []string
+	// UserData are the pools holding user datasets, in their USERDATA container.
+	// New user datasets are created on the first one when none exists yet.
+	UserData []string
+}
+
+// UserDataRules are the defaults applied to new user datasets
+type UserDataRules struct {
+	// Encryption creates new user datasets as their own encryption root.
+	Encryption bool
+	// Quota is the maximum space, in MiB, a user dataset can reference. 0 disables it.
+	Quota int
+	// Reservation is the space, in MiB, guaranteed to a user dataset. 0 disables it.
+	Reservation int
+}
+
+// HistoryConfig store the history rules of system states and user states.
+// Rules set at the top level apply to system states, user states default to the system ones and each user
+// defaults to the user states ones. Any rule element not set in a scope is inherited from its default.
+type HistoryConfig struct {
+	// System are the rules for system states.
+	System HistoryRules
+	// Users are the rules for user states.
+	Users HistoryRules
+	// PerUser are the rules overriding Users for some users, by user name.
+	PerUser map[string]HistoryRules
 }
 
 // HistoryRules store the rules for each GC element
 type HistoryRules struct {
+	// Strategy is how states are retained: StrategyBuckets, the default, StrategyCalendar, StrategyMaxAge or StrategyMaxCount.
+	Strategy     string
 	GCStartAfter int64
 	KeepLast     int
-	GCRules      []struct {
-		Name             string
-		Buckets          int
-		BucketLength     int64
-		SamplesPerBucket int
+	GCRules      []HistoryGCRule
+	// Calendar are the periods keeping their newest state, for the calendar strategy.
+	Calendar CalendarRules
+	// MaxAge is the age, in days, above which states are removed, for the maxage strategy.
+	MaxAge int64
+	// MaxCount is the number of most recent states kept, for the maxcount strategy.
+	MaxCount int
+}
+
+const (
+	// StrategyBuckets keeps samples of states in buckets sliding with current time, defined by GCRules.
+	StrategyBuckets = "buckets"
+	// StrategyCalendar keeps the newest state of each local day, week and month.
+	StrategyCalendar = "calendar"
+	// StrategyMaxAge removes states older than MaxAge.
+	StrategyMaxAge = "maxage"
+	// StrategyMaxCount keeps the MaxCount most recent states.
+	StrategyMaxCount = "maxcount"
+)
+
+const (
+	// BootloaderGrub is the backend name for GRUB, the default bootloader.
+	BootloaderGrub = "grub"
+	// BootloaderSystemdBoot is the backend name for systemd-boot.
+	BootloaderSystemdBoot = "systemd-boot"
+	// BootloaderZFSBootMenu is the backend name for ZFSBootMenu.
+	BootloaderZFSBootMenu = "zfsbootmenu"
+)
+
+const (
+	// ScheduleScopeSystem saves the current system state with its users.
+	ScheduleScopeSystem = "system"
+	// ScheduleScopeUsers saves user states only.
+	ScheduleScopeUsers = "users"
+)
+
+// CalendarRules are the number of local days, weeks (starting on Monday) and months, most recent first,
+// keeping their newest state.
+type CalendarRules struct {
+	Days   int
+	Weeks  int
+	Months int
+}
+
+// HistoryGCRule divides a period of history in buckets, each one keeping a number of states.
+type HistoryGCRule struct {
+	Name             string
+	Buckets          int
+	BucketLength     int64
+	SamplesPerBucket int
+}
+
+// ForUser returns the history rules applying to user states of user.
+func (h HistoryConfig) ForUser(user string) HistoryRules {
+	if r, ok := h.PerUser[user]; ok {
+		return r
+	}
+	return h.Users
+}
+
+// UnmarshalYAML decodes each scope of history rules on top of the one it defaults to.
+func (h *HistoryConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// Decode every scope as rules first, so that invalid elements are reported on their line.
+	if err := unmarshal(&historyScopes{}); err != nil {
+		return err
+	}
+
+	var raw rawHistoryScopes
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	var err error
+	if h.System, err = overrideRules(raw.HistoryRules, raw.System); err != nil {
+		return fmt.Errorf(i18n.G("invalid system history rules: %v"), err)
+	}
+	if h.Users, err = overrideRules(h.System, raw.Users); err != nil {
+		return fmt.Errorf(i18n.G("invalid users history rules: %v"), err)
+	}
+	h.PerUser = nil
+	for user, r := range raw.PerUser {
+		if h.PerUser == nil {
+			h.PerUser = make(map[string]HistoryRules)
+		}
+		if h.PerUser[user], err = overrideRules(h.Users, r); err != nil {
+			return fmt.Errorf(i18n.G("invalid history rules for user %q: %v"), user, err)
+		}
+	}
+	return nil
+}
+
+// historyScopes are the history rules of each scope, as written.
+type historyScopes struct {
+	HistoryRules `yaml:",inline"`
+	System       HistoryRules
+	Users        HistoryRules
+	PerUser      map[string]HistoryRules
+}
+
+// rawHistoryScopes are the history rules of each scope, only keeping the elements set in overriding scopes.
+type rawHistoryScopes struct {
+	HistoryRules `yaml:",inline"`
+	System       yaml.MapSlice
+	Users        yaml.MapSlice
+	PerUser      map[string]yaml.MapSlice
+}
+
+// overrideRules returns the rules elements set in raw on top of base.
+func overrideRules(base HistoryRules, raw yaml.MapSlice) (HistoryRules, error) {
+	if len(raw) == 0 {
+		return base, nil
+	}
+	b, err := yaml.Marshal(raw)
+	if err != nil {
+		return base, err
+	}
+	err = yaml.UnmarshalStrict(b, &base)
+	return base, err
+}
+
+// validate ensures the history rules can be used to build GC buckets.
+func (r HistoryRules) validate() error {
+	if r.GCStartAfter < 0 {
+		return fmt.Errorf(i18n.G("gcstartafter can't be negative, got %d"), r.GCStartAfter)
 	}
+	if r.KeepLast < 0 {
+		return fmt.Errorf(i18n.G("keeplast can't be negative, got %d"), r.KeepLast)
+	}
+	switch r.Strategy {
+	case "", StrategyBuckets:
+		names := make(map[string]bool)
+		for _, rule := range r.GCRules {
+			if names[rule.Name] {
+				return fmt.Errorf(i18n.G("rule %q is defined more than once"), rule.Name)
+			}
+			names[rule.Name] = true
+			if rule.Buckets <= 0 || rule.BucketLength <= 0 {
+				return fmt.Errorf(i18n.G("rule %q needs at least one bucket of one day"), rule.Name)
+			}
+			if rule.SamplesPerBucket < 0 {
+				return fmt.Errorf(i18n.G("rule %q can't keep a negative number of samples per bucket"), rule.Name)
+			}
+		}
+	case StrategyCalendar:
+		if r.Calendar.Days < 0 || r.Calendar.Weeks < 0 || r.Calendar.Months < 0 {
+			return fmt.Errorf(i18n.G("calendar days, weeks and months can't be negative, got %+v"), r.Calendar)
+		}
+	case StrategyMaxAge:
+		if r.MaxAge <= 0 {
+			return fmt.Errorf(i18n.G("maxage needs to be at least one day, got %d"), r.MaxAge)
+		}
+	case StrategyMaxCount:
+		if r.MaxCount <= 0 {
+			return fmt.Errorf(i18n.G("maxcount needs to keep at least one state, got %d"), r.MaxCount)
+		}
+	default:
+		return fmt.Errorf(i18n.G("unknown strategy %q"), r.Strategy)
+	}
+	return nil
+}
+
+// validate ensures every scope of history rules is valid.
+func (h HistoryConfig) validate() error {
+	if err := h.System.validate(); err != nil {
+		return fmt.Errorf(i18n.G("invalid system history rules: %v"), err)
+	}
+	if err := h.Users.validate(); err != nil {
+		return fmt.Errorf(i18n.G("invalid users history rules: %v"), err)
+	}
+	for user, r := range h.PerUser {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf(i18n.G("invalid history rules for user %q: %v"), user, err)
+		}
+	}
+	return nil
+}
+
+// GCRules store the space based rules for GC, complementing history rules
+type GCRules struct {
+	// TargetFreePoolSpace is the percentage of free space GC tries to reach on each pool. 0 disables it.
+	TargetFreePoolSpace int
+	// MaxStateSize is the size, in MiB, above which a state is collected. 0 disables it.
+	MaxStateSize int
+}
+
+// ScheduleRule is an automatic state saving taken by the daemon at regular interval
+type ScheduleRule struct {
+	// Name identifies the rule. It is stored on each state the rule saves.
+	Name string
+	// Scope is either "system", saving the current system state with its users, or "users".
+	Scope string
+	// Users restricts a users scope rule to those users. All users of the current machine are saved if empty.
+	Users []string
+	// Every is the minimal duration between 2 states saved by this rule.
+	Every time.Duration
 }
 
 // SetVerboseMode change ErrorFormat and logs between very, middly and non verbose
@@ -62,7 +301,10 @@ func SetVerboseMode(level int) {
 	}
 }
 
-// Load reads a zsys configuration file into memory
+// Load reads a zsys configuration file into memory.
+// Fragments in the <path>.d directory, with a .conf extension, are merged over it by name order: mappings are merged
+// while other values, including lists, are replaced.
+// Unknown or duplicated elements are errors, as well as semantically invalid values.
 func Load(ctx context.Context, path string) (ZConfig, error) {
 
 	var c ZConfig
@@ -83,10 +325,49 @@ func Load(ctx context.Context, path string) (ZConfig, error) {
 	if err != nil {
 		return c, fmt.Errorf(i18n.G("failed to read configuration file %s: %v "), path, err)
 	}
+	if err := yaml.UnmarshalStrict(b, &c); err != nil {
+		return ZConfig{}, fmt.Errorf(i18n.G("invalid configuration file %s: %v"), path, err)
+	}
 
-	err = yaml.Unmarshal(b, &c)
+	dropIns, err := filepath.Glob(filepath.Join(path+".d", "*.conf"))
 	if err != nil {
-		return c, fmt.Errorf(i18n.G("failed to unmarshal yaml: %v"), err)
+		return ZConfig{}, fmt.Errorf(i18n.G("couldn't list configuration fragments of %s: %v"), path, err)
+	}
+	if len(dropIns) > 0 {
+		var merged yaml.MapSlice
+		if err := yaml.Unmarshal(b, &merged); err != nil {
+			return ZConfig{}, fmt.Errorf(i18n.G("invalid configuration file %s: %v"), path, err)
+		}
+		for _, p := range dropIns {
+			log.Debugf(ctx, i18n.G("Merging configuration fragment %s"), p)
+			b, err := ioutil.ReadFile(p)
+			if err != nil {
+				return ZConfig{}, fmt.Errorf(i18n.G("failed to read configuration file %s: %v "), p, err)
+			}
+			// Check the fragment alone, to report errors on its own lines.
+			var fc ZConfig
+			if err := yaml.UnmarshalStrict(b, &fc); err != nil {
+				return ZConfig{}, fmt.Errorf(i18n.G("invalid configuration file %s: %v"), p, err)
+			}
+			var fragment yaml.MapSlice
+			if err := yaml.Unmarshal(b, &fragment); err != nil {
+				return ZConfig{}, fmt.Errorf(i18n.G("invalid configuration file %s: %v"), p, err)
+			}
+			merged = mergeYAML(merged, fragment)
+		}
+
+		if b, err = yaml.Marshal(merged); err != nil {
+			return ZConfig{}, fmt.Errorf(i18n.G("couldn't merge configuration fragments of %s: %v"), path, err)
+		}
+		c = ZConfig{}
+		if err := yaml.UnmarshalStrict(b, &c); err != nil {
+			return ZConfig{}, fmt.Errorf(i18n.G("couldn't merge configuration fragments of %s: %v"), path, err)
+		}
+		c.DropIns = dropIns
+	}
+
+	if err := c.validate(); err != nil {
+		return ZConfig{}, err
 	}
 
 	c.Path = path
@@ -94,6 +375,78 @@ func Load(ctx context.Context, path string) (ZConfig, error) {
 	return c, nil
 }
 
+// mergeYAML returns base with the elements of override. Mappings present in both are merged, any other value replaced.
+func mergeYAML(base, override yaml.MapSlice) yaml.MapSlice {
+	r := append(yaml.MapSlice(nil), base...)
+nextItem:
+	for _, o := range override {
+		for i, b := range r {
+			if b.Key != o.Key {
+				continue
+			}
+			bm, bok := b.Value.(yaml.MapSlice)
+			om, ook := o.Value.(yaml.MapSlice)
+			if bok && ook {
+				r[i].Value = mergeYAML(bm, om)
+			} else {
+				r[i].Value = o.Value
+			}
+			continue nextItem
+		}
+		r = append(r, o)
+	}
+	return r
+}
+
+// validate ensures the configuration values are consistent.
+// Schedule rules without interval are only skipped when running them.
+func (c ZConfig) validate() error {
+	if err := c.History.validate(); err != nil {
+		return err
+	}
+	switch c.Bootloader.Backend {
+	case "", BootloaderGrub, BootloaderSystemdBoot, BootloaderZFSBootMenu:
+	default:
+		return fmt.Errorf(i18n.G("unknown bootloader backend %q"), c.Bootloader.Backend)
+	}
+	for _, p := range []struct {
+		name  string
+		value int
+	}{{"general.minfreepoolspace", c.General.MinFreePoolSpace}, {"gc.targetfreepoolspace", c.GC.TargetFreePoolSpace}} {
+		if p.value < 0 || p.value > 100 {
+			return fmt.Errorf(i18n.G("%s is a percentage between 0 and 100, got %d"), p.name, p.value)
+		}
+	}
+	for _, p := range []struct {
+		name  string
+		value int
+	}{
+		{"general.timeout", c.General.Timeout},
+		{"hooks.timeout", c.Hooks.Timeout},
+		{"gc.maxstatesize", c.GC.MaxStateSize},
+		{"userdata.quota", c.UserData.Quota},
+		{"userdata.reservation", c.UserData.Reservation},
+	} {
+		if p.value < 0 {
+			return fmt.Errorf(i18n.G("%s can't be negative, got %d"), p.name, p.value)
+		}
+	}
+	names := make(map[string]bool)
+	for _, r := range c.Schedule {
+		if names[r.Name] {
+			return fmt.Errorf(i18n.G("schedule rule %q is defined more than once"), r.Name)
+		}
+		names[r.Name] = true
+		if r.Scope != ScheduleScopeSystem && r.Scope != ScheduleScopeUsers {
+			return fmt.Errorf(i18n.G("schedule rule %q has an unknown scope %q"), r.Name, r.Scope)
+		}
+		if r.Every < 0 {
+			return fmt.Errorf(i18n.G("schedule rule %q can't have a negative interval, got %v"), r.Name, r.Every)
+		}
+	}
+	return nil
+}
+
 // SocketPath returns the unix path which can be overridden by environment variable
 func SocketPath() string {
 	s := defaultSocket
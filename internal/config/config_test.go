@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		path string
+
+		wantErr bool
+		// wantErrIn are parts of the error message, like the line or the file of an invalid element.
+		wantErrIn []string
+	}{
+		"Shipped configuration":             {path: "zsys.conf"},
+		"Minimal configuration":             {path: "minimal.conf"},
+		"Drop-ins are merged by name order": {path: "dropins.conf"},
+
+		"Error on missing file":                                  {path: "doesntexist.conf", wantErr: true},
+		"Error on invalid yaml":                                  {path: "invalid_yaml.conf", wantErr: true, wantErrIn: []string{"invalid_yaml.conf", "line 2"}},
+		"Error on unknown key":                                   {path: "unknown_key.conf", wantErr: true, wantErrIn: []string{"unknown_key.conf", "line 7", "samplesperbuckets"}},
+		"Error on negative keeplast":                             {path: "negative_keeplast.conf", wantErr: true, wantErrIn: []string{"keeplast"}},
+		"Error on negative gcstartafter":                         {path: "negative_gcstartafter.conf", wantErr: true, wantErrIn: []string{"gcstartafter"}},
+		"Error on negative samples per bucket":                   {path: "negative_samplesperbucket.conf", wantErr: true, wantErrIn: []string{"PreviousDay"}},
+		"Error on negative keeplast of a user":                   {path: "negative_user_keeplast.conf", wantErr: true, wantErrIn: []string{"alice", "keeplast"}},
+		"Error on negative timeout":                              {path: "negative_timeout.conf", wantErr: true, wantErrIn: []string{"general.timeout"}},
+		"Error on negative quota":                                {path: "negative_quota.conf", wantErr: true, wantErrIn: []string{"userdata.quota"}},
+		"Error on negative schedule interval":                    {path: "negative_schedule_interval.conf", wantErr: true, wantErrIn: []string{"Hourly"}},
+		"Error on minfreepoolspace above 100":                    {path: "minfreepoolspace_above_100.conf", wantErr: true, wantErrIn: []string{"general.minfreepoolspace"}},
+		"Error on unknown strategy":                              {path: "unknown_strategy.conf", wantErr: true, wantErrIn: []string{"fifo"}},
+		"Error on unknown bootloader backend":                    {path: "unknown_backend.conf", wantErr: true, wantErrIn: []string{"lilo"}},
+		"Error on unknown schedule scope":                        {path: "unknown_scope.conf", wantErr: true, wantErrIn: []string{"machine"}},
+		"Error on duplicated gc rule names":                      {path: "duplicate_gcrule.conf", wantErr: true, wantErrIn: []string{"PreviousDay"}},
+		"Error on duplicated schedule rule names":                {path: "duplicate_schedule_rule.conf", wantErr: true, wantErrIn: []string{"Hourly"}},
+		"Error on invalid drop-in":                               {path: "invalid_dropin.conf", wantErr: true, wantErrIn: []string{"10-typo.conf", "line 3", "minfreespace"}},
+		"Error on drop-in invalidating the merged configuration": {path: "invalid_merged_dropin.conf", wantErr: true, wantErrIn: []string{"keeplast"}},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := tc.path
+			if path != "zsys.conf" {
+				path = filepath.Join("testdata", "confs", path)
+			}
+
+			c, err := config.Load(context.Background(), path)
+			if tc.wantErr {
+				assert.Error(t, err, "Load should have failed")
+				for _, s := range tc.wantErrIn {
+					assert.Contains(t, err.Error(), s, "Error message should point to the invalid element")
+				}
+				return
+			}
+			assert.NoError(t, err, "Load shouldn't have failed")
+
+			var want config.ZConfig
+			testutils.LoadFromGoldenFile(t, c, &want)
+			assert.Equal(t, want, c, "Loaded configuration")
+		})
+	}
+}
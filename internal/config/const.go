@@ -17,6 +17,8 @@ const (
 	DefaultClientWaitOnServiceReady = time.Minute
 	// DefaultClientTimeout for client requests between 2 pings
 	DefaultClientTimeout = 30 * time.Second
+	// DefaultClientTransferTimeout for client requests between 2 pings when sending or receiving a whole dataset
+	DefaultClientTransferTimeout = 2 * time.Hour
 
 	// DefaultServerIdleTimeout is the default time without a request before the server exits
 	DefaultServerIdleTimeout = time.Minute
@@ -24,6 +26,9 @@ const (
 	// DefaultPath is the default configuration path
 	DefaultPath = "/etc/zsys.conf"
 
+	// DefaultAuditLogPath is the default path of the log recording operations changing the system
+	DefaultAuditLogPath = "/var/log/zsys/audit.log"
+
 	// UserConfirmationNeeded is a dedicated type for GRPC error which signal that we need more info from user
 	UserConfirmationNeeded = "UserConfirmationNeeded"
 )
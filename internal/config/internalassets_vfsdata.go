@@ -19,14 +19,14 @@ var internalAssets = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2020, 7, 27, 13, 26, 42, 327263544, time.UTC),
+			modTime: time.Date(2026, 10, 18, 2, 5, 26, 204463637, time.UTC),
 		},
 		"/zsys.conf": &vfsgen۰CompressedFileInfo{
 			name:             "zsys.conf",
-			modTime:          time.Date(2020, 7, 27, 13, 26, 42, 323263518, time.UTC),
-			uncompressedSize: 908,
+			modTime:          time.Date(2026, 10, 18, 2, 16, 1, 930257770, time.UTC),
+			uncompressedSize: 4315,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\x4d\xab\xdb\x3c\x10\x85\xf7\xfe\x15\x07\xee\xe6\x7d\x17\x2d\x37\xfd\x04\xef\x0a\x77\xd7\xde\xd2\x45\xa1\xeb\x89\x7d\x1c\x8b\x58\x23\x77\x34\x0e\xf8\xdf\x17\x29\x4e\x6f\x48\x43\xa1\x5e\x59\x33\x3a\xcf\x7c\x6a\x0c\xd9\x93\xad\x6d\x03\x3c\xe0\x33\x39\x43\x1c\x13\x25\x3b\x14\x9b\x13\x54\xb7\x15\x33\x0d\x8b\x06\x47\x1a\xe0\x21\x12\x61\x00\x35\x2d\x87\xb1\x5a\x46\x46\x88\x11\xb3\x31\x53\xbd\x02\xbf\x8f\x44\xb2\x9e\x86\x2e\x69\x1f\x3c\x24\x2d\x17\xb1\x5f\xba\x23\x1d\xd9\xc5\x1c\xa2\x3d\xa8\x3d\x7a\x71\x66\xfc\x37\x58\x8a\x88\x29\x3b\x8c\x1d\xd5\xe1\x09\x69\xea\x99\xfd\xff\x06\x38\x74\x55\x24\x83\xd3\x5a\xec\x1a\xe0\x48\xce\x93\x64\x6f\xf1\xe6\x11\x0f\x78\x0e\x1a\xe2\x12\xa1\x4b\xdc\xd3\x4a\x66\x1b\x26\x7b\xe5\x7b\xaa\x8a\xd7\x35\x3f\x00\xaf\xa0\x12\xd9\xe2\xfa\xfb\xb4\x0f\x6e\x62\x6b\x75\x6d\xc5\x6d\x39\x5f\x64\xd8\xce\xf9\x4a\xf9\xf5\x77\xc8\xcd\x87\x74\xa2\x55\x71\x50\xa7\x9d\x64\xba\x95\x4f\xd4\x83\x8f\x67\xc6\x97\xfa\x5f\xe4\x94\x6e\xbc\xf4\x28\x28\x7a\x59\xf3\x8b\x30\x4b\x9c\x27\xe6\x99\x76\xbe\xd1\x5e\xc5\xed\xc5\x25\x97\xc0\x5b\x95\x45\x7d\x05\xab\xfd\xb3\x65\x62\x2e\xf3\x7e\xa9\xfd\x9b\xf1\x14\xd2\x92\x9f\x64\x6d\x6e\x8a\xdb\x35\xf7\xd2\xbd\x58\xff\xcc\xe5\xed\x5d\xf0\x0f\xf2\x78\x4b\x7e\xff\x8f\xe4\xdd\x5d\xf2\x73\x52\x1f\x6f\xd1\xef\xee\xa2\x3f\xfe\x05\x7d\xa0\xd2\x64\x3a\x3f\x83\xba\x42\x32\x61\x30\x12\x79\x96\x8e\x30\xfe\x5c\x82\xb1\xc7\x9e\x43\x32\xc2\xe5\x18\xf4\x00\x41\x56\x99\xf3\x98\x4a\x6b\x63\xd0\xa2\x98\x53\x9a\xaa\xa8\x2c\x64\xe5\x3d\x09\x63\x59\xfc\x10\x99\x96\x3a\xd1\xcc\xf2\x1e\xca\x50\x37\x63\x8b\x0f\x8f\xcd\xaf\x00\x00\x00\xff\xff\xbf\xda\xe7\x4a\x8c\x03\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x57\x5d\x8f\xdb\xb8\xd5\xbe\xd7\xaf\x78\x10\xdf\x24\x80\xc6\x33\x93\xcc\x9b\xbc\x10\x8a\x02\x93\x4e\x16\x2d\xda\xd9\x59\x64\x27\xe8\x45\xb1\x17\x34\x75\x64\x11\xa6\x48\x2d\x49\xd9\xe3\x14\xfd\xef\xc5\x39\xa2\x64\x79\xe2\x16\xa8\x6f\x4c\x91\x3c\xcf\xf9\xfe\xe0\x0a\x3f\x05\xb5\xed\xc8\xa5\x08\xe3\x90\x5a\xc2\xf7\x78\x8c\x6b\xed\x5d\xb3\xae\x51\x9b\x40\x3a\xf9\x70\x84\xa3\x97\x84\xe4\x91\x5a\x13\xd1\x18\x4b\x25\x0e\x26\xb5\x50\x90\xbb\xa0\x97\x44\x2e\x1a\xef\x4a\xa8\x40\xe8\x28\x6c\xa9\x86\xdf\x53\x80\x49\xd8\x1c\xe1\x54\x47\xf0\xa1\xa6\xb0\x2e\x56\x78\x54\x7d\x6f\xdc\x36\x2e\x2e\x97\x50\xee\x08\x9f\x5a\x0a\xd8\x2b\x3b\x50\x09\xe3\xb4\x1d\x6a\xe3\xb6\xb0\x26\xa6\x58\xc2\x44\x04\xea\xad\xd2\x54\xaf\xf1\xcd\xed\x9c\x3f\x38\x90\xa5\x51\x01\xc6\xa2\x10\x7c\x88\xcc\xe2\xeb\xe0\xf0\x86\x95\xd1\xc9\x22\x52\xd8\x1b\x4d\x60\x59\xcd\x16\xba\x25\xbd\x7b\xc3\xfa\xec\x95\x35\xb5\x4a\x24\xaa\x07\x8a\x83\x4d\xd8\x50\xe3\x03\x21\x90\xf5\x4a\xb8\xf3\x59\xad\xa8\xf3\x6e\x5d\xb4\x26\xb2\x41\xaa\x02\x58\xe1\x2b\x25\x72\xc9\x78\x87\x98\x82\x4a\xb4\x3d\x56\xd8\x0c\x7a\x47\x2c\x6c\xe3\xad\xf5\x07\xa6\xdf\xea\x30\x58\x8a\x25\xb4\xb2\xe4\x6a\x15\x4a\x74\xea\x45\x6d\xd9\x20\xbc\xd2\x7e\x70\x69\x2d\x88\x5f\xf6\x14\x8e\x33\x1a\x76\x44\x7d\x14\xd9\xac\x8a\x09\x31\xa9\x44\x11\xca\xd5\xd3\xf2\xd0\x1a\xdd\x8a\x15\x7b\xe3\x1c\xd5\x25\x5a\xb2\x35\xe3\xb6\x6a\x4f\xa8\xa9\x27\x57\x93\xd3\x86\x22\x33\xf8\x41\x4c\x61\xfa\x57\xa2\x1e\x2a\xc1\x12\x33\x71\xc8\x3a\x82\x5c\x0a\x47\xf4\x14\x30\x38\x93\xe0\x1b\x24\xd3\x11\x4c\x03\x72\x7e\xd8\xb6\xb2\xd3\x52\x37\xf2\x0f\x14\xc9\x25\x01\x7c\x6e\xb3\xaf\xd9\xe0\xb5\x11\x0b\xb1\x12\x23\x53\x96\x3d\x24\xd1\x82\x5c\x0d\x36\x7f\xc4\xdb\x26\xf8\x0e\x9d\x8f\x09\x81\x34\x39\x89\x36\x6f\x6b\x8a\xe9\x5d\x01\x6c\xb5\x10\xa9\x26\x51\xa8\x70\x8b\x15\x7e\x1e\xba\x0d\x05\x16\xa1\x56\xc7\x28\x96\x62\x5b\x2b\x6b\xd9\x5e\x26\x64\x0b\xb1\x1f\xc2\xa4\xad\xf0\x9c\x9c\x30\x19\x23\x9b\x86\x01\xd8\xc8\x15\xde\xdf\x60\x85\x47\xe3\x4c\x37\x74\x70\x33\x9b\x2c\x56\x36\x7c\xf2\xc2\x92\x8d\xba\x9a\xfd\xba\xf0\x97\xa3\x03\x4d\x1e\x63\x21\x49\xe9\xc9\x5e\x6c\xaa\xbd\xf1\x43\x84\xf5\x5a\x59\x91\xbf\xc4\x81\x68\x17\xf1\x56\xd4\x64\x45\xbc\xc3\xa3\x77\xb5\x3a\xbe\x13\xa9\x3b\xef\x52\x2b\x82\x4e\xcc\x38\x04\x21\xc4\x15\x3e\xc9\x5a\x20\x2a\xdc\xc9\xc7\x48\x50\xe1\xa3\x48\x98\xe3\x2d\x50\xe7\xf7\x14\xa7\xe8\x61\xfb\x06\xa4\x56\x71\xea\x9b\xb8\x50\x96\x61\x99\xd9\x48\x57\xe1\xc3\xcd\x04\x23\xc1\x3a\x2b\x7a\x46\xb4\xf4\xde\xc8\x21\x43\x08\x0d\x1b\x56\x40\x00\x5c\x49\x3d\xa8\xb0\xfc\xdd\x6f\x4c\x0a\x2a\x4c\xa5\xa2\x59\x44\xcc\x44\x86\xc9\x91\x0b\xca\x53\x1c\x4c\x4e\x96\xaa\xc3\xc4\xc6\x25\x0a\x7b\x65\x5f\x93\x5b\x72\xdb\xd4\x8e\x18\x7f\x93\xf5\xec\xa1\x1c\xa1\xc6\x89\x61\x4f\x84\x51\x75\xbd\xa5\xd8\x53\x18\x6f\x54\x67\xf1\x97\x54\xa4\x34\xc7\x04\x57\xd2\x05\x98\x44\xaf\x14\x80\xaa\x58\xea\xfe\x4b\x8e\x82\x07\x75\x2c\x5e\x29\x77\x5b\x5c\x12\xf7\xb6\xf8\x4f\xb2\x7c\xb8\x08\xfc\x77\xa2\xdd\x19\x50\xac\xf0\x7f\xff\x23\xf2\xed\x45\xe4\x47\x8e\xad\x33\xa4\x39\xec\x5e\x43\x7f\xfa\xaf\xd0\x5c\xa6\x2d\x57\xb4\x8d\xdf\x13\x54\xdf\xdb\x23\xe7\x7d\x3c\xc6\x44\xdd\x14\x43\x78\x6e\xe9\x08\xad\x1c\x36\x24\x2d\x25\x98\xba\x26\x27\x89\x7d\x76\x73\xcc\xf5\x21\xd2\x94\xfc\x92\x39\x52\xbf\x22\xf7\x1d\xe6\xf7\x85\x1d\x93\x5b\x06\x9c\x4f\x88\xec\xee\x88\xa4\x76\x0c\xc9\x45\x88\x43\x27\xe3\xe6\xba\x9d\xda\xe5\xd9\x92\x81\x77\x14\x85\xab\xc2\xd6\xec\xc9\xcd\x9c\x46\x80\x0a\xff\xfc\x57\x01\xd9\x8c\x79\xdd\x53\xe0\x4f\xf9\x5a\x4d\x47\xc5\x0a\x58\x96\xa0\xbb\xff\x1f\x77\xe6\xc0\xc9\x61\x78\xd1\xc5\xf9\x6c\xe1\x8a\x4f\xaf\xf7\x4e\x8e\x9e\x0f\x7e\x74\xc8\x5d\xb1\x3a\x89\xc7\x6b\x40\x59\xa3\x69\xe6\x7e\x92\xef\xf6\xa6\xd8\x6a\x0e\xe7\x15\x9e\xa4\x46\x43\x7b\x6b\x49\x27\xb5\xb1\x34\x1b\x3f\x4c\x05\xa7\xc6\xe0\x92\xb1\x63\x56\xf4\xde\x5b\xb4\x2a\x57\x8f\x9e\x02\x97\x55\x69\x86\x0d\x9a\x40\x84\xd8\x2b\x4d\x6b\xdc\xa0\x36\x91\xf1\x22\x8c\x74\xc7\xa4\xc2\x96\x12\x5f\x61\x08\xb9\x55\x61\xac\x4b\xbf\x8e\x1c\xf9\x8c\x6b\x67\xc7\xfd\xfb\x54\xd6\xa2\xf9\x4e\x78\x6b\x1c\x1e\xcd\xe7\x77\x67\x62\x99\x66\x29\xf8\x05\x9e\x9d\x7a\x19\xb5\x31\xdf\x85\xd9\x0a\xf7\x43\xf2\x9d\x4a\x46\x4f\x6a\x46\xb5\xa7\x9a\x47\x9c\xd3\x98\x80\x03\x07\x0c\x0f\x1f\x57\x51\xb7\x54\x0f\x96\xd6\xdc\x3a\x03\x1a\x13\xb8\x2a\xae\x70\x0f\xf6\xac\x10\x47\x28\x38\x3a\x4c\xbd\xc2\x69\x3a\xef\x12\xe3\xbe\x49\x99\x93\x39\xab\xdb\x46\x26\xb7\xb1\xce\x31\xee\x22\x48\x70\x1f\x2e\x95\x54\xe6\x5b\x82\x3b\x3c\xd5\x10\x37\x10\xde\x4c\x62\xbe\x81\x55\x1b\xb2\x73\x3d\x1c\x59\x8a\x04\x53\x14\x44\xed\x7b\xaa\x72\x86\x94\x2c\xd4\x34\x23\xe9\x21\x04\x4e\xab\x65\x52\x4a\x0a\xb2\x90\x12\xe8\x25\x72\x82\xc6\x09\x2d\xa7\xc6\x37\xfe\x3b\x99\x52\x8d\x79\x31\xf2\x12\x4b\xad\x71\x6f\x6d\xde\xf5\xcd\x19\xbb\x4e\xe9\xd6\xb8\x71\x2e\xe9\xfa\x74\x9c\xa0\x89\x67\xa9\x6a\xec\xe4\xca\xce\x56\xc2\x86\xd2\x81\xc8\xe1\x7d\xe6\x97\xfd\xf8\x96\xd6\xdb\x35\x3e\xdc\x74\x25\x3e\xb6\x25\xde\xdf\xb5\xef\x8a\xc9\x2c\x15\xfe\xf1\x5b\xb1\x3a\x99\xf6\xcf\x7e\x08\x36\x33\xca\xf6\x58\x68\x95\x19\xdf\xb6\x4b\x92\x07\x65\x5e\x51\x8c\x66\x3a\x23\x79\x7f\xd7\x16\x5b\x72\x14\x94\x1d\xb3\x6b\x12\xfe\x94\x18\x08\xf4\xfb\x60\x02\xdb\x69\x9c\x53\x93\xda\xb1\x03\x14\xa2\x53\x7d\x6c\x3d\x8f\x60\x9d\x71\xaf\xf2\x24\xf7\xde\x07\x19\x63\x65\x8e\xf3\x43\xe2\x4e\x15\x89\x67\xb4\xc8\xf9\x35\x6e\x56\xf8\x78\x53\x6c\xbc\x4f\x3c\xfe\x52\x18\xe5\xf8\x3c\x7f\x63\xe8\x79\x62\x93\x88\xe7\x08\xaf\xb0\x0d\xc3\xa6\xcc\x4e\xaf\xaf\x98\x92\xbd\xfc\xbd\x89\xbc\xec\xc8\x0d\x82\xb0\xf8\x96\xec\x77\x1e\xde\xd1\x15\x0b\x8c\x48\x9c\x82\xc6\xbb\x0a\x0a\x81\x6d\x91\x26\xbf\x70\x14\x1d\xb9\x02\x44\xd4\xd4\x28\x99\xcf\x99\x03\xb9\xbd\x09\xde\xf1\xe4\xcf\xb5\x61\xa3\xf4\x8e\x5c\x3d\x0a\x23\xfc\x1e\x79\xe6\xe8\xbd\x71\x69\x0a\xfc\x2f\x3f\xfd\x65\x0a\xcd\x9e\x87\x2c\x1e\x4b\x4b\x4e\xd5\x40\xe7\xd2\xf3\xdc\x6b\x72\x01\x3b\x04\x93\x12\xb9\x02\xa0\xd8\x57\xb8\xe6\x0b\xd7\xd4\x98\xa2\xf5\x7e\x17\x47\xe3\x3c\xcc\xcf\x24\xed\x5d\x52\xc6\xb1\x3f\xbc\x23\xfc\x81\xf6\xe4\xd2\x1f\xcf\x5e\x52\x9c\x59\x2f\xa4\x07\x29\x95\x51\xa6\x6b\xb9\x35\x42\xf5\x81\xae\x38\x2a\x4b\xf4\x3e\xa6\x69\x19\xe8\x6a\x9c\xdc\xf2\xf6\xfc\x21\x07\x6c\xae\xf9\x60\xf1\xa1\x7d\xd7\x99\x24\x09\x28\xdf\x5b\xcd\x96\xe2\xd2\xd3\x28\x63\x59\xc6\x3e\x10\x58\x0f\xee\xbf\x81\x07\x18\x9e\xdb\x7b\x0a\x8a\x4d\xc3\x97\x6b\x13\x2a\x5c\x53\xd2\xd7\xec\xea\x6b\xbe\x1b\xd7\xb5\xa0\x3c\x9b\x8e\xca\x45\x00\x95\xb9\x01\x26\x3f\x96\x79\xbe\xcb\xbd\x5c\x7b\xee\x33\x89\x96\x01\xf6\xe1\xa6\x90\xd0\x1c\x75\xfe\x85\x97\x68\xbd\x9d\x1f\x5b\x5f\x9f\x9e\x9e\x4b\x7c\x7e\x7a\x7a\x16\xe9\xbf\xfd\xfa\xe5\xeb\xc3\xfd\xf3\xfd\x64\x5e\x0a\x71\x9d\x5f\x4a\x0c\xc3\xed\x3b\x6a\xc5\xcf\x9f\xdc\x8a\xe7\x7b\xec\x5d\x27\xc1\xc3\x2f\x48\xbe\x28\x15\x62\x2d\x7c\x7f\xa6\x83\x14\x94\xd3\x00\xc7\xad\x41\x07\x92\xf0\xce\x4f\x96\xc6\x84\x98\xe4\x1a\xdf\xca\xfc\x1a\x38\x76\x2f\xbd\xf0\xb3\x14\x47\x4a\x0c\x18\xbc\x4f\x52\x27\x80\xcd\x69\x39\x51\x4e\x15\x24\xdf\x0a\x0c\x24\x25\x25\xdf\xdd\xcc\x1b\x0b\x8a\xa4\xdc\xee\xb7\x62\xde\x10\xa9\xff\x24\xf2\xc1\xfd\x28\x7c\xcc\x0f\x1f\x79\x14\x3b\x1d\x8e\x3d\xbb\x51\x38\x96\x18\x9c\xf5\x7a\x37\x55\xd7\x5e\xc5\xd8\xb7\x41\x45\xca\x5e\x53\x69\x54\x3c\xfb\xfd\x44\x5e\xa1\x51\x36\xb2\xf7\xf8\xd5\xfe\x22\x6f\x22\x29\x29\xe2\xfb\x47\xf3\xb9\x1c\xdd\xfd\x5a\x1e\x99\xd7\x02\x35\x14\xc8\x5d\xec\xe6\xbf\x0f\x3e\xa9\xb9\x7f\x9f\x23\xf2\x53\x32\xec\xb3\x3b\x2f\xc2\x5f\x00\x1c\x89\x54\x32\xde\x55\xb8\x29\xfe\x3d\x00\x28\x04\x15\xcb\xdb\x10\x00\x00"),
 		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/audit"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+// audited returns the function recording in the audit log the operation op, authorized by action with args, once
+// it ended with *err. Empty arguments are left out.
+// It must be called with the requests lock held, for the affected datasets to only be the ones of op:
+//
+//	defer s.audited(ctx, "Operation", action, args)(&err)
+func (s *Server) audited(ctx context.Context, op string, action authorizer.Action, args map[string]string) func(err *error) {
+	before := s.Machines.DatasetsProperties()
+
+	return func(err *error) {
+		e := audit.Entry{
+			Time:      time.Now(),
+			Operation: op,
+			Arguments: make(map[string]string),
+			Success:   *err == nil,
+		}
+		for k, v := range args {
+			if v == "" {
+				continue
+			}
+			e.Arguments[k] = v
+		}
+		actionCtx := ctx
+		if user := args["user"]; user != "" {
+			actionCtx = context.WithValue(ctx, authorizer.OnUserKey, user)
+		}
+		e.Action = string(s.authorizer.PolkitAction(actionCtx, action))
+		e.UID, e.PID, _ = authorizer.PeerCredsFromContext(ctx)
+		e.RequestID, _ = log.IDFromContext(ctx)
+		if *err != nil {
+			e.Error = (*err).Error()
+		}
+		e.Datasets = audit.DatasetsChanges(before, s.Machines.DatasetsProperties())
+
+		if errRecord := s.audit.Record(ctx, e); errRecord != nil {
+			log.Warningf(ctx, i18n.G("couldn't record %s in audit log: %v"), op, errRecord)
+		}
+	}
+}
+
+// boolArg returns the audit argument of a flag, which is left out when unset.
+func boolArg(b bool) string {
+	if !b {
+		return ""
+	}
+	return strconv.FormatBool(b)
+}
@@ -0,0 +1,129 @@
+package daemon_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/streamlogger"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+func TestAudit(t *testing.T) {
+	//t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		since int64
+		user  string
+
+		wantOperations []string
+		wantSuccess    []bool
+	}{
+		"Record mutating operations":          {wantOperations: []string{"GC", "RemoveSystemState", "CreateUserData"}, wantSuccess: []bool{true, false, false}},
+		"Filter on user acted on":             {user: "user1", wantOperations: []string{"CreateUserData"}, wantSuccess: []bool{false}},
+		"Filter on unknown user":              {user: "unknown-user"},
+		"Filter on operations since the past": {since: 1, wantOperations: []string{"GC", "RemoveSystemState", "CreateUserData"}, wantSuccess: []bool{true, false, false}},
+		"Filter on operations since future":   {since: time.Now().Add(time.Hour).Unix()},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "one_machine.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			socket := filepath.Join(dir, "daemon_test.sock")
+			s, errs := startDaemonAndListenWithLibZFS(t, socket, libzfs)
+			defer func() {
+				s.Stop()
+				<-errs
+			}()
+
+			client, err := zsys.NewZsysUnixSocketClient(socket, logrus.InfoLevel)
+			if err != nil {
+				t.Fatalf("couldn't create client: %v", err)
+			}
+			defer client.Close()
+
+			// A dry run doesn't change anything and isn't recorded.
+			gcDryRun, err := client.GC(client.Ctx, &zsys.GCRequest{DryRun: true})
+			if err != nil {
+				t.Fatalf("couldn't request GC plan: %v", err)
+			}
+			drainStream(func() error { _, err := gcDryRun.Recv(); return err })
+
+			gc, err := client.GC(client.Ctx, &zsys.GCRequest{})
+			if err != nil {
+				t.Fatalf("couldn't request GC: %v", err)
+			}
+			drainStream(func() error { _, err := gc.Recv(); return err })
+
+			// Failed operations are recorded as such.
+			remove, err := client.RemoveSystemState(client.Ctx, &zsys.RemoveSystemStateRequest{StateName: "doesntexist"})
+			if err != nil {
+				t.Fatalf("couldn't request to remove system state: %v", err)
+			}
+			drainStream(func() error { _, err := remove.Recv(); return err })
+
+			// The current machine isn't a zsys one: creating user data fails.
+			create, err := client.CreateUserData(client.Ctx, &zsys.CreateUserDataRequest{User: "user1", Homepath: "/home/user1"})
+			if err != nil {
+				t.Fatalf("couldn't request to create user data: %v", err)
+			}
+			drainStream(func() error { _, err := create.Recv(); return err })
+
+			stream, err := client.Audit(client.Ctx, &zsys.AuditRequest{Since: tc.since, User: tc.user})
+			if err != nil {
+				t.Fatalf("couldn't request audit log: %v", err)
+			}
+			var operations []string
+			var success []bool
+			for {
+				r, err := stream.Recv()
+				if err == streamlogger.ErrLogMsg {
+					continue
+				}
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("expected no error reading audit log but got: %v", err)
+				}
+				e := r.GetEntry()
+				operations = append(operations, e.GetOperation())
+				success = append(success, e.GetSuccess())
+
+				assert.NotZero(t, e.GetTime(), "Entry should have a time")
+				assert.NotZero(t, e.GetPid(), "Entry should have the pid of the requester")
+				assert.NotEmpty(t, e.GetRequestId(), "Entry should have the request id")
+				if !e.GetSuccess() {
+					assert.NotEmpty(t, e.GetError(), "Failed entry should have an error")
+				}
+				if e.GetOperation() == "CreateUserData" {
+					assert.Equal(t, map[string]string{"user": "user1", "homepath": "/home/user1", "encryption": "DEFAULT"},
+						e.GetArguments(), "Entry should have the request arguments")
+				}
+			}
+
+			assert.Equal(t, tc.wantOperations, operations, "Recorded operations should match")
+			assert.Equal(t, tc.wantSuccess, success, "Recorded outcomes should match")
+		})
+	}
+}
+
+// drainStream reads all replies of a request, ignoring its error.
+func drainStream(recv func() error) {
+	for {
+		if err := recv(); err != nil && err != streamlogger.ErrLogMsg {
+			return
+		}
+	}
+}
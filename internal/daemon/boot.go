@@ -20,12 +20,15 @@ func (s *Server) PrepareBoot(req *zsys.Empty, stream zsys.Zsys_PrepareBootServer
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	defer s.audited(stream.Context(), "PrepareBoot", authorizer.ActionSystemWrite, nil)(&err)
+
 	log.Infof(stream.Context(), i18n.G("Prepare current boot state"))
 
 	changed, err := s.Machines.EnsureBoot(stream.Context())
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't ensure boot: ")+config.ErrorFormat, err)
 	}
+	s.publishCurrentMachineEvent(zsys.Event_BOOT_PREPARED, "")
 	stream.Send(&zsys.PrepareBootResponse{
 		Reply: &zsys.PrepareBootResponse_Changed{Changed: changed},
 	})
@@ -45,12 +48,15 @@ func (s *Server) CommitBoot(req *zsys.Empty, stream zsys.Zsys_CommitBootServer)
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	defer s.audited(stream.Context(), "CommitBoot", authorizer.ActionSystemWrite, nil)(&err)
+
 	log.Infof(stream.Context(), i18n.G("Commit current boot state"))
 
 	changed, err := s.Machines.Commit(stream.Context())
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't commit: ")+config.ErrorFormat, err)
 	}
+	s.publishCurrentMachineEvent(zsys.Event_BOOT_COMMITTED, "")
 	stream.Send(&zsys.CommitBootResponse{
 		Reply: &zsys.CommitBootResponse_Changed{Changed: changed},
 	})
@@ -59,7 +65,7 @@ func (s *Server) CommitBoot(req *zsys.Empty, stream zsys.Zsys_CommitBootServer)
 		return nil
 	}
 
-	return updateBootMenu(stream.Context())
+	return s.updateBootMenu(stream.Context())
 }
 
 // UpdateBootMenu updates machine bootmenu.
@@ -76,9 +82,11 @@ func (s *Server) UpdateBootMenu(req *zsys.UpdateBootMenuRequest, stream zsys.Zsy
 		return nil
 	}
 
+	defer s.audited(stream.Context(), "UpdateBootMenu", authorizer.ActionSystemWrite, nil)(&err)
+
 	log.Infof(stream.Context(), i18n.G("Updating system boot menu"))
 
-	return updateBootMenu(stream.Context())
+	return s.updateBootMenu(stream.Context())
 }
 
 // UpdateLastUsed updates all active (system and user) datasets with current time
@@ -90,6 +98,8 @@ func (s *Server) UpdateLastUsed(req *zsys.Empty, stream zsys.Zsys_UpdateLastUsed
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	defer s.audited(stream.Context(), "UpdateLastUsed", authorizer.ActionAlwaysAllowed, nil)(&err)
+
 	log.Infof(stream.Context(), i18n.G("Updating last used timestamp"))
 
 	return s.Machines.UpdateLastUsed(stream.Context())
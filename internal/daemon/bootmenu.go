@@ -3,34 +3,25 @@ package daemon
 import (
 	"context"
 	"fmt"
-	"os/exec"
 
-	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/bootloader"
 	"github.com/ubuntu/zsys/internal/i18n"
-	"github.com/ubuntu/zsys/internal/log"
 )
 
-const (
-	updateGrubCmd = "update-grub"
-)
-
-func updateBootMenu(ctx context.Context) error {
-	log.RemotePrintln(ctx, i18n.G("ZSys is adding automatic system snapshot to GRUB menu"))
-	cmd := exec.Command(updateGrubCmd)
-	logger := &logWriter{ctx: ctx}
-	cmd.Stdout = logger
-	cmd.Stderr = logger
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf(i18n.G("%q returned an error: ")+config.ErrorFormat, updateGrubCmd, err)
+// bootloaderBackend returns the bootloader backend selected in configuration.
+func (s *Server) bootloaderBackend() (bootloader.Backend, error) {
+	b, err := bootloader.New(s.Machines.Config())
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't select bootloader: %v"), err)
 	}
-	return nil
+	return b, nil
 }
 
-type logWriter struct {
-	ctx context.Context
-}
-
-func (lw logWriter) Write(p []byte) (n int, err error) {
-	log.Debug(lw.ctx, string(p))
-	return len(p), nil
+// updateBootMenu regenerates the bootloader menu with all bootable system states.
+func (s *Server) updateBootMenu(ctx context.Context) error {
+	b, err := s.bootloaderBackend()
+	if err != nil {
+		return err
+	}
+	return b.GenerateMenu(ctx, s.Machines.BootStates())
 }
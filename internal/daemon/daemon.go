@@ -12,6 +12,7 @@ import (
 	"github.com/coreos/go-systemd/activation"
 	"github.com/coreos/go-systemd/daemon"
 	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/audit"
 	"github.com/ubuntu/zsys/internal/authorizer"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
@@ -32,6 +33,9 @@ type Server struct {
 	socket     string
 	lis        net.Listener
 	grpcserver *grpc.Server
+	dbus       *dbusService
+	events     *eventsHub
+	audit      *audit.Log
 
 	// Those elements could be mocked in tests
 	authorizer        *authorizer.Authorizer
@@ -61,12 +65,24 @@ func WithLibZFS(libzfs libzfs.Interface) func(o *options) error {
 	}
 }
 
+// WithAuditLog changes the path of the audit log. Entries are sent to the journal as well if withJournal is set.
+func WithAuditLog(path string, withJournal bool) func(o *options) error {
+	return func(o *options) error {
+		o.auditPath = path
+		o.auditJournal = withJournal
+		return nil
+	}
+}
+
 type options struct {
 	timeout                   time.Duration
 	libzfs                    libzfs.Interface
+	auditPath                 string
+	auditJournal              bool
 	authorizer                *authorizer.Authorizer
 	systemdActivationListener func() ([]net.Listener, error)
 	systemdSdNotifier         func(unsetEnvironment bool, state string) (bool, error)
+	procCmdline               func() (string, error)
 }
 
 type option func(*options) error
@@ -78,7 +94,10 @@ func New(socket string, opts ...option) (*Server, error) {
 		timeout:                   config.DefaultServerIdleTimeout,
 		systemdActivationListener: activation.Listeners,
 		systemdSdNotifier:         daemon.SdNotify,
+		procCmdline:               procCmdline,
 		libzfs:                    &libzfs.Adapter{},
+		auditPath:                 config.DefaultAuditLogPath,
+		auditJournal:              true,
 	}
 	for _, o := range opts {
 		if err := o(&args); err != nil {
@@ -108,14 +127,23 @@ func New(socket string, opts ...option) (*Server, error) {
 		return nil, fmt.Errorf(i18n.G("unexpected number of systemd socket activation (%d != 1)"), len(listeners))
 	}
 
-	cmdline, err := procCmdline()
+	cmdline, err := args.procCmdline()
 	if err != nil {
 		return nil, fmt.Errorf(i18n.G("couldn't parse kernel command line: %v"), err)
 	}
-	ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(args.libzfs))
+	// D-Bus service is connected once the server is ready to answer requests.
+	var dbusSrv *dbusService
+	events := newEventsHub()
+	ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(args.libzfs),
+		machines.WithStatesObserver(func(changes []machines.StateChange) {
+			dbusSrv.emitStatesChanges(changes)
+			events.publishStatesChanges(changes)
+		}))
 	if err != nil {
 		return nil, fmt.Errorf(i18n.G("couldn't create a new machine: %v"), err)
 	}
+	// No request is running yet: any zsys hold was left over by an interrupted daemon.
+	ms.ReleaseStaleHolds(context.Background())
 
 	if args.authorizer == nil {
 		args.authorizer, err = authorizer.New()
@@ -124,11 +152,18 @@ func New(socket string, opts ...option) (*Server, error) {
 		}
 	}
 
+	var auditOpts []func(*audit.Log)
+	if !args.auditJournal {
+		auditOpts = append(auditOpts, audit.WithoutJournal())
+	}
+
 	s := &Server{
 		Machines: ms,
 
 		socket: socket,
 		lis:    lis,
+		events: events,
+		audit:  audit.New(args.auditPath, auditOpts...),
 
 		authorizer:        args.authorizer,
 		systemdSdNotifier: args.systemdSdNotifier,
@@ -138,6 +173,17 @@ func New(socket string, opts ...option) (*Server, error) {
 	grpcserver := zsys.RegisterServer(s)
 	s.grpcserver = grpcserver
 
+	// D-Bus is an additional interface for desktop tools: the daemon still serves the grpc socket without it.
+	if dbusSrv, err = newDBusService(); err != nil {
+		log.Warningf(context.Background(), i18n.G("couldn't connect to system bus, D-Bus interface is disabled: %v"), err)
+		dbusSrv = nil
+	} else if err := dbusSrv.export(s); err != nil {
+		log.Warningf(context.Background(), i18n.G("couldn't export zsys on system bus, D-Bus interface is disabled: %v"), err)
+		dbusSrv.stop()
+		dbusSrv = nil
+	}
+	s.dbus = dbusSrv
+
 	// Handle idle timeout
 	go s.idlerTimeout.start(s)
 
@@ -163,7 +209,10 @@ func (s *Server) Listen() error {
 // Stop gracefully stops the grpc server
 func (s *Server) Stop() {
 	log.Debug(context.Background(), i18n.G("Stopping daemon requested. Wait for active requests to close"))
+	// Subscriptions only end with the client: close them to not wait on them forever.
+	s.events.close()
 	s.grpcserver.GracefulStop()
+	s.dbus.stop()
 	log.Debug(context.Background(), i18n.G("All connections closed"))
 }
 
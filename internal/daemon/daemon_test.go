@@ -10,6 +10,7 @@ import (
 
 	"github.com/ubuntu/zsys/internal/daemon"
 	"github.com/ubuntu/zsys/internal/testutils"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
 )
 
 func TestServerStartStop(t *testing.T) {
@@ -259,6 +260,25 @@ func assertServerTimeout(t *testing.T, s *daemon.Server, errs chan error) {
 	}
 }
 
+func startDaemonAndListenWithLibZFS(t *testing.T, socket string, libzfs libzfs.Interface) (*daemon.Server, chan error) {
+	t.Helper()
+
+	s, err := daemon.New(socket, daemon.WithLibZFS(libzfs), daemon.WithAuditLog(filepath.Join(filepath.Dir(socket), "audit.log"), false))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	errs := make(chan error)
+	go func() {
+		if err := s.Listen(); err != nil {
+			errs <- fmt.Errorf("Server exited with error: %v", err)
+		}
+		close(errs)
+	}()
+
+	return s, errs
+}
+
 func startDaemonAndListen(t *testing.T, dir string, timeout time.Duration) (*daemon.Server, chan error) {
 	t.Helper()
 
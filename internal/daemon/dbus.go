@@ -0,0 +1,458 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+)
+
+//go:generate go run ../generators/copy.go com.ubuntu.zsys.conf dbus-1/system.d ../../generated
+//go:generate go run ../generators/copy.go com.ubuntu.zsys.service dbus-1/system-services ../../generated
+
+const (
+	dbusName      = "com.ubuntu.zsys"
+	dbusPath      = dbus.ObjectPath("/com/ubuntu/zsys")
+	dbusInterface = "com.ubuntu.zsys.Zsys"
+
+	dbusErrFailed            = "com.ubuntu.zsys.Error.Failed"
+	dbusErrPermissionDenied  = "com.ubuntu.zsys.Error.PermissionDenied"
+	dbusErrNeedsConfirmation = "com.ubuntu.zsys.Error.NeedsConfirmation"
+)
+
+const dbusIntrospection = `
+<node>
+  <interface name="` + dbusInterface + `">
+    <method name="ListMachines">
+      <arg name="machines" type="a(sbbx)" direction="out"/>
+    </method>
+    <method name="ShowMachine">
+      <arg name="id" type="s" direction="in"/>
+      <arg name="full" type="b" direction="in"/>
+      <arg name="info" type="s" direction="out"/>
+    </method>
+    <method name="ListStates">
+      <arg name="user" type="s" direction="in"/>
+      <arg name="states" type="a(sxbsa{ss})" direction="out"/>
+    </method>
+    <method name="SaveSystemState">
+      <arg name="name" type="s" direction="in"/>
+      <arg name="description" type="s" direction="in"/>
+      <arg name="labels" type="a{ss}" direction="in"/>
+      <arg name="updateBootMenu" type="b" direction="in"/>
+      <arg name="id" type="s" direction="out"/>
+    </method>
+    <method name="SaveUserState">
+      <arg name="user" type="s" direction="in"/>
+      <arg name="name" type="s" direction="in"/>
+      <arg name="description" type="s" direction="in"/>
+      <arg name="labels" type="a{ss}" direction="in"/>
+      <arg name="id" type="s" direction="out"/>
+    </method>
+    <method name="RemoveSystemState">
+      <arg name="name" type="s" direction="in"/>
+      <arg name="force" type="b" direction="in"/>
+    </method>
+    <method name="RemoveUserState">
+      <arg name="user" type="s" direction="in"/>
+      <arg name="name" type="s" direction="in"/>
+      <arg name="force" type="b" direction="in"/>
+    </method>
+    <method name="GC">
+      <arg name="all" type="b" direction="in"/>
+    </method>
+    <method name="UpdateBootMenu"/>
+    <signal name="StateAdded">
+      <arg name="id" type="s"/>
+      <arg name="user" type="s"/>
+    </signal>
+    <signal name="StateRemoved">
+      <arg name="id" type="s"/>
+      <arg name="user" type="s"/>
+    </signal>
+  </interface>` + introspect.IntrospectDataString + `</node>`
+
+// dbusService exposes the zsys service on the system bus, alongside the grpc socket, for desktop tools.
+type dbusService struct {
+	conn *dbus.Conn
+}
+
+// newDBusService opens a private connection to the system bus.
+func newDBusService() (*dbusService, error) {
+	conn, err := dbus.SystemBusPrivate()
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Auth(nil); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.Hello(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &dbusService{conn: conn}, nil
+}
+
+// export publishes the methods of s and requests our well-known name on the bus.
+func (d *dbusService) export(s *Server) error {
+	if err := d.conn.Export(dbusZsys{s: s, conn: d.conn}, dbusPath, dbusInterface); err != nil {
+		return fmt.Errorf(i18n.G("couldn't export %s: %v"), dbusInterface, err)
+	}
+	if err := d.conn.Export(introspect.Introspectable(dbusIntrospection), dbusPath, "org.freedesktop.DBus.Introspectable"); err != nil {
+		return fmt.Errorf(i18n.G("couldn't export introspection data: %v"), err)
+	}
+
+	// A new daemon replaces any previous instance still shutting down, which then loses the name for good.
+	reply, err := d.conn.RequestName(dbusName, dbus.NameFlagAllowReplacement|dbus.NameFlagReplaceExisting|dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't request name %s: %v"), dbusName, err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return fmt.Errorf(i18n.G("name %s is already owned"), dbusName)
+	}
+	return nil
+}
+
+// emitStatesChanges sends a StateAdded or StateRemoved signal for each change.
+func (d *dbusService) emitStatesChanges(changes []machines.StateChange) {
+	if d == nil {
+		return
+	}
+	for _, c := range changes {
+		signal := dbusInterface + ".StateAdded"
+		if c.Removed {
+			signal = dbusInterface + ".StateRemoved"
+		}
+		if err := d.conn.Emit(dbusPath, signal, c.ID, c.User); err != nil {
+			log.Warningf(context.Background(), i18n.G("couldn't emit %s for %s: %v"), signal, c.ID, err)
+		}
+	}
+}
+
+// stop releases our name and closes the connection to the bus.
+func (d *dbusService) stop() {
+	if d == nil {
+		return
+	}
+	d.conn.ReleaseName(dbusName)
+	d.conn.Close()
+}
+
+// dbusZsys is the object exported on the bus. Each of its exported methods is a D-Bus method.
+type dbusZsys struct {
+	s    *Server
+	conn *dbus.Conn
+}
+
+// dbusMachine is the D-Bus representation of a machine summary.
+type dbusMachine struct {
+	ID       string
+	IsZsys   bool
+	Current  bool
+	LastUsed int64
+}
+
+// dbusState is the D-Bus representation of a state.
+type dbusState struct {
+	ID          string
+	LastUsed    int64
+	Pinned      bool
+	Description string
+	Labels      map[string]string
+}
+
+// ListMachines returns a summary of all machines.
+func (o dbusZsys) ListMachines(sender dbus.Sender) ([]dbusMachine, *dbus.Error) {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionAlwaysAllowed, "")
+	if dErr != nil {
+		return nil, dErr
+	}
+
+	o.s.RWRequest.RLock()
+	defer o.s.RWRequest.RUnlock()
+
+	log.Infof(ctx, i18n.G("Retrieving list of machines."))
+
+	var r []dbusMachine
+	for _, m := range o.s.Machines.SortedMachines() {
+		r = append(r, dbusMachine{
+			ID:       m.ID,
+			IsZsys:   m.IsZsys,
+			Current:  o.s.Machines.IsCurrent(m),
+			LastUsed: m.LastUsed.Unix(),
+		})
+	}
+	return r, nil
+}
+
+// ShowMachine returns the information about the machine id, or the current machine if id is empty.
+func (o dbusZsys) ShowMachine(sender dbus.Sender, id string, full bool) (string, *dbus.Error) {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionAlwaysAllowed, "")
+	if dErr != nil {
+		return "", dErr
+	}
+
+	o.s.RWRequest.RLock()
+	defer o.s.RWRequest.RUnlock()
+
+	m, err := o.s.Machines.GetMachine(id)
+	if err != nil {
+		return "", dbusError(err)
+	}
+
+	log.Infof(ctx, i18n.G("Retrieving information for machine %s"), m.ID)
+
+	info, err := o.s.Machines.Info(ctx, m, full, "")
+	if err != nil {
+		return "", dbusError(fmt.Errorf(i18n.G("couldn't fetch matching information: %v"), err))
+	}
+	return info, nil
+}
+
+// ListStates returns the system states of the current machine, or the states of user if not empty.
+func (o dbusZsys) ListStates(sender dbus.Sender, user string) ([]dbusState, *dbus.Error) {
+	defer o.s.TrackRequest()()
+	action := authorizer.ActionSystemList
+	if user != "" {
+		action = authorizer.ActionUserWrite
+	}
+	ctx, dErr := o.authorize(sender, action, user)
+	if dErr != nil {
+		return nil, dErr
+	}
+
+	o.s.RWRequest.RLock()
+	defer o.s.RWRequest.RUnlock()
+
+	log.Info(ctx, i18n.G("Requesting states list"))
+
+	states, err := o.s.Machines.ListStates(ctx, user, machines.StateFilter{})
+	if err != nil {
+		return nil, dbusError(err)
+	}
+
+	var r []dbusState
+	for _, ls := range states {
+		var lastUsed int64
+		if !ls.State.LastUsed.IsZero() {
+			lastUsed = ls.State.LastUsed.Unix()
+		}
+		r = append(r, dbusState{
+			ID:          ls.ID,
+			LastUsed:    lastUsed,
+			Pinned:      ls.State.IsPinned(),
+			Description: ls.State.Description(),
+			Labels:      ls.State.Labels(),
+		})
+	}
+	return r, nil
+}
+
+// SaveSystemState saves the current system state and its users. An ID is generated if name is empty.
+func (o dbusZsys) SaveSystemState(sender dbus.Sender, name, description string, labels map[string]string, updateBootMenu bool) (string, *dbus.Error) {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionSystemWrite, "")
+	if dErr != nil {
+		return "", dErr
+	}
+
+	o.s.RWRequest.Lock()
+	defer o.s.RWRequest.Unlock()
+
+	var err error
+	defer o.s.audited(ctx, "SaveSystemState", authorizer.ActionSystemWrite, map[string]string{
+		"state":       name,
+		"description": description,
+	})(&err)
+
+	log.Info(ctx, i18n.G("Requesting to save current system state"))
+
+	id, err := o.s.Machines.CreateSystemSnapshot(ctx, name, description, labels)
+	if err != nil {
+		return "", dbusError(err)
+	}
+	if updateBootMenu {
+		if err = o.s.updateBootMenu(ctx); err != nil {
+			return "", dbusError(err)
+		}
+	}
+	return id, nil
+}
+
+// SaveUserState saves the current state of user. An ID is generated if name is empty.
+func (o dbusZsys) SaveUserState(sender dbus.Sender, user, name, description string, labels map[string]string) (string, *dbus.Error) {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionUserWrite, user)
+	if dErr != nil {
+		return "", dErr
+	}
+
+	o.s.RWRequest.Lock()
+	defer o.s.RWRequest.Unlock()
+
+	var err error
+	defer o.s.audited(ctx, "SaveUserState", authorizer.ActionUserWrite, map[string]string{
+		"user":        user,
+		"state":       name,
+		"description": description,
+	})(&err)
+
+	log.Infof(ctx, i18n.G("Requesting to save state for user %q"), user)
+
+	id, err := o.s.Machines.CreateUserSnapshot(ctx, user, name, description, labels)
+	if err != nil {
+		return "", dbusError(err)
+	}
+	return id, nil
+}
+
+// RemoveSystemState removes the system state name. States depending on it are only removed if force is set.
+func (o dbusZsys) RemoveSystemState(sender dbus.Sender, name string, force bool) *dbus.Error {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionSystemWrite, "")
+	if dErr != nil {
+		return dErr
+	}
+
+	o.s.RWRequest.Lock()
+	defer o.s.RWRequest.Unlock()
+
+	var err error
+	defer o.s.audited(ctx, "RemoveSystemState", authorizer.ActionSystemWrite, map[string]string{
+		"state": name,
+		"force": boolArg(force),
+	})(&err)
+
+	if name == "" {
+		err = errors.New(i18n.G("System state name is required"))
+		return dbusError(err)
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to remove system state %q"), name)
+
+	if err = o.s.Machines.RemoveState(ctx, name, "", force, false); err != nil {
+		return dbusError(err)
+	}
+	if err = o.s.updateBootMenu(ctx); err != nil {
+		return dbusError(err)
+	}
+	return nil
+}
+
+// RemoveUserState removes the state name of user. States depending on it are only removed if force is set.
+func (o dbusZsys) RemoveUserState(sender dbus.Sender, user, name string, force bool) *dbus.Error {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionUserWrite, user)
+	if dErr != nil {
+		return dErr
+	}
+
+	o.s.RWRequest.Lock()
+	defer o.s.RWRequest.Unlock()
+
+	var err error
+	defer o.s.audited(ctx, "RemoveUserState", authorizer.ActionUserWrite, map[string]string{
+		"user":  user,
+		"state": name,
+		"force": boolArg(force),
+	})(&err)
+
+	if name == "" {
+		err = errors.New(i18n.G("State name is required"))
+		return dbusError(err)
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to remove user state %q for user %s"), name, user)
+
+	if err = o.s.Machines.RemoveState(ctx, name, user, force, false); err != nil {
+		return dbusError(err)
+	}
+	return nil
+}
+
+// GC runs the garbage collection. Manual states are collected too if all is set.
+func (o dbusZsys) GC(sender dbus.Sender, all bool) *dbus.Error {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionAlwaysAllowed, "")
+	if dErr != nil {
+		return dErr
+	}
+
+	o.s.RWRequest.Lock()
+	defer o.s.RWRequest.Unlock()
+
+	var err error
+	defer o.s.audited(ctx, "GC", authorizer.ActionAlwaysAllowed, map[string]string{
+		"all": boolArg(all),
+	})(&err)
+
+	log.Info(ctx, i18n.G("Requesting zsys daemon to garbage collect"))
+
+	if err = o.s.Machines.GC(ctx, all); err != nil {
+		return dbusError(err)
+	}
+	o.s.events.publishEvent(zsys.Event_GC_FINISHED, "", "", "")
+	return nil
+}
+
+// UpdateBootMenu regenerates the bootloader menu.
+func (o dbusZsys) UpdateBootMenu(sender dbus.Sender) *dbus.Error {
+	defer o.s.TrackRequest()()
+	ctx, dErr := o.authorize(sender, authorizer.ActionSystemWrite, "")
+	if dErr != nil {
+		return dErr
+	}
+
+	o.s.RWRequest.Lock()
+	defer o.s.RWRequest.Unlock()
+
+	var err error
+	defer o.s.audited(ctx, "UpdateBootMenu", authorizer.ActionSystemWrite, nil)(&err)
+
+	log.Infof(ctx, i18n.G("Updating system boot menu"))
+
+	if err = o.s.updateBootMenu(ctx); err != nil {
+		return dbusError(err)
+	}
+	return nil
+}
+
+// authorize returns the context of the request from sender, once allowed to perform action on user, if any.
+// The sender credentials are retrieved from the bus, as the socket credentials are for grpc requests.
+func (o dbusZsys) authorize(sender dbus.Sender, action authorizer.Action, user string) (context.Context, *dbus.Error) {
+	var uid, pid uint32
+	if err := o.conn.BusObject().Call("org.freedesktop.DBus.GetConnectionUnixUser", 0, string(sender)).Store(&uid); err != nil {
+		return nil, dbusError(fmt.Errorf(i18n.G("couldn't get uid of %s: %v"), sender, err))
+	}
+	if err := o.conn.BusObject().Call("org.freedesktop.DBus.GetConnectionUnixProcessID", 0, string(sender)).Store(&pid); err != nil {
+		return nil, dbusError(fmt.Errorf(i18n.G("couldn't get pid of %s: %v"), sender, err))
+	}
+
+	ctx := authorizer.ContextWithPeerCreds(context.Background(), uid, int32(pid))
+	if user != "" {
+		ctx = context.WithValue(ctx, authorizer.OnUserKey, user)
+	}
+	if err := o.s.authorizer.IsAllowedFromContext(ctx, action); err != nil {
+		return nil, dbus.NewError(dbusErrPermissionDenied, []interface{}{err.Error()})
+	}
+	return ctx, nil
+}
+
+// dbusError converts err to a D-Bus error, telling apart removals needing a confirmation.
+func dbusError(err error) *dbus.Error {
+	var e *machines.ErrStateRemovalNeedsConfirmation
+	if errors.As(err, &e) {
+		return dbus.NewError(dbusErrNeedsConfirmation, []interface{}{e.Error()})
+	}
+	return dbus.NewError(dbusErrFailed, []interface{}{err.Error()})
+}
@@ -0,0 +1,334 @@
+package daemon_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/daemon"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+// dbusMachine is the structure of machines returned by ListMachines.
+type dbusMachine struct {
+	ID       string
+	IsZsys   bool
+	Current  bool
+	LastUsed int64
+}
+
+// dbusState is the structure of states returned by ListStates.
+type dbusState struct {
+	ID          string
+	LastUsed    int64
+	Pinned      bool
+	Description string
+	Labels      map[string]string
+}
+
+func TestDBusService(t *testing.T) {
+	//t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		method string
+		args   []interface{}
+
+		wantMachines      []dbusMachine
+		wantIntrospection bool
+		wantErrName       string
+	}{
+		"List machines":                         {method: "ListMachines", wantMachines: []dbusMachine{{ID: "rpool/ROOT/ubuntu_1234", IsZsys: true, LastUsed: 1555555555}}},
+		"Introspection describes the interface": {method: "org.freedesktop.DBus.Introspectable.Introspect", wantIntrospection: true},
+
+		// Error cases
+		"Error on unknown machine": {method: "ShowMachine", args: []interface{}{"unknown", false}, wantErrName: "com.ubuntu.zsys.Error.Failed"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "one_machine.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			s, err := daemon.New(filepath.Join(dir, "daemon_test.sock"), daemon.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			defer s.Stop()
+
+			conn, err := dbus.SystemBusPrivate()
+			if err != nil {
+				t.Fatalf("couldn't connect to system bus: %v", err)
+			}
+			defer conn.Close()
+			if err := conn.Auth(nil); err != nil {
+				t.Fatalf("couldn't authenticate on system bus: %v", err)
+			}
+			if err := conn.Hello(); err != nil {
+				t.Fatalf("couldn't say hello on system bus: %v", err)
+			}
+
+			method := tc.method
+			if !strings.Contains(method, ".") {
+				method = "com.ubuntu.zsys.Zsys." + method
+			}
+			call := conn.Object("com.ubuntu.zsys", "/com/ubuntu/zsys").Call(method, 0, tc.args...)
+
+			if tc.wantErrName != "" {
+				e, ok := call.Err.(dbus.Error)
+				if !ok {
+					t.Fatalf("expected a D-Bus error but got: %v", call.Err)
+				}
+				assert.Equal(t, tc.wantErrName, e.Name, "D-Bus error name should match")
+				return
+			}
+			if call.Err != nil {
+				t.Fatalf("expected no error but got: %v", call.Err)
+			}
+
+			if tc.wantIntrospection {
+				var xml string
+				if err := call.Store(&xml); err != nil {
+					t.Fatalf("couldn't read introspection data: %v", err)
+				}
+				assert.Contains(t, xml, `<interface name="com.ubuntu.zsys.Zsys">`, "introspection data should describe zsys interface")
+				return
+			}
+
+			var got []dbusMachine
+			if err := call.Store(&got); err != nil {
+				t.Fatalf("couldn't read reply: %v", err)
+			}
+			assert.Equal(t, tc.wantMachines, got, "D-Bus reply should match")
+		})
+	}
+}
+
+func TestDBusStateChanges(t *testing.T) {
+	//t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		method string
+		args   []interface{}
+
+		wantID     string
+		wantSignal string
+		wantState  string
+		wantUser   string
+	}{
+		"Save system state emits StateAdded": {method: "SaveSystemState", args: []interface{}{"new_state", "", map[string]string{}, false},
+			wantID: "new_state", wantSignal: "StateAdded", wantState: "rpool/ROOT/ubuntu_1234@new_state"},
+		"Save user state emits StateAdded": {method: "SaveUserState", args: []interface{}{"root", "new_state", "", map[string]string{}},
+			wantID: "new_state", wantSignal: "StateAdded", wantState: "rpool/USERDATA/root_abcd@new_state", wantUser: "root"},
+		"Remove user state emits StateRemoved": {method: "RemoveUserState", args: []interface{}{"root", "user_state", false},
+			wantSignal: "StateRemoved", wantState: "rpool/USERDATA/root_abcd@user_state", wantUser: "root"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			_, cleanupDaemon := newDBusTestDaemon(t, dir)
+			defer cleanupDaemon()
+
+			conn := connectPrivateSystemBus(t)
+			defer conn.Close()
+			if err := conn.AddMatchSignal(dbus.WithMatchInterface("com.ubuntu.zsys.Zsys")); err != nil {
+				t.Fatalf("couldn't subscribe to zsys signals: %v", err)
+			}
+			signals := make(chan *dbus.Signal, 10)
+			conn.Signal(signals)
+
+			call := conn.Object("com.ubuntu.zsys", "/com/ubuntu/zsys").Call("com.ubuntu.zsys.Zsys."+tc.method, 0, tc.args...)
+			if call.Err != nil {
+				t.Fatalf("expected no error but got: %v", call.Err)
+			}
+			if tc.wantID != "" {
+				var id string
+				if err := call.Store(&id); err != nil {
+					t.Fatalf("couldn't read reply: %v", err)
+				}
+				assert.Equal(t, tc.wantID, id, "returned state ID should match")
+			}
+
+			timeout := time.After(5 * time.Second)
+			for {
+				select {
+				case sig := <-signals:
+					if sig.Name != "com.ubuntu.zsys.Zsys."+tc.wantSignal || sig.Body[0] != tc.wantState {
+						continue
+					}
+					assert.Equal(t, dbus.ObjectPath("/com/ubuntu/zsys"), sig.Path, "signal should be emitted on zsys object")
+					assert.Equal(t, tc.wantUser, sig.Body[1], "signal user should match")
+					return
+				case <-timeout:
+					t.Fatalf("didn't receive %s signal for %s", tc.wantSignal, tc.wantState)
+				}
+			}
+		})
+	}
+}
+
+func TestDBusPermissionDenied(t *testing.T) {
+	//t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		method string
+		args   []interface{}
+	}{
+		"Remove system state":          {method: "RemoveSystemState", args: []interface{}{"system_state", false}},
+		"Remove state of another user": {method: "RemoveUserState", args: []interface{}{"root", "user_state", false}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			_, cleanupDaemon := newDBusTestDaemon(t, dir)
+			defer cleanupDaemon()
+
+			errName := callAsUnprivilegedUser(t, dir, tc.method, tc.args)
+			assert.Equal(t, "com.ubuntu.zsys.Error.PermissionDenied", errName, "D-Bus error name should match")
+
+			// The states are still there
+			conn := connectPrivateSystemBus(t)
+			defer conn.Close()
+			var ids []string
+			for _, user := range []string{"", "root"} {
+				var states []dbusState
+				if err := conn.Object("com.ubuntu.zsys", "/com/ubuntu/zsys").Call("com.ubuntu.zsys.Zsys.ListStates", 0, user).Store(&states); err != nil {
+					t.Fatalf("couldn't list states: %v", err)
+				}
+				for _, st := range states {
+					ids = append(ids, st.ID)
+				}
+			}
+			assert.Contains(t, ids, "rpool/ROOT/ubuntu_1234@system_state", "system state should not be removed")
+			assert.Contains(t, ids, "rpool/USERDATA/root_abcd@user_state", "user state should not be removed")
+		})
+	}
+}
+
+// TestDBusCallHelper isn't a real test: it is run in a separate process by callAsUnprivilegedUser
+// and prints the D-Bus error name of the call, if any.
+func TestDBusCallHelper(t *testing.T) {
+	if os.Getenv("ZSYS_TEST_DBUS_HELPER") != "1" {
+		return
+	}
+
+	var args []interface{}
+	if err := json.Unmarshal([]byte(os.Getenv("ZSYS_TEST_DBUS_ARGS")), &args); err != nil {
+		t.Fatalf("couldn't decode call arguments: %v", err)
+	}
+
+	conn := connectPrivateSystemBus(t)
+	defer conn.Close()
+	call := conn.Object("com.ubuntu.zsys", "/com/ubuntu/zsys").Call("com.ubuntu.zsys.Zsys."+os.Getenv("ZSYS_TEST_DBUS_METHOD"), 0, args...)
+	if e, ok := call.Err.(dbus.Error); ok {
+		fmt.Printf("DBUS_ERROR=%s\n", e.Name)
+	}
+}
+
+// callAsUnprivilegedUser calls method on the zsys D-Bus service from a process running as nobody and returns the
+// D-Bus error name of the reply. Administrators are always allowed, and nobody can't be authorized as there is
+// no polkit on the local system bus.
+func callAsUnprivilegedUser(t *testing.T, dir, method string, args []interface{}) string {
+	t.Helper()
+
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		t.Fatalf("couldn't encode call arguments: %v", err)
+	}
+
+	exe := os.Args[0]
+	var attr *syscall.SysProcAttr
+	if os.Getuid() == 0 {
+		const nobody = 65534
+
+		// The test binary directory is only accessible by us.
+		exe = filepath.Join(dir, "dbus_helper.test")
+		content, err := ioutil.ReadFile(os.Args[0])
+		if err != nil {
+			t.Fatalf("couldn't read test binary: %v", err)
+		}
+		if err := ioutil.WriteFile(exe, content, 0755); err != nil {
+			t.Fatalf("couldn't copy test binary: %v", err)
+		}
+		if err := os.Chmod(dir, 0755); err != nil {
+			t.Fatalf("couldn't change permissions of %q: %v", dir, err)
+		}
+		attr = &syscall.SysProcAttr{Credential: &syscall.Credential{Uid: nobody, Gid: nobody}}
+	}
+
+	cmd := exec.Command(exe, "-test.run=^TestDBusCallHelper$")
+	cmd.Env = append(os.Environ(), "ZSYS_TEST_DBUS_HELPER=1", "ZSYS_TEST_DBUS_METHOD="+method, "ZSYS_TEST_DBUS_ARGS="+string(encodedArgs))
+	cmd.SysProcAttr = attr
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("D-Bus helper process failed: %v\n%s", err, out)
+	}
+
+	for _, l := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(l, "DBUS_ERROR=") {
+			return strings.TrimPrefix(l, "DBUS_ERROR=")
+		}
+	}
+	return ""
+}
+
+// newDBusTestDaemon starts a daemon on the current machine of testdata/one_machine_with_states.yaml, with pools
+// and audit log in dir.
+func newDBusTestDaemon(t *testing.T, dir string) (s *daemon.Server, cleanup func()) {
+	t.Helper()
+
+	libzfs := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "one_machine_with_states.yaml"), testutils.WithLibZFS(libzfs))
+	cleanupPools := fPools.Create(dir)
+
+	s, err := daemon.New(filepath.Join(dir, "daemon_test.sock"), daemon.WithLibZFS(libzfs),
+		daemon.WithKernelCmdline("root=ZFS=rpool/ROOT/ubuntu_1234"), daemon.WithAuditLog(filepath.Join(dir, "audit.log"), false))
+	if err != nil {
+		cleanupPools()
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	return s, func() {
+		s.Stop()
+		cleanupPools()
+	}
+}
+
+// connectPrivateSystemBus returns a new authenticated connection to the local system bus.
+func connectPrivateSystemBus(t *testing.T) *dbus.Conn {
+	t.Helper()
+
+	conn, err := dbus.SystemBusPrivate()
+	if err != nil {
+		t.Fatalf("couldn't connect to system bus: %v", err)
+	}
+	if err := conn.Auth(nil); err != nil {
+		conn.Close()
+		t.Fatalf("couldn't authenticate on system bus: %v", err)
+	}
+	if err := conn.Hello(); err != nil {
+		conn.Close()
+		t.Fatalf("couldn't say hello on system bus: %v", err)
+	}
+	return conn
+}
@@ -0,0 +1,172 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/authorizer"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+)
+
+// subscriberQueueSize is the number of events a subscriber can lag behind before events are dropped for it.
+const subscriberQueueSize = 100
+
+// eventsFilter selects the events sent to a subscriber.
+type eventsFilter struct {
+	machine string
+	user    string
+}
+
+// match returns true if e concerns the machine and user of the filter.
+// Events not related to any machine or user, like configuration reload, always match.
+func (f eventsFilter) match(e *zsys.Event) bool {
+	if e.GetMachine() == "" && e.GetUser() == "" {
+		return true
+	}
+	if f.machine != "" && e.GetMachine() != f.machine {
+		return false
+	}
+	if f.user != "" && e.GetUser() != f.user {
+		return false
+	}
+	return true
+}
+
+// eventsHub dispatches events to every subscriber.
+type eventsHub struct {
+	mu          sync.Mutex
+	subscribers map[chan *zsys.Event]eventsFilter
+	closed      bool
+}
+
+func newEventsHub() *eventsHub {
+	return &eventsHub{subscribers: make(map[chan *zsys.Event]eventsFilter)}
+}
+
+// subscribe returns a channel receiving the events matching filter. It is closed on unsubscribe or when the hub closes.
+func (h *eventsHub) subscribe(filter eventsFilter) (events <-chan *zsys.Event, unsubscribe func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan *zsys.Event, subscriberQueueSize)
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subscribers[ch] = filter
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; !ok {
+			return
+		}
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publish sends e to every matching subscriber, without waiting for slow ones.
+func (h *eventsHub) publish(e *zsys.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch, filter := range h.subscribers {
+		if !filter.match(e) {
+			continue
+		}
+		select {
+		case ch <- e:
+		default:
+			log.Warningf(context.Background(), i18n.G("Subscriber is too slow, dropping %s event"), e.GetType())
+		}
+	}
+}
+
+// close ends every subscription, so that the server can stop.
+func (h *eventsHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// publishEvent sends an event of type t to subscribers.
+func (h *eventsHub) publishEvent(t zsys.Event_Type, machine, user, state string) {
+	h.publish(&zsys.Event{
+		Type:    t,
+		Time:    time.Now().Unix(),
+		Machine: machine,
+		User:    user,
+		State:   state,
+	})
+}
+
+// publishStatesChanges sends a state created or removed event for each change.
+func (h *eventsHub) publishStatesChanges(changes []machines.StateChange) {
+	for _, c := range changes {
+		t := zsys.Event_STATE_CREATED
+		if c.Removed {
+			t = zsys.Event_STATE_REMOVED
+		}
+		h.publishEvent(t, c.Machine, c.User, c.ID)
+	}
+}
+
+// publishCurrentMachineEvent sends an event of type t, about user if any, on the current machine to subscribers.
+func (s *Server) publishCurrentMachineEvent(t zsys.Event_Type, user string) {
+	var machine string
+	if m, err := s.Machines.GetMachine(""); err == nil {
+		machine = m.ID
+	}
+	s.events.publishEvent(t, machine, user, "")
+}
+
+// Subscribe streams the events matching the optional machine and user until the client or the server stops.
+// As any request in flight, a subscription prevents the daemon from idling out.
+func (s *Server) Subscribe(req *zsys.SubscribeRequest, stream zsys.Zsys_SubscribeServer) error {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
+		return err
+	}
+
+	filter := eventsFilter{user: req.GetUserName()}
+	if id := req.GetMachineId(); id != "" {
+		s.RWRequest.RLock()
+		m, err := s.Machines.GetMachine(id)
+		s.RWRequest.RUnlock()
+		if err != nil {
+			return err
+		}
+		filter.machine = m.ID
+	}
+
+	events, unsubscribe := s.events.subscribe(filter)
+	defer unsubscribe()
+
+	log.Info(stream.Context(), i18n.G("Subscribed to events"))
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(&zsys.SubscribeResponse{
+				Reply: &zsys.SubscribeResponse_Event{Event: e},
+			}); err != nil {
+				return fmt.Errorf(i18n.G("couldn't send event to client: %v"), err)
+			}
+		}
+	}
+}
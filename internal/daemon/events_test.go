@@ -0,0 +1,118 @@
+package daemon_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/streamlogger"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+func TestSubscribe(t *testing.T) {
+	//t.Parallel()
+	defer testutils.StartLocalSystemBus(t)()
+
+	tests := map[string]struct {
+		machineID string
+		userName  string
+
+		wantType zsys.Event_Type
+		wantErr  bool
+	}{
+		"Receive GC finished event":                  {wantType: zsys.Event_GC_FINISHED},
+		"Global events are sent to filtered user":    {userName: "user1", wantType: zsys.Event_GC_FINISHED},
+		"Global events are sent to filtered machine": {machineID: "ubuntu_1234", wantType: zsys.Event_GC_FINISHED},
+
+		// Error cases
+		"Error on unknown machine": {machineID: "unknown", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "one_machine.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			socket := filepath.Join(dir, "daemon_test.sock")
+			s, errs := startDaemonAndListenWithLibZFS(t, socket, libzfs)
+			defer func() {
+				s.Stop()
+				<-errs
+			}()
+
+			client, err := zsys.NewZsysUnixSocketClient(socket, logrus.InfoLevel)
+			if err != nil {
+				t.Fatalf("couldn't create client: %v", err)
+			}
+			defer client.Close()
+
+			stream, err := client.Subscribe(client.Ctx, &zsys.SubscribeRequest{MachineId: tc.machineID, UserName: tc.userName})
+			if err != nil {
+				t.Fatalf("expected no error on subscription but got: %v", err)
+			}
+			events := make(chan *zsys.Event)
+			errc := make(chan error, 1)
+			go func() {
+				for {
+					r, err := stream.Recv()
+					if err == streamlogger.ErrLogMsg {
+						continue
+					}
+					if err != nil {
+						errc <- err
+						return
+					}
+					events <- r.GetEvent()
+				}
+			}()
+
+			if tc.wantErr {
+				select {
+				case <-time.After(5 * time.Second):
+					t.Fatal("expected an error but subscription is still running")
+				case err := <-errc:
+					if err == io.EOF {
+						t.Fatal("expected an error but stream ended successfully")
+					}
+				}
+				return
+			}
+
+			// The subscription is registered asynchronously on the server: trigger GC until we get our event.
+			timeout := time.After(5 * time.Second)
+			ticker := time.NewTicker(100 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				gc, err := client.GC(client.Ctx, &zsys.GCRequest{})
+				if err != nil {
+					t.Fatalf("couldn't request GC: %v", err)
+				}
+				for {
+					if _, err := gc.Recv(); err != nil && err != streamlogger.ErrLogMsg {
+						break
+					}
+				}
+
+				select {
+				case err := <-errc:
+					t.Fatalf("subscription ended unexpectedly: %v", err)
+				case e := <-events:
+					assert.Equal(t, tc.wantType, e.GetType(), "Event type should match")
+					assert.NotZero(t, e.GetTime(), "Event should have a time")
+					return
+				case <-timeout:
+					t.Fatal("didn't receive any event")
+				case <-ticker.C:
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,13 @@ func WithSystemdSdNotifier(f func(unsetEnvironment bool, state string) (bool, er
 	}
 }
 
+func WithKernelCmdline(cmdline string) func(o *options) error {
+	return func(o *options) error {
+		o.procCmdline = func() (string, error) { return cmdline, nil }
+		return nil
+	}
+}
+
 func FailingOption() func(o *options) error {
 	return func(o *options) error {
 		return errors.New("failing option")
@@ -1,12 +1,16 @@
 package daemon
 
 import (
+	"context"
 	"fmt"
+	"sort"
 
 	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/authorizer"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
+	"github.com/ubuntu/zsys/internal/zfs"
 )
 
 // MachineShow returns information about the machine id passed in argument
@@ -24,7 +28,20 @@ func (s *Server) MachineShow(req *zsys.MachineShowRequest, stream zsys.Zsys_Mach
 
 	log.Infof(stream.Context(), i18n.G("Retrieving information for machine %s"), m.ID)
 
-	machineInfo, err := m.Info(fullInfo)
+	if req.GetStructured() {
+		machine, err := s.machineToProto(stream.Context(), m, fullInfo, req.GetSort())
+		if err != nil {
+			return fmt.Errorf(i18n.G("couldn't fetch matching information: %v"), err)
+		}
+		stream.Send(&zsys.MachineShowResponse{
+			Reply: &zsys.MachineShowResponse_Machine{
+				Machine: machine,
+			},
+		})
+		return nil
+	}
+
+	machineInfo, err := s.Machines.Info(stream.Context(), m, fullInfo, req.GetSort())
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't fetch matching information: %v"), err)
 	}
@@ -40,13 +57,31 @@ func (s *Server) MachineShow(req *zsys.MachineShowRequest, stream zsys.Zsys_Mach
 }
 
 // MachineList returns a list of machines and their summary
-func (s *Server) MachineList(req *zsys.Empty, stream zsys.Zsys_MachineListServer) error {
+func (s *Server) MachineList(req *zsys.MachineListRequest, stream zsys.Zsys_MachineListServer) error {
 	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
 		return err
 	}
 
 	log.Infof(stream.Context(), i18n.G("Retrieving list of machines."))
 
+	if req.GetStructured() {
+		var summaries []*zsys.MachineSummary
+		for _, m := range s.Machines.SortedMachines() {
+			summaries = append(summaries, &zsys.MachineSummary{
+				Id:       m.ID,
+				IsZsys:   m.IsZsys,
+				Current:  s.Machines.IsCurrent(m),
+				LastUsed: m.LastUsed.Unix(),
+			})
+		}
+		stream.Send(&zsys.MachineListResponse{
+			Reply: &zsys.MachineListResponse_Machines{
+				Machines: &zsys.MachineSummaries{Machines: summaries},
+			},
+		})
+		return nil
+	}
+
 	machinesList, err := s.Machines.List()
 	if err != nil {
 		return fmt.Errorf(i18n.G("couldn't fetch list of machines: %v"), err)
@@ -61,3 +96,123 @@ func (s *Server) MachineList(req *zsys.Empty, stream zsys.Zsys_MachineListServer
 	return nil
 
 }
+
+// machineToProto converts a machine to its structured representation, with history ordered by sortBy.
+// Datasets and space accounting are only listed when full is requested, as for the text output.
+func (s *Server) machineToProto(ctx context.Context, m *machines.Machine, full bool, sortBy string) (*zsys.Machine, error) {
+	historyIDs, err := s.Machines.SortedHistoryIDs(ctx, m, sortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	toProto := func(id string, st *machines.State) *zsys.State {
+		r := stateToProto(id, st, full)
+		if full {
+			space := s.Machines.StateSpace(ctx, st)
+			r.Space = &zsys.Space{
+				Used:        space.Used,
+				Referenced:  space.Referenced,
+				Unique:      space.Unique,
+				Written:     space.Written,
+				Reclaimable: space.Reclaimable,
+			}
+		}
+		return r
+	}
+
+	r := &zsys.Machine{
+		Id:      m.ID,
+		IsZsys:  m.IsZsys,
+		Current: s.Machines.IsCurrent(m),
+		State:   toProto(m.ID, &m.State),
+	}
+
+	for _, id := range historyIDs {
+		r.History = append(r.History, toProto(id, m.History[id]))
+	}
+
+	if full {
+		for _, d := range m.PersistentDatasets {
+			r.PersistentDatasets = append(r.PersistentDatasets, datasetToProto(d))
+		}
+	}
+
+	for user := range m.AllUsersStates {
+		if r.Users == nil {
+			r.Users = make(map[string]*zsys.UserStates)
+		}
+		us := &zsys.UserStates{}
+		// user states are referenced by their unique id, as some can be attached to multiple system states.
+		for _, uid := range m.UserStateIDs(user) {
+			us.States = append(us.States, toProto(uid, m.AllUsersStates[user][uid]))
+		}
+		r.Users[user] = us
+	}
+
+	return r, nil
+}
+
+// stateToProto converts a state, referenced by id, to its structured representation.
+func stateToProto(id string, s *machines.State, full bool) *zsys.State {
+	r := &zsys.State{
+		Id:       id,
+		LastUsed: s.LastUsed.Unix(),
+	}
+	if ds := s.Datasets[s.ID]; len(ds) > 0 {
+		r.Current = ds[0].Mounted
+		r.LastBootedKernel = ds[0].LastBootedKernel
+	}
+	r.Pinned = s.IsPinned()
+	r.Description = s.Description()
+	r.Labels = s.Labels()
+
+	if !full {
+		return r
+	}
+
+	for _, p := range s.Pools() {
+		r.Pools = append(r.Pools, &zsys.PoolMembership{Name: p.Name, Roles: p.Roles})
+	}
+
+	for _, ds := range s.Datasets {
+		for _, d := range ds {
+			r.Datasets = append(r.Datasets, datasetToProto(d))
+		}
+	}
+	sort.Slice(r.Datasets, func(i, j int) bool { return r.Datasets[i].Name < r.Datasets[j].Name })
+
+	for u, us := range s.Users {
+		if r.Users == nil {
+			r.Users = make(map[string]*zsys.State)
+		}
+		r.Users[u] = stateToProto(us.ID, us, full)
+	}
+
+	return r
+}
+
+// datasetToProto converts a dataset to its structured representation.
+func datasetToProto(d *zfs.Dataset) *zsys.Dataset {
+	return &zsys.Dataset{
+		Name:             d.Name,
+		IsSnapshot:       d.IsSnapshot,
+		Mountpoint:       d.Mountpoint,
+		CanMount:         d.CanMount,
+		Mounted:          d.Mounted,
+		Bootfs:           d.BootFS,
+		LastUsed:         int64(d.LastUsed),
+		LastBootedKernel: d.LastBootedKernel,
+		BootfsDatasets:   d.BootfsDatasets,
+		Origin:           d.Origin,
+		PendingRevert:    d.PendingRevert,
+		Used:             d.Used,
+		Referenced:       d.Referenced,
+		UsedBySnapshots:  d.UsedBySnapshots,
+		Written:          d.Written,
+		Pinned:           d.Pinned,
+		Description:      d.Description,
+		Labels:           d.Labels,
+		Quota:            d.Quota,
+		Reservation:      d.Reservation,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ubuntu/zsys"
+	"github.com/ubuntu/zsys/internal/audit"
 	"github.com/ubuntu/zsys/internal/authorizer"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
@@ -167,18 +168,188 @@ func (s *Server) Reload(req *zsys.Empty, stream zsys.Zsys_ReloadServer) error {
 	}
 	log.Info(stream.Context(), i18n.G("Reloading daemon configuration"))
 
-	return s.Machines.Reload(stream.Context())
+	if err := s.Machines.Reload(stream.Context()); err != nil {
+		return err
+	}
+	s.events.publishEvent(zsys.Event_CONFIG_RELOADED, "", "", "")
+	return nil
 }
 
 // GC call machine garbage collection stops zsys daemon
-func (s *Server) GC(req *zsys.GCRequest, stream zsys.Zsys_GCServer) error {
+func (s *Server) GC(req *zsys.GCRequest, stream zsys.Zsys_GCServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
+		return err
+	}
+	if !req.GetDryRun() {
+		log.Info(stream.Context(), i18n.G("Requesting zsys daemon to garbage collect"))
+
+		s.RWRequest.Lock()
+		defer s.RWRequest.Unlock()
+
+		defer s.audited(stream.Context(), "GC", authorizer.ActionAlwaysAllowed, map[string]string{
+			"all": boolArg(req.GetAll()),
+		})(&err)
+
+		if err := s.Machines.GC(stream.Context(), req.GetAll()); err != nil {
+			return err
+		}
+		s.events.publishEvent(zsys.Event_GC_FINISHED, "", "", "")
+		return nil
+	}
+
+	log.Info(stream.Context(), i18n.G("Requesting zsys daemon garbage collection plan"))
+
+	s.RWRequest.RLock()
+	defer s.RWRequest.RUnlock()
+
+	decisions, err := s.Machines.GCDryRun(stream.Context(), req.GetAll())
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't compute garbage collection plan: ")+config.ErrorFormat, err)
+	}
+
+	for _, d := range decisions {
+		var lastUsed int64
+		if !d.LastUsed.IsZero() {
+			lastUsed = d.LastUsed.Unix()
+		}
+		if err := stream.Send(&zsys.GCResponse{
+			Reply: &zsys.GCResponse_Decision{
+				Decision: &zsys.GCDecision{
+					Machine:  d.Machine,
+					User:     d.User,
+					State:    d.State,
+					LastUsed: lastUsed,
+					Bucket:   d.Bucket,
+					Remove:   d.Remove,
+					Reason:   d.Reason,
+				},
+			},
+		}); err != nil {
+			return fmt.Errorf(i18n.G("couldn't send garbage collection plan to client: %v"), err)
+		}
+	}
+
+	return nil
+}
+
+// GCSimulate previews the garbage collection over the next days, with states saved at the requested daily rate.
+// Nothing is saved or removed on the system.
+func (s *Server) GCSimulate(req *zsys.GCSimulateRequest, stream zsys.Zsys_GCSimulateServer) error {
 	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionAlwaysAllowed); err != nil {
 		return err
 	}
-	log.Info(stream.Context(), i18n.G("Requesting zsys daemon to garbage collect"))
+
+	log.Info(stream.Context(), i18n.G("Requesting zsys daemon garbage collection simulation"))
+
+	s.RWRequest.RLock()
+	defer s.RWRequest.RUnlock()
+
+	sim, err := s.Machines.SimulateGC(stream.Context(), int(req.GetDays()), req.GetRate())
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't simulate garbage collection: ")+config.ErrorFormat, err)
+	}
+
+	r := &zsys.GCSimulation{}
+	for _, d := range sim.Days {
+		users := make(map[string]int32)
+		for u, n := range d.Users {
+			users[u] = int32(n)
+		}
+		r.Days = append(r.Days, &zsys.GCSimulatedDay{
+			Date:    d.Date.Unix(),
+			Saved:   int32(d.Saved),
+			Removed: int32(d.Removed),
+			System:  int32(d.System),
+			Users:   users,
+		})
+	}
+	for _, rm := range sim.Removals {
+		r.Removals = append(r.Removals, &zsys.GCSimulatedRemoval{
+			Machine:   rm.Machine,
+			User:      rm.User,
+			State:     rm.State,
+			LastUsed:  rm.LastUsed.Unix(),
+			RemovedOn: rm.RemovedOn.Unix(),
+			Reason:    rm.Reason,
+		})
+	}
+	for _, b := range sim.Buckets {
+		r.Buckets = append(r.Buckets, &zsys.GCSimulatedBucket{
+			User:   b.User,
+			Bucket: b.Bucket,
+			States: int32(b.States),
+		})
+	}
+
+	if err := stream.Send(&zsys.GCSimulateResponse{Reply: &zsys.GCSimulateResponse_Simulation{Simulation: r}}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't send garbage collection simulation to client: %v"), err)
+	}
+	return nil
+}
+
+// RunSchedule saves the states of every due schedule rule and garbage collects afterwards
+func (s *Server) RunSchedule(req *zsys.Empty, stream zsys.Zsys_RunScheduleServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	log.Info(stream.Context(), i18n.G("Requesting zsys daemon to run scheduled state saves"))
 
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
-	return s.Machines.GC(stream.Context(), req.GetAll())
+	defer s.audited(stream.Context(), "RunSchedule", authorizer.ActionSystemWrite, nil)(&err)
+
+	systemSaved, err := s.Machines.RunSchedule(stream.Context())
+	if systemSaved {
+		if errBootMenu := s.updateBootMenu(stream.Context()); errBootMenu != nil && err == nil {
+			err = errBootMenu
+		}
+	}
+	return err
+}
+
+// Audit streams the entries of the audit log recorded since the requested time, optionally requested by or
+// acting on a user.
+func (s *Server) Audit(req *zsys.AuditRequest, stream zsys.Zsys_AuditServer) error {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionManageService); err != nil {
+		return err
+	}
+
+	log.Info(stream.Context(), i18n.G("Requesting audit log"))
+
+	f := audit.Filter{User: req.GetUser()}
+	if since := req.GetSince(); since > 0 {
+		f.Since = time.Unix(since, 0)
+	}
+	entries, err := s.audit.Entries(stream.Context(), f)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't read audit log: ")+config.ErrorFormat, err)
+	}
+
+	for _, e := range entries {
+		var datasets []*zsys.AuditDatasetChange
+		for _, d := range e.Datasets {
+			datasets = append(datasets, &zsys.AuditDatasetChange{Name: d.Name, Change: d.Change})
+		}
+		if err := stream.Send(&zsys.AuditResponse{
+			Reply: &zsys.AuditResponse_Entry{
+				Entry: &zsys.AuditEntry{
+					Time:      e.Time.Unix(),
+					RequestId: e.RequestID,
+					Uid:       e.UID,
+					Pid:       e.PID,
+					Action:    e.Action,
+					Operation: e.Operation,
+					Arguments: e.Arguments,
+					Datasets:  datasets,
+					Success:   e.Success,
+					Error:     e.Error,
+				},
+			},
+		}); err != nil {
+			return fmt.Errorf(i18n.G("couldn't send audit log to client: %v"), err)
+		}
+	}
+	return nil
 }
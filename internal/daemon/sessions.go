@@ -0,0 +1,38 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/ubuntu/zsys/internal/i18n"
+)
+
+// userSessions returns the logind session IDs opened by user.
+func userSessions(user string) ([]string, error) {
+	bus, err := dbus.SystemBus()
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't connect to system bus: %v"), err)
+	}
+
+	// ListSessions returns an array of (session id, uid, user name, seat id, session object path)
+	var sessions []struct {
+		ID   string
+		UID  uint32
+		User string
+		Seat string
+		Path dbus.ObjectPath
+	}
+	logind := bus.Object("org.freedesktop.login1", "/org/freedesktop/login1")
+	if err := logind.Call("org.freedesktop.login1.Manager.ListSessions", 0).Store(&sessions); err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't list user sessions: %v"), err)
+	}
+
+	var r []string
+	for _, s := range sessions {
+		if s.User != user {
+			continue
+		}
+		r = append(r, s.ID)
+	}
+	return r, nil
+}
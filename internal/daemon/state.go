@@ -34,6 +34,12 @@ func (s *Server) SaveSystemState(req *zsys.SaveSystemStateRequest, stream zsys.Z
 		return nil
 	}
 
+	defer s.audited(stream.Context(), "SaveSystemState", authorizer.ActionSystemWrite, map[string]string{
+		"state":       stateName,
+		"description": req.GetDescription(),
+		"autosave":    boolArg(req.GetAutosave()),
+	})(&err)
+
 	if stateName != "" {
 		log.Infof(stream.Context(), i18n.G("Requesting to save current system state %q"), stateName)
 	} else {
@@ -46,12 +52,12 @@ func (s *Server) SaveSystemState(req *zsys.SaveSystemStateRequest, stream zsys.Z
 		}
 	}
 
-	if stateName, err = s.Machines.CreateSystemSnapshot(stream.Context(), stateName); err != nil {
+	if stateName, err = s.Machines.CreateSystemSnapshot(stream.Context(), stateName, req.GetDescription(), req.GetLabels()); err != nil {
 		return fmt.Errorf(i18n.G("couldn't save system state: ")+config.ErrorFormat, err)
 	}
 
 	if req.GetUpdateBootMenu() {
-		if err := updateBootMenu(stream.Context()); err != nil {
+		if err := s.updateBootMenu(stream.Context()); err != nil {
 			return err
 		}
 	}
@@ -80,13 +86,19 @@ func (s *Server) SaveUserState(req *zsys.SaveUserStateRequest, stream zsys.Zsys_
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	defer s.audited(stream.Context(), "SaveUserState", authorizer.ActionUserWrite, map[string]string{
+		"user":        userName,
+		"state":       stateName,
+		"description": req.GetDescription(),
+	})(&err)
+
 	if stateName != "" {
 		log.Infof(stream.Context(), i18n.G("Requesting to save state %q for user %q"), stateName, userName)
 	} else {
 		log.Infof(stream.Context(), i18n.G("Requesting to save state for user %q"), userName)
 	}
 
-	if stateName, err = s.Machines.CreateUserSnapshot(stream.Context(), userName, stateName); err != nil {
+	if stateName, err = s.Machines.CreateUserSnapshot(stream.Context(), userName, stateName, req.GetDescription(), req.GetLabels()); err != nil {
 		return fmt.Errorf(i18n.G("couldn't save state for user %q: ")+config.ErrorFormat, userName, err)
 	}
 
@@ -108,6 +120,13 @@ func (s *Server) RemoveSystemState(req *zsys.RemoveSystemStateRequest, stream zs
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	if !req.GetDryrun() {
+		defer s.audited(stream.Context(), "RemoveSystemState", authorizer.ActionSystemWrite, map[string]string{
+			"state": stateName,
+			"force": boolArg(req.GetForce()),
+		})(&err)
+	}
+
 	if stateName == "" {
 		return fmt.Errorf(i18n.G("System state name is required"))
 	}
@@ -138,11 +157,11 @@ func (s *Server) RemoveSystemState(req *zsys.RemoveSystemStateRequest, stream zs
 	if req.GetDryrun() {
 		return nil
 	}
-	return updateBootMenu(stream.Context())
+	return s.updateBootMenu(stream.Context())
 }
 
 // RemoveUserState removes a user state
-func (s *Server) RemoveUserState(req *zsys.RemoveUserStateRequest, stream zsys.Zsys_RemoveUserStateServer) error {
+func (s *Server) RemoveUserState(req *zsys.RemoveUserStateRequest, stream zsys.Zsys_RemoveUserStateServer) (err error) {
 	userName := req.GetUserName()
 
 	if err := s.authorizer.IsAllowedFromContext(context.WithValue(stream.Context(), authorizer.OnUserKey, userName),
@@ -155,13 +174,21 @@ func (s *Server) RemoveUserState(req *zsys.RemoveUserStateRequest, stream zsys.Z
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	if !req.GetDryrun() {
+		defer s.audited(stream.Context(), "RemoveUserState", authorizer.ActionUserWrite, map[string]string{
+			"user":  userName,
+			"state": stateName,
+			"force": boolArg(req.GetForce()),
+		})(&err)
+	}
+
 	if stateName == "" {
 		return fmt.Errorf(i18n.G("State name is required"))
 	}
 
 	log.Infof(stream.Context(), i18n.G("Requesting to remove user state %q for user %s"), stateName, userName)
 
-	err := s.Machines.RemoveState(stream.Context(), stateName, userName, req.GetForce(), req.GetDryrun())
+	err = s.Machines.RemoveState(stream.Context(), stateName, userName, req.GetForce(), req.GetDryrun())
 	if err != nil {
 		var e *machines.ErrStateRemovalNeedsConfirmation
 		if errors.As(err, &e) {
@@ -184,3 +211,394 @@ func (s *Server) RemoveUserState(req *zsys.RemoveUserStateRequest, stream zsys.Z
 
 	return nil
 }
+
+// RevertSystemState schedules the machine to boot on a given system state on next boot.
+// User data attached to this state can optionally be reverted as well.
+func (s *Server) RevertSystemState(req *zsys.RevertSystemStateRequest, stream zsys.Zsys_RevertSystemStateServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	stateName := req.GetStateName()
+
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	defer s.audited(stream.Context(), "RevertSystemState", authorizer.ActionSystemWrite, map[string]string{
+		"state":    stateName,
+		"userdata": boolArg(req.GetRevertUserData()),
+	})(&err)
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("System state name is required"))
+	}
+
+	log.Infof(stream.Context(), i18n.G("Requesting to revert to system state %q on next boot"), stateName)
+
+	b, err := s.bootloaderBackend()
+	if err != nil {
+		return err
+	}
+	if err := b.GenerateMenu(stream.Context(), s.Machines.BootStates()); err != nil {
+		return err
+	}
+
+	// The revert is only recorded once the bootloader selected the state.
+	state, err := s.Machines.RevertSystemState(stream.Context(), stateName, req.GetRevertUserData(), b.SetNextBoot)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't revert to system state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	log.RemotePrintf(stream.Context(), i18n.G("System will boot on %s on next reboot\n"), state.ID)
+
+	return nil
+}
+
+// RevertUserState schedules the user data to be reverted to a given user state on next boot.
+func (s *Server) RevertUserState(req *zsys.RevertUserStateRequest, stream zsys.Zsys_RevertUserStateServer) (err error) {
+	userName := req.GetUserName()
+
+	if err := s.authorizer.IsAllowedFromContext(context.WithValue(stream.Context(), authorizer.OnUserKey, userName),
+		authorizer.ActionUserWrite); err != nil {
+		return err
+	}
+
+	stateName := req.GetStateName()
+
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	defer s.audited(stream.Context(), "RevertUserState", authorizer.ActionUserWrite, map[string]string{
+		"user":  userName,
+		"state": stateName,
+	})(&err)
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("State name is required"))
+	}
+
+	log.Infof(stream.Context(), i18n.G("Requesting to revert user %s to state %q on next boot"), userName, stateName)
+
+	state, err := s.Machines.RevertUserState(stream.Context(), stateName, userName)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't revert user state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	log.RemotePrintf(stream.Context(), i18n.G("User %s will be reverted to %s on next reboot\n"), userName, state.ID)
+
+	return nil
+}
+
+// RestoreUserState replaces immediately the user data by a given user state, without rebooting.
+// It refuses to proceed while the user has opened sessions, unless forced.
+func (s *Server) RestoreUserState(req *zsys.RestoreUserStateRequest, stream zsys.Zsys_RestoreUserStateServer) (err error) {
+	userName := req.GetUserName()
+
+	if err := s.authorizer.IsAllowedFromContext(context.WithValue(stream.Context(), authorizer.OnUserKey, userName),
+		authorizer.ActionUserWrite); err != nil {
+		return err
+	}
+
+	stateName := req.GetStateName()
+
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	defer s.audited(stream.Context(), "RestoreUserState", authorizer.ActionUserWrite, map[string]string{
+		"user":  userName,
+		"state": stateName,
+		"force": boolArg(req.GetForce()),
+	})(&err)
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("State name is required"))
+	}
+
+	if !req.GetForce() {
+		sessions, err := userSessions(userName)
+		if err != nil {
+			return fmt.Errorf(i18n.G("couldn't check if user %s is logged in: ")+config.ErrorFormat, userName, err)
+		}
+		if len(sessions) > 0 {
+			return fmt.Errorf(i18n.G("user %s has %d active session(s). Log them out first or use --force"), userName, len(sessions))
+		}
+	}
+
+	log.Infof(stream.Context(), i18n.G("Requesting to restore user %s to state %q"), userName, stateName)
+
+	state, err := s.Machines.RestoreUserState(stream.Context(), stateName, userName)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't restore user state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	log.RemotePrintf(stream.Context(), i18n.G("User %s restored on %s\n"), userName, state.ID)
+
+	return nil
+}
+
+// PinState protects a system or user state from garbage collection and removal.
+func (s *Server) PinState(req *zsys.PinStateRequest, stream zsys.Zsys_PinStateServer) error {
+	return s.setStatePinned(stream.Context(), req.GetStateName(), req.GetUserName(), true)
+}
+
+// UnpinState removes the protection of a system or user state against garbage collection and removal.
+func (s *Server) UnpinState(req *zsys.UnpinStateRequest, stream zsys.Zsys_UnpinStateServer) error {
+	return s.setStatePinned(stream.Context(), req.GetStateName(), req.GetUserName(), false)
+}
+
+func (s *Server) setStatePinned(ctx context.Context, stateName, userName string, pinned bool) (err error) {
+	action := authorizer.ActionSystemWrite
+	if userName != "" {
+		ctx = context.WithValue(ctx, authorizer.OnUserKey, userName)
+		action = authorizer.ActionUserWrite
+	}
+	if err := s.authorizer.IsAllowedFromContext(ctx, action); err != nil {
+		return err
+	}
+
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	op := "PinState"
+	if !pinned {
+		op = "UnpinState"
+	}
+	defer s.audited(ctx, op, action, map[string]string{
+		"user":  userName,
+		"state": stateName,
+	})(&err)
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("State name is required"))
+	}
+
+	if !pinned {
+		log.Infof(ctx, i18n.G("Requesting to unpin state %q"), stateName)
+		state, err := s.Machines.UnpinState(ctx, stateName, userName)
+		if err != nil {
+			return fmt.Errorf(i18n.G("couldn't unpin state %s: ")+config.ErrorFormat, stateName, err)
+		}
+		log.RemotePrintf(ctx, i18n.G("State %s unpinned\n"), state.ID)
+		return nil
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to pin state %q"), stateName)
+	state, err := s.Machines.PinState(ctx, stateName, userName)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't pin state %s: ")+config.ErrorFormat, stateName, err)
+	}
+	log.RemotePrintf(ctx, i18n.G("State %s pinned\n"), state.ID)
+	return nil
+}
+
+// RenameState renames a saved system or user state, along with all its linked states.
+func (s *Server) RenameState(req *zsys.RenameStateRequest, stream zsys.Zsys_RenameStateServer) (err error) {
+	stateName := req.GetStateName()
+	userName := req.GetUserName()
+	newName := req.GetNewName()
+	ctx := stream.Context()
+
+	action := authorizer.ActionSystemWrite
+	if userName != "" {
+		ctx = context.WithValue(ctx, authorizer.OnUserKey, userName)
+		action = authorizer.ActionUserWrite
+	}
+	if err := s.authorizer.IsAllowedFromContext(ctx, action); err != nil {
+		return err
+	}
+
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	defer s.audited(ctx, "RenameState", action, map[string]string{
+		"user":    userName,
+		"state":   stateName,
+		"newname": newName,
+	})(&err)
+
+	if stateName == "" || newName == "" {
+		return fmt.Errorf(i18n.G("State name and new name are required"))
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to rename state %q to %q"), stateName, newName)
+	newID, err := s.Machines.RenameState(ctx, stateName, userName, newName)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't rename state %s: ")+config.ErrorFormat, stateName, err)
+	}
+	log.RemotePrintf(ctx, i18n.G("State %s renamed to %s\n"), stateName, newID)
+
+	if userName != "" {
+		return nil
+	}
+	return s.updateBootMenu(ctx)
+}
+
+// ListStates streams the system states of the current machine, or the states of the given user,
+// matching the description and labels filters.
+func (s *Server) ListStates(req *zsys.ListStatesRequest, stream zsys.Zsys_ListStatesServer) error {
+	userName := req.GetUserName()
+	ctx := stream.Context()
+
+	action := authorizer.ActionSystemList
+	if userName != "" {
+		ctx = context.WithValue(ctx, authorizer.OnUserKey, userName)
+		action = authorizer.ActionUserWrite
+	}
+	if err := s.authorizer.IsAllowedFromContext(ctx, action); err != nil {
+		return err
+	}
+
+	s.RWRequest.RLock()
+	defer s.RWRequest.RUnlock()
+
+	log.Info(stream.Context(), i18n.G("Requesting states list"))
+
+	states, err := s.Machines.ListStates(stream.Context(), userName, machines.StateFilter{
+		Description: req.GetDescription(),
+		Labels:      req.GetLabels(),
+	})
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't list states: ")+config.ErrorFormat, err)
+	}
+
+	r := &zsys.States{}
+	for _, ls := range states {
+		r.States = append(r.States, stateToProto(ls.ID, ls.State, false))
+	}
+	if err := stream.Send(&zsys.ListStatesResponse{
+		Reply: &zsys.ListStatesResponse_States{States: r},
+	}); err != nil {
+		return fmt.Errorf(i18n.G("couldn't send states to client: %v"), err)
+	}
+
+	return nil
+}
+
+// ExportState sends a system or user state, with the user states linked to a system state, to a dataset or a file.
+// Targets are written as root: exporting always requires system write permission, even for a user state.
+// Machines are only locked while preparing the export and once done: held snapshots are streamed meanwhile.
+func (s *Server) ExportState(req *zsys.ExportStateRequest, stream zsys.Zsys_ExportStateServer) (err error) {
+	stateName, userName, target := req.GetStateName(), req.GetUserName(), req.GetTarget()
+	ctx := stream.Context()
+
+	if userName != "" {
+		ctx = context.WithValue(ctx, authorizer.OnUserKey, userName)
+	}
+	if err := s.authorizer.IsAllowedFromContext(ctx, authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	defer s.audited(ctx, "ExportState", authorizer.ActionSystemWrite, map[string]string{
+		"user":   userName,
+		"state":  stateName,
+		"target": target,
+	})(&err)
+
+	if stateName == "" {
+		return fmt.Errorf(i18n.G("State name is required"))
+	}
+	if target == "" {
+		return fmt.Errorf(i18n.G("Export target is required"))
+	}
+
+	log.Infof(ctx, i18n.G("Requesting to export state %q to %q"), stateName, target)
+
+	s.RWRequest.Lock()
+	e, err := s.Machines.PrepareExport(ctx, stateName, userName, target)
+	s.RWRequest.Unlock()
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't export state %s: ")+config.ErrorFormat, stateName, err)
+	}
+
+	errStream := e.Stream(ctx)
+
+	s.RWRequest.Lock()
+	errDone := e.Done(ctx)
+	s.RWRequest.Unlock()
+
+	if errStream == nil {
+		errStream = errDone
+	}
+	if errStream != nil {
+		return fmt.Errorf(i18n.G("couldn't export state %s: ")+config.ErrorFormat, stateName, errStream)
+	}
+	log.RemotePrintf(ctx, i18n.G("State %s exported to %s\n"), stateName, target)
+	return nil
+}
+
+// ImportState receives back a state exported to a dataset or a file.
+func (s *Server) ImportState(req *zsys.ImportStateRequest, stream zsys.Zsys_ImportStateServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	source, stateName := req.GetSource(), req.GetStateName()
+
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	defer s.audited(stream.Context(), "ImportState", authorizer.ActionSystemWrite, map[string]string{
+		"source": source,
+		"state":  stateName,
+	})(&err)
+
+	if source == "" {
+		return fmt.Errorf(i18n.G("Import source is required"))
+	}
+
+	log.Infof(stream.Context(), i18n.G("Requesting to import state from %q"), source)
+
+	if err := s.Machines.ImportState(stream.Context(), source, stateName); err != nil {
+		return fmt.Errorf(i18n.G("couldn't import state from %s: ")+config.ErrorFormat, source, err)
+	}
+	log.RemotePrintf(stream.Context(), i18n.G("State imported from %s\n"), source)
+	return nil
+}
+
+// StateDiff streams path changes between datasets of two states.
+func (s *Server) StateDiff(req *zsys.StateDiffRequest, stream zsys.Zsys_StateDiffServer) error {
+	userName := req.GetUserName()
+	ctx := stream.Context()
+
+	// Changes reveal file names of the whole system: only administrators can list them.
+	action := authorizer.ActionSystemWrite
+	if userName != "" {
+		ctx = context.WithValue(ctx, authorizer.OnUserKey, userName)
+		action = authorizer.ActionUserWrite
+	}
+	if err := s.authorizer.IsAllowedFromContext(ctx, action); err != nil {
+		return err
+	}
+
+	from := req.GetFrom()
+
+	s.RWRequest.RLock()
+	defer s.RWRequest.RUnlock()
+
+	if from == "" {
+		return fmt.Errorf(i18n.G("State name is required"))
+	}
+
+	log.Infof(stream.Context(), i18n.G("Requesting changes between %q and %q"), from, req.GetTo())
+
+	err := s.Machines.StateDiff(stream.Context(), from, req.GetTo(), userName, req.GetWithUsers(), req.GetPath(),
+		func(d machines.DatasetDiff) error {
+			var changes []*zsys.PathChange
+			for _, c := range d.Changes {
+				changes = append(changes, &zsys.PathChange{Change: c.Change, Path: c.Path, NewPath: c.NewPath})
+			}
+			if err := stream.Send(&zsys.StateDiffResponse{
+				Reply: &zsys.StateDiffResponse_Diff{
+					Diff: &zsys.DatasetDiff{From: d.From, To: d.To, Changes: changes},
+				},
+			}); err != nil {
+				return fmt.Errorf(i18n.G("couldn't send changes to client: %v"), err)
+			}
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't compare states: ")+config.ErrorFormat, err)
+	}
+
+	return nil
+}
@@ -1,13 +1,16 @@
 package daemon
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/ubuntu/zsys"
 	"github.com/ubuntu/zsys/internal/authorizer"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/machines"
 )
 
 // CreateUserData creates a new userdata for user and set it to homepath on current zsys system.
@@ -23,11 +26,26 @@ func (s *Server) CreateUserData(req *zsys.CreateUserDataRequest, stream zsys.Zsy
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	defer s.audited(stream.Context(), "CreateUserData", authorizer.ActionSystemWrite, map[string]string{
+		"user":       user,
+		"homepath":   homepath,
+		"encryption": req.GetEncryption().String(),
+	})(&err)
+
 	log.Infof(stream.Context(), i18n.G("Create user dataset for %q on %q"), user, homepath)
 
-	if err := s.Machines.CreateUserData(stream.Context(), user, homepath); err != nil {
+	opts := []machines.UserDataOption{machines.WithPassphrase(req.GetPassphrase())}
+	switch req.GetEncryption() {
+	case zsys.CreateUserDataRequest_ENCRYPTED:
+		opts = append(opts, machines.WithEncryption(true))
+	case zsys.CreateUserDataRequest_NOT_ENCRYPTED:
+		opts = append(opts, machines.WithEncryption(false))
+	}
+
+	if err := s.Machines.CreateUserData(stream.Context(), user, homepath, opts...); err != nil {
 		return fmt.Errorf(i18n.G("couldn't create userdataset for %q: ")+config.ErrorFormat, homepath, err)
 	}
+	s.publishCurrentMachineEvent(zsys.Event_USERDATA_CREATED, user)
 	return nil
 }
 
@@ -42,6 +60,11 @@ func (s *Server) ChangeHomeOnUserData(req *zsys.ChangeHomeOnUserDataRequest, str
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	defer s.audited(stream.Context(), "ChangeHomeOnUserData", authorizer.ActionSystemWrite, map[string]string{
+		"home":    home,
+		"newhome": newHome,
+	})(&err)
+
 	log.Infof(stream.Context(), i18n.G("Rename home user dataset from %q to %q"), home, newHome)
 
 	if err := s.Machines.ChangeHomeOnUserData(stream.Context(), home, newHome); err != nil {
@@ -62,10 +85,64 @@ func (s *Server) DissociateUser(req *zsys.DissociateUserRequest, stream zsys.Zsy
 	s.RWRequest.Lock()
 	defer s.RWRequest.Unlock()
 
+	defer s.audited(stream.Context(), "DissociateUser", authorizer.ActionSystemWrite, map[string]string{
+		"user":       user,
+		"removehome": boolArg(removeHome),
+	})(&err)
+
 	log.Infof(stream.Context(), i18n.G("Dissociate user %q"), user)
 
 	if err := s.Machines.DissociateUser(stream.Context(), user, removeHome); err != nil {
 		return fmt.Errorf(i18n.G("couldn't dissociate user %q: ")+config.ErrorFormat, user, err)
 	}
+	s.publishCurrentMachineEvent(zsys.Event_USER_DISSOCIATED, user)
+	return nil
+}
+
+// UnlockUserData loads the keys of user encrypted datasets from passphrase and mounts them.
+// Any revert of the user delayed on boot while locked is done then.
+func (s *Server) UnlockUserData(req *zsys.UnlockUserDataRequest, stream zsys.Zsys_UnlockUserDataServer) (err error) {
+	user := req.GetUser()
+	if err := s.authorizer.IsAllowedFromContext(context.WithValue(stream.Context(), authorizer.OnUserKey, user),
+		authorizer.ActionUserWrite); err != nil {
+		return err
+	}
+
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	defer s.audited(stream.Context(), "UnlockUserData", authorizer.ActionUserWrite, map[string]string{
+		"user": user,
+	})(&err)
+
+	log.Infof(stream.Context(), i18n.G("Unlock user datasets for %q"), user)
+
+	if err := s.Machines.UnlockUserData(stream.Context(), user, req.GetPassphrase()); err != nil {
+		return fmt.Errorf(i18n.G("couldn't unlock user datasets for %q: ")+config.ErrorFormat, user, err)
+	}
+	return nil
+}
+
+// SetUserQuota sets the maximum space the current user dataset of user can reference and the space reserved for it.
+func (s *Server) SetUserQuota(req *zsys.SetUserQuotaRequest, stream zsys.Zsys_SetUserQuotaServer) (err error) {
+	if err := s.authorizer.IsAllowedFromContext(stream.Context(), authorizer.ActionSystemWrite); err != nil {
+		return err
+	}
+
+	user := req.GetUser()
+	s.RWRequest.Lock()
+	defer s.RWRequest.Unlock()
+
+	defer s.audited(stream.Context(), "SetUserQuota", authorizer.ActionSystemWrite, map[string]string{
+		"user":        user,
+		"quota":       strconv.FormatUint(req.GetQuota(), 10),
+		"reservation": strconv.FormatUint(req.GetReservation(), 10),
+	})(&err)
+
+	log.Infof(stream.Context(), i18n.G("Set quota of user datasets for %q"), user)
+
+	if err := s.Machines.SetUserQuota(stream.Context(), user, req.GetQuota(), req.GetReservation()); err != nil {
+		return fmt.Errorf(i18n.G("couldn't set quota of user datasets for %q: ")+config.ErrorFormat, user, err)
+	}
 	return nil
 }
@@ -0,0 +1,167 @@
+// Package hooks runs administrator provided executables around state operations.
+package hooks
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+// Event is an operation hooks can be attached to.
+type Event string
+
+const (
+	// PreSave runs before saving a state. A failure aborts the save.
+	PreSave Event = "pre-save"
+	// PostSave runs once a state is saved.
+	PostSave Event = "post-save"
+	// PreRemove runs before removing a state. A failure aborts the removal.
+	PreRemove Event = "pre-remove"
+	// PostRemove runs once a state is removed.
+	PostRemove Event = "post-remove"
+	// PreRevert runs before reverting or restoring a state. A failure aborts the revert.
+	PreRevert Event = "pre-revert"
+	// PostRevert runs once a revert is scheduled or a restore is done.
+	PostRevert Event = "post-revert"
+	// PostCommit runs once the current boot is committed.
+	PostCommit Event = "post-commit"
+	// PostGC runs after a garbage collection.
+	PostGC Event = "post-gc"
+)
+
+const (
+	// DefaultDir is the directory containing one <event>.d directory per event.
+	DefaultDir = "/etc/zsys/hooks.d"
+	// DefaultTimeout is the time given to each hook to complete.
+	DefaultTimeout = 30 * time.Second
+)
+
+// Runner executes the hooks of an event.
+type Runner struct {
+	dir     string
+	timeout time.Duration
+}
+
+// Env is the context of the operation passed to hooks.
+type Env struct {
+	State    string
+	User     string
+	Datasets []string
+}
+
+// New returns a hooks runner configured from conf.
+func New(conf config.ZConfig) Runner {
+	r := Runner{dir: conf.Hooks.Dir, timeout: time.Duration(conf.Hooks.Timeout) * time.Second}
+	if r.dir == "" {
+		r.dir = DefaultDir
+	}
+	if r.timeout <= 0 {
+		r.timeout = DefaultTimeout
+	}
+	return r
+}
+
+// Run executes, in lexical order, every executable file of the event directory.
+// Hooks get the event, state ID, user and datasets in ZSYS_EVENT, ZSYS_STATE_ID, ZSYS_USER and ZSYS_DATASETS
+// (space separated) environment variables. Their output is streamed to the client.
+// It stops and returns an error on the first hook failing or exceeding the timeout.
+func (r Runner) Run(ctx context.Context, e Event, env Env) error {
+	dir := filepath.Join(r.dir, string(e)+".d")
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf(i18n.G("couldn't list %s hooks: ")+config.ErrorFormat, e, err)
+	}
+
+	for _, f := range files {
+		if f.IsDir() || f.Mode()&0111 == 0 {
+			log.Debugf(ctx, i18n.G("Skipping non executable hook %s"), f.Name())
+			continue
+		}
+		if err := r.runHook(ctx, filepath.Join(dir, f.Name()), e, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runHook executes one hook with the operation context in its environment.
+func (r Runner) runHook(ctx context.Context, path string, e Event, env Env) error {
+	log.Infof(ctx, i18n.G("Running %s hook %s"), e, path)
+
+	hctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cmd := exec.Command(path)
+	// Run the hook in its own process group, so that any process it spawns is killed with it on timeout.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Env = append(os.Environ(),
+		"ZSYS_EVENT="+string(e),
+		"ZSYS_STATE_ID="+env.State,
+		"ZSYS_USER="+env.User,
+		"ZSYS_DATASETS="+strings.Join(env.Datasets, " "))
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s := bufio.NewScanner(pr)
+		for s.Scan() {
+			log.RemotePrintln(ctx, s.Text())
+		}
+		// Drain anything left if a line was too long.
+		io.Copy(ioutil.Discard, pr)
+	}()
+
+	if err := cmd.Start(); err != nil {
+		pw.Close()
+		wg.Wait()
+		return fmt.Errorf(i18n.G("couldn't start %s hook %s: ")+config.ErrorFormat, e, path, err)
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-hctx.Done():
+			syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		case <-done:
+		}
+	}()
+	err := cmd.Wait()
+	close(done)
+	pw.Close()
+	wg.Wait()
+
+	if hctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf(i18n.G("%s hook %s didn't complete within %s"), e, path, r.timeout)
+	}
+	if err != nil {
+		return fmt.Errorf(i18n.G("%s hook %s failed: ")+config.ErrorFormat, e, path, err)
+	}
+	return nil
+}
+
+// RunPost executes the hooks of a post event. As the operation is already done, failures are only logged.
+func (r Runner) RunPost(ctx context.Context, e Event, env Env) {
+	if err := r.Run(ctx, e, env); err != nil {
+		log.Warning(ctx, err)
+	}
+}
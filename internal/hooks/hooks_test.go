@@ -0,0 +1,94 @@
+package hooks_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/hooks"
+	"github.com/ubuntu/zsys/internal/testutils"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	// record appends the hook name and its environment to the output file.
+	const record = `echo "$(basename $0) $ZSYS_EVENT [$ZSYS_STATE_ID] [$ZSYS_USER] [$ZSYS_DATASETS]" >> {{out}}`
+
+	tests := map[string]struct {
+		hooks         map[string]string
+		nonExecutable []string
+		noDir         bool
+		env           hooks.Env
+
+		wantCalls string
+		wantErr   bool
+	}{
+		"No hook directory":    {noDir: true},
+		"Empty hook directory": {},
+		"Run hook with environment": {hooks: map[string]string{"01-first": record},
+			env:       hooks.Env{State: "rpool/ROOT/ubuntu_1234@snap1", User: "user1", Datasets: []string{"rpool/ROOT/ubuntu_1234", "rpool/ROOT/ubuntu_1234/var"}},
+			wantCalls: "01-first pre-save [rpool/ROOT/ubuntu_1234@snap1] [user1] [rpool/ROOT/ubuntu_1234 rpool/ROOT/ubuntu_1234/var]\n"},
+		"Run hooks in lexical order": {hooks: map[string]string{"02-second": record, "01-first": record, "10-last": record},
+			wantCalls: "01-first pre-save [] [] []\n02-second pre-save [] [] []\n10-last pre-save [] [] []\n"},
+		"Skip non executable files": {hooks: map[string]string{"01-first": record, "02-disabled": record}, nonExecutable: []string{"02-disabled"},
+			wantCalls: "01-first pre-save [] [] []\n"},
+		"Hook output is not an error": {hooks: map[string]string{"01-first": "echo some output; echo some error >&2"}},
+
+		"Error on failing hook stops next ones": {hooks: map[string]string{"01-first": record, "02-failing": "exit 1", "03-never": record},
+			wantCalls: "01-first pre-save [] [] []\n", wantErr: true},
+		"Error on hook timeout": {hooks: map[string]string{"01-sleeping": "sleep 10"}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			out := filepath.Join(dir, "calls")
+			hooksDir := filepath.Join(dir, "hooks.d")
+			if !tc.noDir {
+				if err := os.MkdirAll(filepath.Join(hooksDir, string(hooks.PreSave)+".d"), 0755); err != nil {
+					t.Fatalf("couldn't create hooks directory: %v", err)
+				}
+			}
+			for n, content := range tc.hooks {
+				mode := os.FileMode(0755)
+				for _, ne := range tc.nonExecutable {
+					if n == ne {
+						mode = 0644
+					}
+				}
+				script := "#!/bin/sh\n" + strings.ReplaceAll(content, "{{out}}", out) + "\n"
+				if err := ioutil.WriteFile(filepath.Join(hooksDir, string(hooks.PreSave)+".d", n), []byte(script), mode); err != nil {
+					t.Fatalf("couldn't write hook %s: %v", n, err)
+				}
+			}
+
+			var c config.ZConfig
+			c.Hooks.Dir = hooksDir
+			c.Hooks.Timeout = 1
+			r := hooks.New(c)
+
+			err := r.Run(context.Background(), hooks.PreSave, tc.env)
+			if tc.wantErr {
+				assert.Error(t, err, "Run should have failed")
+			} else {
+				assert.NoError(t, err, "Run shouldn't have failed")
+			}
+
+			calls, err := ioutil.ReadFile(out)
+			if err != nil && !os.IsNotExist(err) {
+				t.Fatalf("couldn't read hooks calls: %v", err)
+			}
+			assert.Equal(t, tc.wantCalls, string(calls), "Hooks calls should match")
+		})
+	}
+}
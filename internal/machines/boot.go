@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/hooks"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
 	"github.com/ubuntu/zsys/internal/zfs"
@@ -47,7 +48,15 @@ func (ms *Machines) EnsureBoot(ctx context.Context) (bool, error) {
 		// was called twice before Commit() during this boot. A new boot will create a new suffix id, so we won't block
 		// the machine forever in case of a real issue.
 		needCreateUserDatas := revertUserData && !(bootedOnSnapshot && len(bootedState.getUsersDatasets()) > 0)
-		if err := m.History[root].createClones(t, bootedState.ID, needCreateUserDatas); err != nil {
+		snapshot := m.History[root]
+		release, err := ms.holdSnapshots(ctx, append(snapshot.getDatasets(), snapshot.getUsersDatasets()...))
+		if err != nil {
+			cancel()
+			return false, fmt.Errorf(i18n.G("couldn't hold state %s: ")+config.ErrorFormat, root, err)
+		}
+		err = snapshot.createClones(t, bootedState.ID, needCreateUserDatas, m.Users)
+		release()
+		if err != nil {
 			cancel()
 			return false, err
 		}
@@ -62,8 +71,26 @@ func (ms *Machines) EnsureBoot(ctx context.Context) (bool, error) {
 	// It's a no-op if the active state was the main one already.
 	// In case of system revert (either from cloning or rebooting this older dataset without user data revert), the newly
 	// active state won't be the main one, and so, we only take its main state userdata.
+	usersOwnerID := bootedState.ID
 	if !revertUserData {
 		bootedState.Users = m.Users
+		usersOwnerID = m.ID
+	}
+
+	// Switch user datasets to any user state scheduled for revert on this boot.
+	revertedUsers, err := bootedState.revertPendingUserStates(t, m, usersOwnerID)
+	if err != nil {
+		cancel()
+		return false, err
+	}
+	if revertedUsers {
+		if err := ms.Refresh(ctx); err != nil {
+			return false, err
+		}
+		m, bootedState = ms.findFromRoot(root)
+		if !revertUserData {
+			bootedState.Users = m.Users
+		}
 	}
 
 	// Start switching every non desired system and user datasets to noauto
@@ -80,6 +107,7 @@ func (ms *Machines) EnsureBoot(ctx context.Context) (bool, error) {
 		cancel()
 		return false, err
 	}
+	hasChanges = hasChanges || revertedUsers
 
 	// Switch current state system and user datasets to on
 	autoDatasets := append(systemDatasets, userDatasets...)
@@ -164,6 +192,23 @@ func (ms *Machines) Commit(ctx context.Context) (bool, error) {
 		}
 	}
 
+	// Any pending system revert is consumed by this boot, whether the bootloader honored it or not.
+	states := []*State{&m.State}
+	for _, k := range sortedStateKeys(m.History) {
+		states = append(states, m.History[k])
+	}
+	for _, s := range states {
+		if s.isSnapshot() || s.Datasets[s.ID][0].PendingRevert == "" {
+			continue
+		}
+		log.Infof(ctx, i18n.G("Reset pending revert on %q"), s.ID)
+		changed = true
+		if err := t.SetProperty(libzfs.PendingRevertProp, "", s.ID, false); err != nil {
+			cancel()
+			return false, fmt.Errorf(i18n.G("couldn't reset pending revert on %q: ")+config.ErrorFormat, s.ID, err)
+		}
+	}
+
 	// Promotion needed for system and user datasets
 	log.Info(ctx, i18n.G("Promoting user datasets"))
 	chg, err := promoteDatasets(t, userDatasets)
@@ -184,6 +229,7 @@ func (ms *Machines) Commit(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	ms.hooksRunner().RunPost(ctx, hooks.PostCommit, hooksEnv(bootedState.ID, "", append(systemDatasets, userDatasets...)))
 	return changed, nil
 }
 
@@ -240,7 +286,12 @@ func diffDatasets(a, b []*zfs.Dataset) []*zfs.Dataset {
 	return diff
 }
 
-func (snapshot State) createClones(t *zfs.Transaction, bootedStateID string, needCreateUserDatas bool) error {
+// createClones clones the snapshot system datasets with the suffix of bootedStateID and, if needCreateUserDatas is set,
+// its user datasets.
+// Quotas of the current user datasets, from currentUsers, are kept on the new ones.
+// Encrypted user datasets with no loaded key can't be cloned: the current user datasets are then attached to the new
+// state and scheduled to be reverted once unlocked.
+func (snapshot State) createClones(t *zfs.Transaction, bootedStateID string, needCreateUserDatas bool, currentUsers map[string]*State) error {
 	// get current generated suffix by initramfs
 	j := strings.LastIndex(bootedStateID, "_")
 	if j < 0 || strings.HasSuffix(bootedStateID, "_") {
@@ -268,7 +319,14 @@ func (snapshot State) createClones(t *zfs.Transaction, bootedStateID string, nee
 	// Find user datasets attached to the snapshot and clone them
 	// Only root datasets are cloned
 	userDataSuffix := t.Zfs.GenerateID(6)
-	for _, us := range snapshot.Users {
+	for _, user := range sortedStateKeys(snapshot.Users) {
+		us := snapshot.Users[user]
+		if us.isLocked() {
+			if err := delayUserRevert(t, bootedStateID, us, currentUsers[user]); err != nil {
+				return err
+			}
+			continue
+		}
 		// Recursively clones childrens, which shouldn't have bootfs elements.
 		if err := t.Clone(us.ID, userDataSuffix, false, true); err != nil {
 			return fmt.Errorf(i18n.G("couldn't create new user datasets from %q: %v"), snapshot.ID, err)
@@ -281,11 +339,41 @@ func (snapshot State) createClones(t *zfs.Transaction, bootedStateID string, nee
 		if err := t.SetProperty(libzfs.BootfsDatasetsProp, bootedStateID, userdatasetName, false); err != nil {
 			return fmt.Errorf(i18n.G("couldn't add %q to BootfsDatasets property of %q: ")+config.ErrorFormat, bootedStateID, us.ID, err)
 		}
+		if current, ok := currentUsers[user]; ok {
+			if err := inheritQuota(t, current.Datasets[current.ID][0], userdatasetName); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// delayUserRevert attaches current user datasets to bootedStateID and schedules their revert to the locked user
+// snapshot us, which will be done once unlocked.
+func delayUserRevert(t *zfs.Transaction, bootedStateID string, us, current *State) error {
+	if current == nil {
+		log.Warningf(t.Context(), i18n.G("Couldn't revert locked user dataset %q: no current user dataset to keep, ignoring"), us.ID)
+		return nil
+	}
+	log.Infof(t.Context(), i18n.G("User dataset %q is locked: keeping %q until it is unlocked"), us.ID, current.ID)
+
+	d := current.Datasets[current.ID][0]
+	if !nameInBootfsDatasets(bootedStateID, *d) {
+		newTag := bootedStateID
+		if d.BootfsDatasets != "" {
+			newTag = d.BootfsDatasets + bootfsdatasetsSeparator + bootedStateID
+		}
+		if err := t.SetProperty(libzfs.BootfsDatasetsProp, newTag, d.Name, false); err != nil {
+			return fmt.Errorf(i18n.G("couldn't add %q to BootfsDatasets property of %q: ")+config.ErrorFormat, bootedStateID, d.Name, err)
+		}
+	}
+	if err := t.SetProperty(libzfs.PendingRevertProp, us.ID, d.Name, false); err != nil {
+		return fmt.Errorf(i18n.G("couldn't set pending revert to %q on %q: ")+config.ErrorFormat, us.ID, d.Name, err)
+	}
+	return nil
+}
+
 func switchDatasetsCanMount(t *zfs.Transaction, ds []*zfs.Dataset, canMount string) (hasChanges bool, err error) {
 	// Only handle on and noauto datasets, not off
 	initialCanMount := "on"
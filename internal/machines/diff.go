@@ -0,0 +1,146 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// diffBatchSize is the maximum number of changes sent at once, so that large diffs aren't held in memory.
+const diffBatchSize = 1000
+
+// DatasetDiff lists path changes between a dataset of a state and its matching dataset in another state.
+type DatasetDiff struct {
+	From    string
+	To      string
+	Changes []libzfs.DiffEntry
+}
+
+// StateDiff calls send with path changes between each dataset of from state and its matching dataset in to state.
+// Changes are sent as they are listed, by batches: a dataset with many changes is sent in multiple consecutive
+// batches with the same From and To. Each compared dataset is sent at least once, even without any change.
+// to defaults to the current state if empty. If user is set, from and to are resolved as this user states.
+// withUsers compares as well the user datasets attached to both system states.
+// Only changes under pathFilter are sent if not empty.
+func (ms *Machines) StateDiff(ctx context.Context, from, to, user string, withUsers bool, pathFilter string, send func(DatasetDiff) error) error {
+	fromState, err := ms.IDToState(ctx, from, user)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+	if !fromState.isSnapshot() {
+		return fmt.Errorf(i18n.G("%s isn't a snapshot: states can only be compared from a snapshot"), fromState.ID)
+	}
+
+	var toState *State
+	if to != "" {
+		if toState, err = ms.IDToState(ctx, to, user); err != nil {
+			return fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+		}
+	} else {
+		if !ms.current.isZsys() {
+			return errors.New(i18n.G("Current machine isn't Zsys, a state to compare to is required"))
+		}
+		toState = &ms.current.State
+		if user != "" {
+			var ok bool
+			if toState, ok = ms.current.State.Users[user]; !ok {
+				return fmt.Errorf(i18n.G("user %q doesn't exist on current state"), user)
+			}
+		}
+	}
+	if fromState == toState {
+		return fmt.Errorf(i18n.G("can't compare %s to itself"), fromState.ID)
+	}
+
+	pairs := pairStatesDatasets(ctx, fromState, toState)
+	if user == "" && withUsers {
+		for _, u := range sortedStateKeys(fromState.Users) {
+			toUserState, ok := toState.Users[u]
+			if !ok {
+				log.Infof(ctx, i18n.G("User %q isn't attached to %s, skipping"), u, toState.ID)
+				continue
+			}
+			pairs = append(pairs, pairStatesDatasets(ctx, fromState.Users[u], toUserState)...)
+		}
+	}
+
+	for _, p := range pairs {
+		log.Debugf(ctx, i18n.G("Comparing %q to %q"), p[0], p[1])
+		d := DatasetDiff{From: p[0], To: p[1]}
+		sent := false
+		err := ms.z.Diff(p[0], p[1], func(c libzfs.DiffEntry) error {
+			if !pathMatches(c.Path, pathFilter) && (c.NewPath == "" || !pathMatches(c.NewPath, pathFilter)) {
+				return nil
+			}
+			d.Changes = append(d.Changes, c)
+			if len(d.Changes) < diffBatchSize {
+				return nil
+			}
+			sent = true
+			if err := send(d); err != nil {
+				return err
+			}
+			d.Changes = nil
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(d.Changes) > 0 || !sent {
+			if err := send(d); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// pairStatesDatasets returns dataset names of from associated to their matching name in to, sorted by from names.
+// Datasets are matched on their container (like <pool>/ROOT or <pool>/BOOT) and their path relative to their route.
+func pairStatesDatasets(ctx context.Context, from, to *State) (pairs [][2]string) {
+	toDatasets := make(map[string]string)
+	for route, ds := range to.Datasets {
+		for _, d := range ds {
+			toDatasets[datasetKeyInRoute(route, d.Name)] = d.Name
+		}
+	}
+
+	for route, ds := range from.Datasets {
+		for _, d := range ds {
+			n, ok := toDatasets[datasetKeyInRoute(route, d.Name)]
+			if !ok {
+				log.Infof(ctx, i18n.G("No dataset matching %q in %s, skipping"), d.Name, to.ID)
+				continue
+			}
+			pairs = append(pairs, [2]string{d.Name, n})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+	return pairs
+}
+
+// datasetKeyInRoute returns a key identifying a dataset in a route independently of its state.
+// rpool/ROOT/ubuntu_1234/var@snap1 in route rpool/ROOT/ubuntu_1234@snap1 -> rpool/ROOT/var
+func datasetKeyInRoute(route, name string) string {
+	route, _ = splitSnapshotName(route)
+	name, _ = splitSnapshotName(name)
+	return filepath.Dir(route) + strings.TrimPrefix(name, route)
+}
+
+// pathMatches returns if path is filter or one of its children. Any path matches an empty filter.
+func pathMatches(path, filter string) bool {
+	filter = strings.TrimSuffix(filter, "/")
+	if filter == "" {
+		return true
+	}
+	return path == filter || strings.HasPrefix(path, filter+"/")
+}
@@ -0,0 +1,100 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// isLocked returns if any dataset of the state is encrypted with a key which isn't loaded.
+func (s State) isLocked() bool {
+	for _, d := range s.getDatasets() {
+		if d.EncryptionRoot != "" && !d.KeyLoaded {
+			return true
+		}
+	}
+	return false
+}
+
+// UnlockUserData loads the keys of user encrypted datasets on current machine from passphrase and mounts
+// its current user datasets.
+// Any revert of the user delayed on boot because its datasets were locked is done now.
+func (ms *Machines) UnlockUserData(ctx context.Context, user string, passphrase []byte) error {
+	if !ms.current.isZsys() {
+		return errors.New(i18n.G("Current machine isn't Zsys, nothing to unlock"))
+	}
+	if user == "" {
+		return errors.New(i18n.G("Needs a valid user name, got nothing"))
+	}
+	if len(passphrase) == 0 {
+		return errors.New(i18n.G("Needs a passphrase to unlock user datasets, got nothing"))
+	}
+
+	if _, ok := ms.current.Users[user]; !ok {
+		return fmt.Errorf(i18n.G("user %q not found on current state"), user)
+	}
+
+	roots := make(map[string]struct{})
+	for _, s := range ms.current.AllUsersStates[user] {
+		for _, d := range s.getDatasets() {
+			if d.EncryptionRoot != "" && !d.KeyLoaded {
+				roots[d.EncryptionRoot] = struct{}{}
+			}
+		}
+	}
+	if len(roots) == 0 {
+		log.Infof(ctx, i18n.G("No locked dataset for user %q"), user)
+		return nil
+	}
+
+	var sortedRoots []string
+	for r := range roots {
+		sortedRoots = append(sortedRoots, r)
+	}
+	sort.Strings(sortedRoots)
+
+	nt := ms.z.NewNoTransaction(ctx)
+	for _, r := range sortedRoots {
+		log.Infof(ctx, i18n.G("Unlocking %q"), r)
+		if err := nt.LoadKey(r, passphrase); err != nil {
+			return fmt.Errorf(i18n.G("couldn't unlock user %q: ")+config.ErrorFormat, user, err)
+		}
+	}
+	if err := ms.Refresh(ctx); err != nil {
+		return err
+	}
+
+	// Refresh replaced the current machine: get the user state again.
+	us, ok := ms.current.Users[user]
+	if !ok {
+		return fmt.Errorf(i18n.G("user %q not found on current state"), user)
+	}
+
+	// Apply the revert which couldn't be done on boot while the user datasets were locked.
+	if pendingID := us.Datasets[us.ID][0].PendingRevert; pendingID != "" {
+		log.Infof(ctx, i18n.G("Reverting user %q to %q"), user, pendingID)
+		if _, err := ms.RestoreUserState(ctx, pendingID, user); err != nil {
+			return fmt.Errorf(i18n.G("couldn't revert user %q to %q: ")+config.ErrorFormat, user, pendingID, err)
+		}
+		return nil
+	}
+
+	// Locked datasets were skipped when mounting on boot.
+	var toMount []*zfs.Dataset
+	for _, d := range us.getDatasets() {
+		if d.CanMount != "on" || d.Mountpoint == "" {
+			continue
+		}
+		toMount = append(toMount, d)
+	}
+	if err := ms.switchMounts(ctx, nil, toMount); err != nil {
+		return fmt.Errorf(i18n.G("couldn't mount user %q datasets: ")+config.ErrorFormat, user, err)
+	}
+	return nil
+}
@@ -0,0 +1,532 @@
+package machines
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// exportManifest is the first line of an export file, followed by the full stream of each dataset.
+type exportManifest struct {
+	// State is the ID of the exported state.
+	State string
+	// Datasets are the exported snapshots, in stream order.
+	Datasets []string
+}
+
+// Export is a state export prepared by PrepareExport. It streams from its own view of the datasets, so that it
+// doesn't need to lock machines while sending snapshots, which stay held until Done is called.
+type Export struct {
+	ms        *Machines
+	z         *zfs.Zfs
+	id        string
+	snapshots []string
+	target    string
+	release   func()
+	received  []string
+}
+
+// ExportState sends the snapshots of the state matching name, and of the user states linked to a system state, to target.
+// See PrepareExport for the accepted targets.
+func (ms *Machines) ExportState(ctx context.Context, name, user, target string) (err error) {
+	e, err := ms.PrepareExport(ctx, name, user, target)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errDone := e.Done(ctx); errDone != nil && err == nil {
+			err = errDone
+		}
+	}()
+	return e.Stream(ctx)
+}
+
+// PrepareExport resolves the state matching name and holds its snapshots, with the ones of the user states linked to
+// a system state, to export them to target.
+// target is either a file path, starting with /, receiving full streams, or a dataset.
+// Snapshots are replicated under a target dataset with their pool and path (<target>/<pool>/ROOT/ubuntu_xxx@snap),
+// incrementally from the newest snapshot already replicated. The target dataset is created and marked so that zsys
+// ignores the replicated states. An existing target dataset is only accepted if it was created by a previous export.
+func (ms *Machines) PrepareExport(ctx context.Context, name, user, target string) (*Export, error) {
+	s, err := ms.IDToState(ctx, name, user)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+	if !s.isSnapshot() {
+		return nil, fmt.Errorf(i18n.G("%s is the current state of a machine or a user: save it before exporting it"), s.ID)
+	}
+
+	var snapshots []*zfs.Dataset
+	for _, d := range append(s.getDatasets(), s.getUsersDatasets()...) {
+		if d.IsSnapshot {
+			snapshots = append(snapshots, d)
+		}
+	}
+	sort.Sort(sortedDataset(snapshots))
+
+	// Prevent snapshots from being destroyed while streaming them.
+	release, err := ms.holdSnapshots(ctx, snapshots)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't hold state %s: ")+config.ErrorFormat, s.ID, err)
+	}
+
+	if !strings.HasPrefix(target, "/") {
+		if err := ms.prepareExportDataset(ctx, snapshots, target); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	// Stream from a copy of the datasets: machines can be changed by other requests meanwhile.
+	z := *ms.z
+	if err := z.Refresh(ctx); err != nil {
+		release()
+		return nil, fmt.Errorf(i18n.G("couldn't scan datasets to export: ")+config.ErrorFormat, err)
+	}
+
+	e := Export{
+		ms:      ms,
+		z:       &z,
+		id:      s.ID,
+		target:  target,
+		release: release,
+	}
+	for _, d := range snapshots {
+		e.snapshots = append(e.snapshots, d.Name)
+	}
+	return &e, nil
+}
+
+// prepareExportDataset checks that target can receive exported states and creates it, with the missing parents of
+// the replicated datasets.
+func (ms *Machines) prepareExportDataset(ctx context.Context, snapshots []*zfs.Dataset, target string) error {
+	// Replicated datasets would be mistaken for the ones of machines.
+	if l := strings.ToLower(target) + "/"; strings.Contains(l, rootdatasetsContainerName) || isUserDataset(l) || isBootDataset(l) {
+		return fmt.Errorf(i18n.G("target dataset %q can't be in a system, boot or user data container"), target)
+	}
+
+	all := ms.datasetsByName()
+	d := all[target]
+	if d != nil && (d.IsSnapshot || !d.Backup) {
+		return fmt.Errorf(i18n.G("target dataset %q already exists and doesn't hold exported states"), target)
+	}
+	if parent := all[filepath.Dir(target)]; d == nil && (parent == nil || parent.IsSnapshot) {
+		return fmt.Errorf(i18n.G("parent dataset of %q doesn't exist"), target)
+	}
+
+	return ms.prepareReplication(ctx, snapshots,
+		func(fs string) string { return target + "/" + fs },
+		func(t *zfs.Transaction) error {
+			if d != nil {
+				return nil
+			}
+			if err := t.Create(target, "", "off"); err != nil {
+				return fmt.Errorf(i18n.G("couldn't create target dataset %q: ")+config.ErrorFormat, target, err)
+			}
+			// Backups keep the mountpoints of the original datasets: never mount them nor consider them as machines.
+			return t.SetProperty(libzfs.BackupProp, "yes", target, true)
+		})
+}
+
+// Stream sends the snapshots of the export to its target. It doesn't change machines and can run while other
+// requests are handled.
+func (e *Export) Stream(ctx context.Context) error {
+	log.Infof(ctx, i18n.G("Exporting state %s to %s"), e.id, e.target)
+
+	if strings.HasPrefix(e.target, "/") {
+		return e.streamToFile(ctx)
+	}
+
+	var err error
+	e.received, err = streamReplication(ctx, e.z, e.snapshots, func(fs string) string { return e.target + "/" + fs })
+	return err
+}
+
+// Done releases the snapshots of the export and refreshes machines with the replicated datasets.
+func (e *Export) Done(ctx context.Context) error {
+	e.release()
+	if strings.HasPrefix(e.target, "/") {
+		return nil
+	}
+
+	if err := e.ms.z.Refresh(ctx); err != nil {
+		return err
+	}
+	return e.ms.finishReplication(ctx, e.received)
+}
+
+// streamToFile writes a manifest and full streams of the snapshots to a new file at the export target.
+func (e *Export) streamToFile(ctx context.Context) (err error) {
+	path := e.target
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't create export file: ")+config.ErrorFormat, err)
+	}
+	defer func() {
+		if errClose := f.Close(); errClose != nil && err == nil {
+			err = fmt.Errorf(i18n.G("couldn't close export file: ")+config.ErrorFormat, errClose)
+		}
+		if err != nil {
+			if errRemove := os.Remove(path); errRemove != nil {
+				log.Warningf(ctx, i18n.G("couldn't remove incomplete export file %q: %v"), path, errRemove)
+			}
+		}
+	}()
+
+	data, err := json.Marshal(exportManifest{State: e.id, Datasets: e.snapshots})
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't encode export manifest: ")+config.ErrorFormat, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf(i18n.G("couldn't write export manifest: ")+config.ErrorFormat, err)
+	}
+
+	for _, n := range e.snapshots {
+		log.Debugf(ctx, i18n.G("Sending %s"), n)
+		if err := e.z.Send(n, "", f); err != nil {
+			return fmt.Errorf(i18n.G("couldn't export %q: ")+config.ErrorFormat, n, err)
+		}
+	}
+	return nil
+}
+
+// ImportState receives the snapshots of a state exported by ExportState back to their original pools.
+// source is either an export file path, starting with /, or the target dataset of an export, in which case name
+// is the snapshot name of the state to import.
+// Filesystems missing on the system are recreated. Existing ones, which must not be mounted, receive incrementally
+// from their newest snapshot, which is only possible from a dataset source.
+func (ms *Machines) ImportState(ctx context.Context, source, name string) error {
+	if strings.HasPrefix(source, "/") {
+		return ms.importFromFile(ctx, source)
+	}
+
+	if d := ms.datasetsByName()[source]; d == nil || d.IsSnapshot {
+		return fmt.Errorf(i18n.G("source dataset %q doesn't exist"), source)
+	}
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		return errors.New(i18n.G("state name is required to import from a dataset"))
+	}
+
+	var snapshots []*zfs.Dataset
+	for _, d := range ms.z.Datasets() {
+		if d.IsSnapshot && strings.HasPrefix(d.Name, source+"/") && strings.HasSuffix(d.Name, "@"+name) {
+			snapshots = append(snapshots, d)
+		}
+	}
+	if len(snapshots) == 0 {
+		return fmt.Errorf(i18n.G("no state %q found in %q"), name, source)
+	}
+	sort.Sort(sortedDataset(snapshots))
+
+	// Receiving would replace the content of a filesystem in use.
+	all := ms.datasetsByName()
+	for _, d := range snapshots {
+		fs, snapshot := splitSnapshotName(d.Name)
+		dest := all[strings.TrimPrefix(fs, source+"/")]
+		if dest != nil && dest.Mounted && all[dest.Name+"@"+snapshot] == nil {
+			return fmt.Errorf(i18n.G("%q is mounted: a state can't be imported onto a filesystem in use"), dest.Name)
+		}
+	}
+
+	log.Infof(ctx, i18n.G("Importing state %s from %s"), name, source)
+
+	return ms.replicate(ctx, snapshots,
+		func(fs string) string { return strings.TrimPrefix(fs, source+"/") }, nil)
+}
+
+// importFromFile receives the streams of an export file at path to the original location of each snapshot.
+func (ms *Machines) importFromFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't open export file: ")+config.ErrorFormat, err)
+	}
+	defer f.Close()
+
+	m, err := readExportManifest(f)
+	if err != nil {
+		return fmt.Errorf(i18n.G("%q isn't a valid export file: ")+config.ErrorFormat, path, err)
+	}
+
+	log.Infof(ctx, i18n.G("Importing state %s from %s"), m.State, path)
+
+	// Full streams can only create filesystems: check them all before receiving anything.
+	all := ms.datasetsByName()
+	received := make(map[string]bool)
+	for _, n := range m.Datasets {
+		fs, _ := splitSnapshotName(n)
+		if all[fs] != nil {
+			return fmt.Errorf(i18n.G("%q already exists: only states whose datasets were removed can be imported from a file"), fs)
+		}
+		if parent := filepath.Dir(fs); all[parent] == nil && !received[parent] {
+			return fmt.Errorf(i18n.G("parent dataset of %q doesn't exist"), fs)
+		}
+		received[fs] = true
+	}
+
+	nt := ms.z.NewNoTransaction(ctx)
+	for _, n := range m.Datasets {
+		log.Debugf(ctx, i18n.G("Receiving %s"), n)
+		fs, _ := splitSnapshotName(n)
+		if err := nt.Receive(filepath.Dir(fs), f); err != nil {
+			return fmt.Errorf(i18n.G("couldn't import %q: ")+config.ErrorFormat, n, err)
+		}
+	}
+
+	if err := ms.setCanMountNoAuto(ctx, m.Datasets); err != nil {
+		return err
+	}
+
+	return ms.Refresh(ctx)
+}
+
+// readExportManifest reads the first line of an export file, leaving f at the start of the first stream.
+func readExportManifest(f *os.File) (m exportManifest, err error) {
+	var data []byte
+	b := make([]byte, 1)
+	for {
+		n, err := f.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			data = append(data, b[0])
+			continue
+		}
+		if err == io.EOF {
+			return m, errors.New(i18n.G("no manifest found"))
+		} else if err != nil {
+			return m, err
+		}
+	}
+
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, err
+	}
+	if m.State == "" || len(m.Datasets) == 0 {
+		return m, errors.New(i18n.G("no state found in manifest"))
+	}
+	return m, nil
+}
+
+// replicate sends snapshots to the filesystems returned by destination for their own filesystem.
+// prepare, if not nil, is run before any dataset is created or received.
+func (ms *Machines) replicate(ctx context.Context, snapshots []*zfs.Dataset, destination func(fs string) string,
+	prepare func(t *zfs.Transaction) error) error {
+	if err := ms.prepareReplication(ctx, snapshots, destination, prepare); err != nil {
+		return err
+	}
+
+	var names []string
+	for _, d := range snapshots {
+		names = append(names, d.Name)
+	}
+	received, err := streamReplication(ctx, ms.z, names, destination)
+	if err != nil {
+		return err
+	}
+
+	return ms.finishReplication(ctx, received)
+}
+
+// streamReplication sends snapshots, by name, to the filesystems returned by destination for their own filesystem,
+// with z as the only view of datasets. It returns the snapshots received.
+// Snapshots already present on destination are skipped, making interrupted replications resumable.
+func streamReplication(ctx context.Context, z *zfs.Zfs, snapshots []string, destination func(fs string) string) ([]string, error) {
+	// Received data can't be reverted: what is received stays even if a following snapshot fails.
+	nt := z.NewNoTransaction(ctx)
+	var received []string
+	for _, n := range snapshots {
+		fs, snapshot := splitSnapshotName(n)
+		dest := destination(fs)
+		all := datasetsByName(z)
+		d := all[n]
+		if d == nil {
+			return received, fmt.Errorf(i18n.G("%q doesn't exist anymore"), n)
+		}
+		if all[dest+"@"+snapshot] != nil {
+			log.Infof(ctx, i18n.G("%s is already present on %s, skipping"), n, dest)
+			continue
+		}
+
+		var from string
+		if all[dest] != nil {
+			from = commonSnapshot(all, d, dest)
+			if from == "" {
+				return received, fmt.Errorf(i18n.G("%q already exists and its newest snapshot isn't an earlier snapshot of %q"), dest, n)
+			}
+		}
+
+		log.Debugf(ctx, i18n.G("Sending %s to %s, incremental from: %q"), n, dest, from)
+		if err := sendReceive(z, nt, n, from, filepath.Dir(dest)); err != nil {
+			return received, fmt.Errorf(i18n.G("couldn't replicate %q to %q: ")+config.ErrorFormat, n, dest, err)
+		}
+		received = append(received, dest+"@"+snapshot)
+	}
+	return received, nil
+}
+
+// finishReplication prepares received snapshots to be listed by zsys.
+// Received filesystems are never mounted automatically: they aren't the current state of any machine and zsys
+// switches canmount when booting on them.
+func (ms *Machines) finishReplication(ctx context.Context, received []string) error {
+	if err := ms.setCanMountNoAuto(ctx, received); err != nil {
+		return err
+	}
+
+	return ms.Refresh(ctx)
+}
+
+// prepareReplication runs prepare, if not nil, and creates missing parents of destinations which aren't replicated
+// themselves, with canmount=off as they only hold the hierarchy.
+func (ms *Machines) prepareReplication(ctx context.Context, snapshots []*zfs.Dataset, destination func(fs string) string,
+	prepare func(t *zfs.Transaction) error) error {
+	destinations := make(map[string]bool)
+	for _, d := range snapshots {
+		fs, _ := splitSnapshotName(d.Name)
+		destinations[destination(fs)] = true
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	if prepare != nil {
+		if err := prepare(t); err != nil {
+			cancel()
+			return err
+		}
+	}
+
+	// prepare can create datasets.
+	all := ms.datasetsByName()
+	for _, d := range snapshots {
+		fs, _ := splitSnapshotName(d.Name)
+		parents := strings.Split(filepath.Dir(destination(fs)), "/")
+		// Start below the pool, which can't be created.
+		for i := 1; i < len(parents); i++ {
+			p := strings.Join(parents[:i+1], "/")
+			if destinations[p] {
+				break
+			}
+			if all[p] != nil {
+				continue
+			}
+			if err := t.Create(p, "", "off"); err != nil {
+				cancel()
+				return fmt.Errorf(i18n.G("couldn't create parent dataset %q: ")+config.ErrorFormat, p, err)
+			}
+			all[p] = &zfs.Dataset{Name: p}
+		}
+	}
+	return nil
+}
+
+// commonSnapshot returns the snapshot of the filesystem of d to send d from incrementally to dest: it matches the
+// newest snapshot of dest, as dest can only receive from it, and must be older than d.
+// It returns an empty string if there is no such snapshot.
+func commonSnapshot(all map[string]*zfs.Dataset, d *zfs.Dataset, dest string) string {
+	fs, _ := splitSnapshotName(d.Name)
+
+	var newest *zfs.Dataset
+	for n, c := range all {
+		if cfs, _ := splitSnapshotName(n); cfs != dest || !c.IsSnapshot {
+			continue
+		}
+		if newest == nil || c.LastUsed > newest.LastUsed {
+			newest = c
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+
+	_, snapshot := splitSnapshotName(newest.Name)
+	from := all[fs+"@"+snapshot]
+	if from == nil || from.LastUsed > d.LastUsed {
+		return ""
+	}
+	return from.Name
+}
+
+// sendReceive streams snapshot name of z, incremental from from if not empty, to be received under parent.
+func sendReceive(z *zfs.Zfs, nt *zfs.NoTransaction, name, from, parent string) error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	// Receiving refreshes the datasets: send from a copy of them.
+	sender := *z
+	errSend := make(chan error, 1)
+	go func() {
+		errSend <- sender.Send(name, from, w)
+		w.Close()
+	}()
+
+	errReceive := nt.Receive(parent, r)
+	// Unblock the sender if the receiver stopped reading.
+	r.Close()
+
+	// A failing receiver makes the sender fail too: report the root cause.
+	if err := <-errSend; errReceive == nil {
+		return err
+	}
+	return errReceive
+}
+
+// setCanMountNoAuto sets canmount=noauto on the filesystems of received snapshots.
+func (ms *Machines) setCanMountNoAuto(ctx context.Context, snapshots []string) error {
+	all := ms.datasetsByName()
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	for _, n := range snapshots {
+		fs, _ := splitSnapshotName(n)
+		if all[fs] == nil || all[fs].CanMount == "noauto" {
+			continue
+		}
+		if err := t.SetProperty(libzfs.CanmountProp, "noauto", fs, true); err != nil {
+			cancel()
+			return fmt.Errorf(i18n.G("couldn't set canmount on %q: ")+config.ErrorFormat, fs, err)
+		}
+	}
+	return nil
+}
+
+// datasetsByName returns all datasets on the system, indexed by name.
+func (ms *Machines) datasetsByName() map[string]*zfs.Dataset {
+	return datasetsByName(ms.z)
+}
+
+// datasetsByName returns all datasets of z, indexed by name.
+func datasetsByName(z *zfs.Zfs) map[string]*zfs.Dataset {
+	r := make(map[string]*zfs.Dataset)
+	for _, d := range z.Datasets() {
+		r[d.Name] = d
+	}
+	return r
+}
+
+// isBackup returns if d belongs to a dataset hierarchy holding exported states.
+func isBackup(d *zfs.Dataset, backups map[string]bool) bool {
+	if !d.IsSnapshot {
+		return d.Backup
+	}
+	fs, _ := splitSnapshotName(d.Name)
+	return backups[fs]
+}
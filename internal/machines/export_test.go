@@ -12,6 +12,7 @@ import (
 const (
 	RevertUserDataTag       = zfsRevertUserDataTag
 	AutomatedSnapshotPrefix = automatedSnapshotPrefix
+	DiffBatchSize           = diffBatchSize
 )
 
 // WithTime allows overriding default time implementations with a mock
@@ -22,6 +23,23 @@ func WithTime(time Nower) func(o *options) error {
 	}
 }
 
+// WithMounter allows overriding default mount and unmount system calls with a mock
+func WithMounter(mounter Mounter) func(o *options) error {
+	return func(o *options) error {
+		o.mounter = mounter
+		return nil
+	}
+}
+
+// PendingRevert returns the system state ID the current machine will revert to on next boot and if user data
+// will be reverted with it.
+func (ms *Machines) PendingRevert() (id string, revertUserData bool) {
+	if !ms.current.isZsys() {
+		return "", false
+	}
+	return parsePendingRevert(ms.current.Datasets[ms.current.ID][0].PendingRevert)
+}
+
 // Import from json to export the private fields
 func (ms *Machines) UnmarshalJSON(b []byte) error {
 	mt := Machinesdump{}
@@ -72,6 +90,7 @@ func (ms *Machines) MakeComparable() {
 
 	ms.z = nil
 	ms.time = nil
+	ms.mounter = nil
 	ms.conf = config.ZConfig{}
 }
 
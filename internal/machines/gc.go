@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/hooks"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
 	"github.com/ubuntu/zsys/internal/zfs"
@@ -47,33 +48,37 @@ type stateWithKeep struct {
 // GC starts garbage collection for system and users
 // If all is set manual snapshots are considered too
 func (ms *Machines) GC(ctx context.Context, all bool) error {
-	now := ms.time.Now()
+	if _, err := ms.gc(ctx, all, false); err != nil {
+		return err
+	}
+	ms.hooksRunner().RunPost(ctx, hooks.PostGC, hooks.Env{})
+	return nil
+}
 
-	buckets := computeBuckets(ctx, now, ms.conf.History)
-	keepLast := ms.conf.History.KeepLast
+// GCDryRun computes what the garbage collection would do, without removing anything.
+// It returns the decision taken for each state, with its bucket and the reason why it's kept or removed.
+// As nothing is removed, user states untagged when removing their system state are still considered linked to it.
+func (ms *Machines) GCDryRun(ctx context.Context, all bool) ([]GCDecision, error) {
+	return ms.gc(ctx, all, true)
+}
 
-	allDatasets := make([]*zfs.Dataset, 0, len(ms.allSystemDatasets)+len(ms.allPersistentDatasets)+len(ms.allUsersDatasets)+len(ms.unmanagedDatasets))
+// gc runs the garbage collection, only recording its decisions without removing anything if dryRun is set.
+func (ms *Machines) gc(ctx context.Context, all, dryRun bool) ([]GCDecision, error) {
+	now := ms.time.Now()
 
-	byOrigin := make(map[string][]string)      // list of clones for a given origin (snapshot)
-	snapshotsByDS := make(map[string][]string) // List of snapshots for a given dataset
+	// System and user states have their own history rules.
+	strategy := newGCStrategy(ms.conf.History.System)
+	buckets := strategy.buckets(ctx, now)
+	keepLast := ms.conf.History.System.KeepLast
 
 	log.Debug(ctx, i18n.G("Collect datasets"))
-	allDatasets = append(allDatasets, ms.allSystemDatasets...)
-	allDatasets = append(allDatasets, ms.allPersistentDatasets...)
-	allDatasets = append(allDatasets, ms.allUsersDatasets...)
-	allDatasets = append(allDatasets, ms.unmanagedDatasets...)
-
-	for _, d := range allDatasets {
-		if !d.IsSnapshot && d.Origin != "" {
-			byOrigin[d.Origin] = append(byOrigin[d.Origin], d.Name)
-		} else if d.IsSnapshot {
-			n, _ := splitSnapshotName(d.Name)
-			snapshotsByDS[n] = append(snapshotsByDS[n], d.Name)
-		}
-	}
+	byOrigin, snapshotsByDS := ms.datasetsRelations()
 
 	var statesToRemove []*State
 	keepDueToErrorOnDelete := make(map[string]bool)
+	// States selected for removal in dry run mode, that we treat as already removed.
+	removed := make(map[string]bool)
+	plan := newGCPlan()
 
 	// 1. System GC
 	var gcPassNum int
@@ -90,6 +95,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 			var newestStateIndex int
 			var sortedStates sortedReverseByTimeStates
 			for _, s := range m.History {
+				if removed[s.ID] {
+					continue
+				}
 				sortedStates = append(sortedStates, s)
 			}
 			sort.Sort(sortedStates)
@@ -124,6 +132,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 				// Don't touch anything for this bucket, skip all states in here and advance to next one.
 				if bucket.samples == -1 {
 					log.Debug(ctx, i18n.G("Keeping all snapshots for this bucket"))
+					for _, s := range sortedStates[newestStateIndex : oldestStateIndex+1] {
+						plan.add(m, "", s, &bucket, false, keepReasonRecent)
+					}
 					newestStateIndex = oldestStateIndex + 1
 					continue
 				}
@@ -137,44 +148,13 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 					s := sortedStates[i]
 
 					keep := keepUnknown
-					// Previous deletion failed
-					if keepDueToErrorOnDelete[s.ID] {
-						keep = keepYes
-					}
-					// In keep last list
-					if keep == keepUnknown && i < keepLast {
-						log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's in the last %d snapshots"), s.ID, keepLast)
+					reason := systemStateKeepReason(ctx, s, i, keepLast, all, keepDueToErrorOnDelete, byOrigin, snapshotsByDS)
+					if reason != keepReasonNone {
 						keep = keepYes
+					} else {
+						reason = keepReasonBucketSample
 					}
-					// Has snapshots as children
-					if keep == keepUnknown && !s.isSnapshot() {
-						for _, ds := range s.Datasets {
-							if ds[0].HasSnapshotInHierarchy() {
-								log.Debugf(ctx, i18n.G("Keeping %v as it has a snapshot in its child hierarchy"), s.ID)
-								keep = keepYes
-							}
-						}
-					}
-					// Non automated snapshots
-					if keep == keepUnknown && s.isSnapshot() && !all && !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
-						log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's not a zsys one"), s.ID)
-						keep = keepYes
-					}
-					// Has clones
-					if keep == keepUnknown && s.isSnapshot() {
-						// We only collect systems because users will be untagged if they have any dependency
-					analyzeSystemDataset:
-						for _, ds := range s.Datasets {
-							for _, d := range ds {
-								// keep the whole state if any dataset is the origin of a clone of if it’s a clone with snapshots on it
-								if byOrigin[d.Name] != nil || snapshotsByDS[d.Name] != nil {
-									log.Debugf(ctx, i18n.G("Keeping snapshot %v as at least %s dataset has dependencies"), s.ID, d.Name)
-									keep = keepYes
-									break analyzeSystemDataset
-								}
-							}
-						}
-					}
+					plan.add(m, "", s, &bucket, false, reason)
 
 					states = append(states, stateWithKeep{
 						State: s,
@@ -192,10 +172,11 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 				}
 				log.Debugf(ctx, i18n.G("There are %d exceeding states to potentially remove"), nStatesToRemove)
 
-				statesToRemoveForBucket := selectStatesToRemove(ctx, bucket.samples, states)
+				statesToRemoveForBucket := strategy.selectStatesToRemove(ctx, bucket, states)
 
 				for _, s := range statesToRemoveForBucket {
 					statesChanges = true
+					plan.add(m, "", s, &bucket, true, removeReasonBucketFull)
 					// We are removing that state: purge all datasets from our maps.
 					// We don’t deal with user datasets right now as we only untag them.
 					for _, ds := range s.Datasets {
@@ -224,14 +205,20 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 		// Remove the given states.
 		for _, s := range statesToRemove {
 			log.Infof(ctx, i18n.G("Selecting state to remove: %s"), s.ID)
+			if dryRun {
+				removed[s.ID] = true
+				continue
+			}
 			if err := s.remove(ctx, ms, ""); err != nil {
 				log.Errorf(ctx, i18n.G("Couldn't fully destroy state %s: %v\nPutting it in keep list."), s.ID, err)
 				keepDueToErrorOnDelete[s.ID] = true
 			}
 		}
 		statesToRemove = nil
-		if err := ms.Refresh(ctx); err != nil {
-			return fmt.Errorf("Couldn't refresh machine list: %v", err)
+		if !dryRun {
+			if err := ms.Refresh(ctx); err != nil {
+				return nil, fmt.Errorf("Couldn't refresh machine list: %v", err)
+			}
 		}
 		log.Debug(ctx, i18n.G("System have changes, rerun system GC"))
 	}
@@ -252,12 +239,20 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 
 		for _, m := range ms.all {
 			// FIXME: we count same user state multiple times if linked to multiple bootfs systems
-			for _, us := range m.AllUsersStates {
+			for user, us := range m.AllUsersStates {
+				rules := ms.conf.History.ForUser(user)
+				strategy := newGCStrategy(rules)
+				buckets := strategy.buckets(ctx, now)
+				keepLast := rules.KeepLast
+
 				var newestStateIndex int
 				var sortedStates sortedReverseByTimeStates
 
 			nextUserState:
 				for _, s := range us {
+					if removed[s.ID] {
+						continue
+					}
 					// exclude "current" user state fom history
 					for _, us := range m.State.Users {
 						if us == s {
@@ -297,6 +292,9 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 					// Don't touch anything for this bucket, skip all states in here and advance to next one.
 					if bucket.samples == -1 {
 						log.Debug(ctx, i18n.G("Keeping all snapshots for this bucket"))
+						for _, s := range sortedStates[newestStateIndex : oldestStateIndex+1] {
+							plan.add(m, user, s, &bucket, false, keepReasonRecent)
+						}
 						newestStateIndex = oldestStateIndex + 1
 						continue
 					}
@@ -309,64 +307,13 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 						log.Debugf(ctx, i18n.G("Analyzing state %v: %v"), s.ID, s.LastUsed.Format(timeFormat))
 
 						keep := keepUnknown
-						// Previous deletion failed
-						if keepDueToErrorOnDelete[s.ID] {
-							keep = keepYes
-						}
-						// In keep last list
-						if keep == keepUnknown && i < keepLast {
-							log.Debugf(ctx, i18n.G("Keeping %v as it's in the last %d snapshots"), s.ID, keepLast)
+						reason := userStateKeepReason(ctx, m, s, i, keepLast, all, keepDueToErrorOnDelete, byOrigin, removed)
+						if reason != keepReasonNone {
 							keep = keepYes
+						} else {
+							reason = keepReasonBucketSample
 						}
-						// Has snapshots as children
-						if keep == keepUnknown && !s.isSnapshot() {
-							for _, ds := range s.Datasets {
-								if ds[0].HasSnapshotInHierarchy() {
-									log.Debugf(ctx, i18n.G("Keeping %v as it has a snapshot in its child hierarchy"), s.ID)
-									keep = keepYes
-								}
-							}
-						}
-						// Non automated snapshots
-						if keep == keepUnknown && s.isSnapshot() && !all && !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
-							log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's not a zsys one"), s.ID)
-							keep = keepYes
-						}
-						// Filesystem linked to system state
-						if keep == keepUnknown && !s.isSnapshot() && s.linkedToSystemState() {
-							log.Debugf(ctx, i18n.G("Keeping %v as it's not a snapshot and associated to a system state"), s.ID)
-							keep = keepYes
-						}
-						// Snapshot linked to system state
-						if keep == keepUnknown && s.isSnapshot() {
-							_, snapshotName := splitSnapshotName(s.ID)
-							// Do we have a state associated with us?
-							for k := range m.History {
-								_, n := splitSnapshotName(k)
-								if n == snapshotName {
-									log.Debugf(ctx, i18n.G("Keeping as snapshot %v is associated to a system snapshot"), s.ID)
-									keep = keepYes
-									break
-								}
-							}
-						}
-
-						// Has clones
-						if keep == keepUnknown && s.isSnapshot() {
-						analyzeUserDataset:
-							for _, ds := range s.Datasets {
-								for _, d := range ds {
-									// We only treat snapshots as clones are necessarily associated with one system state or
-									// has already been destroyed and not associated.
-									// do we have clones of us?
-									if byOrigin[d.Name] != nil {
-										log.Debugf(ctx, i18n.G("Keeping snapshot %v as at least %s dataset has dependencies"), s.ID, d.Name)
-										keep = keepYes
-										break analyzeUserDataset
-									}
-								}
-							}
-						}
+						plan.add(m, user, s, &bucket, false, reason)
 
 						states = append(states, stateWithKeep{
 							State: s,
@@ -387,10 +334,11 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 					}
 					log.Debugf(ctx, i18n.G("There are %d exceeding states to potentially remove"), nStatesToRemove)
 
-					statesToRemoveForBucket := selectStatesToRemove(ctx, bucket.samples, states)
+					statesToRemoveForBucket := strategy.selectStatesToRemove(ctx, bucket, states)
 
 					for _, s := range statesToRemoveForBucket {
 						statesChanges = true
+						plan.add(m, user, s, &bucket, true, removeReasonBucketFull)
 						// We are removing that state: purge all datasets from our maps.
 						for _, ds := range s.Datasets {
 							for _, d := range ds {
@@ -417,6 +365,10 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 		// Remove the given states.
 		for _, s := range statesToRemove {
 			log.Infof(ctx, i18n.G("Selecting state to remove: %s"), s.ID)
+			if dryRun {
+				removed[s.ID] = true
+				continue
+			}
 			if err := s.remove(ctx, ms, ""); err != nil {
 				log.Errorf(ctx, i18n.G("Couldn't fully destroy user state %s: %v.\nPutting it in keep list."), s.ID, err)
 				keepDueToErrorOnDelete[s.ID] = true
@@ -428,8 +380,10 @@ func (ms *Machines) GC(ctx context.Context, all bool) error {
 		}
 
 		statesToRemove = nil
-		if err := ms.Refresh(ctx); err != nil {
-			return fmt.Errorf("Couldn't refresh machine list: %v", err)
+		if !dryRun {
+			if err := ms.Refresh(ctx); err != nil {
+				return nil, fmt.Errorf("Couldn't refresh machine list: %v", err)
+			}
 		}
 		log.Debug(ctx, i18n.G("Users states have changes, rerun user GC"))
 	}
@@ -454,10 +408,18 @@ nextUnmanagedUserPass:
 			if _, ok := keepDueToErrorOnDelete[d.Name]; ok {
 				continue
 			}
+			// Ignore datasets already selected for removal in dry run mode
+			if removed[d.Name] {
+				continue
+			}
 
 			if d.IsSnapshot {
 				continue
 			}
+			// Never collect user datasets on pools we aren't configured to manage them on.
+			if !inPools(d.Name, ms.conf.Pools.UserData) {
+				continue
+			}
 			r, err := d.IsUserDataset()
 			if err != nil {
 				log.Warningf(ctx, i18n.G("Couldn't determine if %s was a user dataset %v"), d.Name, err)
@@ -489,14 +451,27 @@ nextUnmanagedUserPass:
 			if d.IsSnapshot {
 				continue
 			}
-			if _, ok := destroyCandidates[d.Name]; !ok {
+			if _, ok := destroyCandidates[d.Name]; !ok && !removed[d.Name] {
 				log.Infof(ctx, "Won’t remove %s: %s is a dependency not listed for auto destruction", candidate.Name, d.Name)
 				keepDatasets[candidate.Name] = true
+				plan.addDataset(candidate, false, keepReasonHasDependencies)
 				continue nextUnmanagedUserPass
 			}
 		}
 
 		log.Debugf(ctx, "Trying to destroy %s", candidate.Name)
+		for _, d := range append(deps, candidate) {
+			if !d.IsSnapshot {
+				plan.addDataset(d, true, removeReasonUnmanaged)
+			}
+		}
+		if dryRun {
+			for _, d := range append(deps, candidate) {
+				removed[d.Name] = true
+			}
+			gcPassNum++
+			continue
+		}
 		for _, d := range append(deps, candidate) {
 			// We destroy here all snapshots and leaf attached. Snapshots won’t be taken into account, however, we don’t want
 			// to try destroying leaves again, keep a list.
@@ -507,12 +482,190 @@ nextUnmanagedUserPass:
 		}
 
 		if err := ms.Refresh(ctx); err != nil {
-			return fmt.Errorf("Couldn't refresh machine list: %v", err)
+			return nil, fmt.Errorf("Couldn't refresh machine list: %v", err)
 		}
 		gcPassNum++
 	}
 
-	return nil
+	// 4. Space based GC, on top of the history policy.
+	if err := ms.gcForSpace(ctx, all, dryRun, removed, plan); err != nil {
+		return nil, err
+	}
+
+	return plan.decisions(), nil
+}
+
+// datasetsRelations returns the list of clones for each origin snapshot and the list of snapshots for each dataset.
+func (ms *Machines) datasetsRelations() (byOrigin, snapshotsByDS map[string][]string) {
+	allDatasets := make([]*zfs.Dataset, 0, len(ms.allSystemDatasets)+len(ms.allPersistentDatasets)+len(ms.allUsersDatasets)+len(ms.unmanagedDatasets))
+	allDatasets = append(allDatasets, ms.allSystemDatasets...)
+	allDatasets = append(allDatasets, ms.allPersistentDatasets...)
+	allDatasets = append(allDatasets, ms.allUsersDatasets...)
+	allDatasets = append(allDatasets, ms.unmanagedDatasets...)
+
+	byOrigin = make(map[string][]string)
+	snapshotsByDS = make(map[string][]string)
+	for _, d := range allDatasets {
+		if !d.IsSnapshot && d.Origin != "" {
+			byOrigin[d.Origin] = append(byOrigin[d.Origin], d.Name)
+		} else if d.IsSnapshot {
+			n, _ := splitSnapshotName(d.Name)
+			snapshotsByDS[n] = append(snapshotsByDS[n], d.Name)
+		}
+	}
+	return byOrigin, snapshotsByDS
+}
+
+// gcReason explains why the GC keeps or removes a state.
+type gcReason string
+
+const (
+	keepReasonNone             gcReason = ""
+	keepReasonDeletionFailed   gcReason = "deletion-failed"
+	keepReasonPinned           gcReason = "pinned"
+	keepReasonHeld             gcReason = "held"
+	keepReasonKeepLast         gcReason = "keep-last"
+	keepReasonSnapshotChildren gcReason = "snapshot-children"
+	keepReasonManualSnapshot   gcReason = "manual-snapshot"
+	keepReasonHasClones        gcReason = "has-clones"
+	keepReasonLinkedToSystem   gcReason = "linked-to-system-state"
+	keepReasonSystemSnapshot   gcReason = "system-snapshot"
+	keepReasonRecent           gcReason = "recent"
+	keepReasonBucketSample     gcReason = "bucket-sample"
+	keepReasonHasDependencies  gcReason = "has-dependencies"
+
+	removeReasonBucketFull      gcReason = "bucket-full"
+	removeReasonUnmanaged       gcReason = "unmanaged-user-dataset"
+	removeReasonFreeSpaceTarget gcReason = "free-space-target"
+	removeReasonMaxStateSize    gcReason = "max-state-size"
+)
+
+// systemStateKeepReason returns why the system history state s, at index in the most recent first history, must be kept.
+// keepReasonNone is returned if the state can be collected.
+func systemStateKeepReason(ctx context.Context, s *State, index, keepLast int, all bool, failedDeletions map[string]bool,
+	byOrigin, snapshotsByDS map[string][]string) gcReason {
+	// Previous deletion failed
+	if failedDeletions[s.ID] {
+		return keepReasonDeletionFailed
+	}
+	// Pinned by the user, even when collecting all states
+	if s.IsPinned() {
+		log.Debugf(ctx, i18n.G("Keeping %v as it's pinned"), s.ID)
+		return keepReasonPinned
+	}
+	// Held by another application, which would prevent its destruction anyway
+	if tags := s.externalHolds(); len(tags) > 0 {
+		log.Infof(ctx, i18n.G("Keeping %v as it's held by %s"), s.ID, strings.Join(tags, ", "))
+		return keepReasonHeld
+	}
+	// In keep last list
+	if index < keepLast {
+		log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's in the last %d snapshots"), s.ID, keepLast)
+		return keepReasonKeepLast
+	}
+	// Has snapshots as children
+	if !s.isSnapshot() {
+		for _, ds := range s.Datasets {
+			if ds[0].HasSnapshotInHierarchy() {
+				log.Debugf(ctx, i18n.G("Keeping %v as it has a snapshot in its child hierarchy"), s.ID)
+				return keepReasonSnapshotChildren
+			}
+		}
+	}
+	// Non automated snapshots
+	if s.isSnapshot() && !all && !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
+		log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's not a zsys one"), s.ID)
+		return keepReasonManualSnapshot
+	}
+	// Has clones
+	if s.isSnapshot() {
+		// We only collect systems because users will be untagged if they have any dependency
+		for _, ds := range s.Datasets {
+			for _, d := range ds {
+				// keep the whole state if any dataset is the origin of a clone of if it’s a clone with snapshots on it
+				if byOrigin[d.Name] != nil || snapshotsByDS[d.Name] != nil {
+					log.Debugf(ctx, i18n.G("Keeping snapshot %v as at least %s dataset has dependencies"), s.ID, d.Name)
+					return keepReasonHasClones
+				}
+			}
+		}
+	}
+	return keepReasonNone
+}
+
+// userStateKeepReason returns why the user state s of machine m, at index in the most recent first user history, must be kept.
+// System states in removed are considered as already removed.
+// keepReasonNone is returned if the state can be collected.
+func userStateKeepReason(ctx context.Context, m *Machine, s *State, index, keepLast int, all bool, failedDeletions map[string]bool,
+	byOrigin map[string][]string, removed map[string]bool) gcReason {
+	// Previous deletion failed
+	if failedDeletions[s.ID] {
+		return keepReasonDeletionFailed
+	}
+	// Pinned by the user, even when collecting all states
+	if s.IsPinned() {
+		log.Debugf(ctx, i18n.G("Keeping %v as it's pinned"), s.ID)
+		return keepReasonPinned
+	}
+	// Held by another application, which would prevent its destruction anyway
+	if tags := s.externalHolds(); len(tags) > 0 {
+		log.Infof(ctx, i18n.G("Keeping %v as it's held by %s"), s.ID, strings.Join(tags, ", "))
+		return keepReasonHeld
+	}
+	// In keep last list
+	if index < keepLast {
+		log.Debugf(ctx, i18n.G("Keeping %v as it's in the last %d snapshots"), s.ID, keepLast)
+		return keepReasonKeepLast
+	}
+	// Has snapshots as children
+	if !s.isSnapshot() {
+		for _, ds := range s.Datasets {
+			if ds[0].HasSnapshotInHierarchy() {
+				log.Debugf(ctx, i18n.G("Keeping %v as it has a snapshot in its child hierarchy"), s.ID)
+				return keepReasonSnapshotChildren
+			}
+		}
+	}
+	// Non automated snapshots
+	if s.isSnapshot() && !all && !strings.Contains(s.ID, "@"+automatedSnapshotPrefix) {
+		log.Debugf(ctx, i18n.G("Keeping snapshot %v as it's not a zsys one"), s.ID)
+		return keepReasonManualSnapshot
+	}
+	// Filesystem linked to system state
+	if !s.isSnapshot() && s.linkedToSystemState() {
+		log.Debugf(ctx, i18n.G("Keeping %v as it's not a snapshot and associated to a system state"), s.ID)
+		return keepReasonLinkedToSystem
+	}
+	// Snapshot linked to system state
+	if s.isSnapshot() {
+		_, snapshotName := splitSnapshotName(s.ID)
+		// Do we have a state associated with us?
+		for k := range m.History {
+			if removed[k] {
+				continue
+			}
+			_, n := splitSnapshotName(k)
+			if n == snapshotName {
+				log.Debugf(ctx, i18n.G("Keeping as snapshot %v is associated to a system snapshot"), s.ID)
+				return keepReasonSystemSnapshot
+			}
+		}
+	}
+	// Has clones
+	if s.isSnapshot() {
+		for _, ds := range s.Datasets {
+			for _, d := range ds {
+				// We only treat snapshots as clones are necessarily associated with one system state or
+				// has already been destroyed and not associated.
+				// do we have clones of us?
+				if byOrigin[d.Name] != nil {
+					log.Debugf(ctx, i18n.G("Keeping snapshot %v as at least %s dataset has dependencies"), s.ID, d.Name)
+					return keepReasonHasClones
+				}
+			}
+		}
+	}
+	return keepReasonNone
 }
 
 func removeFromSlice(s []string, name string) (r []string) {
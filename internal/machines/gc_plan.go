@@ -0,0 +1,76 @@
+package machines
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// GCDecision is what the garbage collection decided for a state or an unmanaged user dataset.
+type GCDecision struct {
+	// Machine is the ID of the machine the state belongs to. It's empty for unmanaged user datasets.
+	Machine string
+	// User is the owner of a user state. It's empty for system states.
+	User string
+	// State is the ID of the state or the name of the unmanaged user dataset.
+	State    string
+	LastUsed time.Time
+	// Bucket is the history policy bucket the state fell into, if any.
+	Bucket string
+	// Remove is true when the state is collected.
+	Remove bool
+	// Reason explains why the state is kept or removed.
+	Reason string
+}
+
+// gcPlan records garbage collection decisions. Later decisions on a state override previous ones.
+type gcPlan struct {
+	byKey map[string]*GCDecision
+}
+
+func newGCPlan() *gcPlan {
+	return &gcPlan{byKey: make(map[string]*GCDecision)}
+}
+
+// add records the decision for the state s of machine m, owned by user if not empty.
+// The bucket of a previous decision is kept if b is nil.
+func (p *gcPlan) add(m *Machine, user string, s *State, b *bucket, remove bool, reason gcReason) {
+	key := m.ID + "|" + user + "|" + s.ID
+	d, ok := p.byKey[key]
+	if !ok {
+		d = &GCDecision{Machine: m.ID, User: user, State: s.ID, LastUsed: s.LastUsed}
+		p.byKey[key] = d
+	}
+	if b != nil {
+		d.Bucket = b.String()
+	}
+	d.Remove = remove
+	d.Reason = string(reason)
+}
+
+// addDataset records the decision for an unmanaged user dataset.
+func (p *gcPlan) addDataset(ds *zfs.Dataset, remove bool, reason gcReason) {
+	p.byKey["||"+ds.Name] = &GCDecision{State: ds.Name, Remove: remove, Reason: string(reason)}
+}
+
+// decisions returns all recorded decisions, grouped by machine and user, most recently used states first.
+func (p *gcPlan) decisions() []GCDecision {
+	r := make([]GCDecision, 0, len(p.byKey))
+	for _, d := range p.byKey {
+		r = append(r, *d)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Machine != r[j].Machine {
+			return r[i].Machine < r[j].Machine
+		}
+		if r[i].User != r[j].User {
+			return r[i].User < r[j].User
+		}
+		if !r[i].LastUsed.Equal(r[j].LastUsed) {
+			return r[i].LastUsed.After(r[j].LastUsed)
+		}
+		return r[i].State < r[j].State
+	})
+	return r
+}
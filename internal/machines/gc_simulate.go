@@ -0,0 +1,234 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+// GCSimulation is the preview of the history policy applied over time to current states, and to future states saved
+// on the current machine at a regular rate.
+type GCSimulation struct {
+	// Days is the retention of the current machine at the end of each simulated day.
+	Days []GCSimulatedDay
+	// Removals are the current states collected during the simulation, by order of removal.
+	Removals []GCSimulatedRemoval
+	// Buckets are the states of the current machine surviving in each bucket at the end of the simulation.
+	Buckets []GCSimulatedBucket
+}
+
+// GCSimulatedDay is the retention of the current machine at the end of a simulated day.
+type GCSimulatedDay struct {
+	Date time.Time
+	// Saved is the number of states saved during the day.
+	Saved int
+	// Removed is the number of states collected at the end of the day, on every machine.
+	Removed int
+	// System is the number of system states in history.
+	System int
+	// Users is the number of states in history of each user.
+	Users map[string]int `json:",omitempty"`
+}
+
+// GCSimulatedRemoval is a current state collected during the simulation.
+type GCSimulatedRemoval struct {
+	Machine string
+	// User is the owner of a user state. It's empty for system states.
+	User      string
+	State     string
+	LastUsed  time.Time
+	RemovedOn time.Time
+	Reason    string
+}
+
+// GCSimulatedBucket is the number of states of the current machine surviving in a bucket.
+type GCSimulatedBucket struct {
+	// User is the owner of the states. It's empty for system states.
+	User   string
+	Bucket string
+	States int
+}
+
+// simulatedTime is the clock of a simulation, advanced day by day.
+type simulatedTime struct {
+	now time.Time
+}
+
+func (t *simulatedTime) Now() time.Time {
+	return t.now
+}
+
+// SimulateGC previews the garbage collection over the next days, while rate states per day are saved on the current
+// machine. Each simulated day ends by a garbage collection run with the current history rules.
+// Space based rules and unmanaged user datasets aren't simulated. Nothing is saved or removed on the system.
+func (ms *Machines) SimulateGC(ctx context.Context, days int, rate float64) (GCSimulation, error) {
+	if !ms.current.isZsys() {
+		return GCSimulation{}, errors.New(i18n.G("Current machine isn't Zsys, nothing to simulate"))
+	}
+	if days <= 0 {
+		return GCSimulation{}, fmt.Errorf(i18n.G("number of days to simulate must be positive, got %d"), days)
+	}
+	if rate < 0 {
+		return GCSimulation{}, fmt.Errorf(i18n.G("rate of state saves can't be negative, got %v"), rate)
+	}
+
+	clock := &simulatedTime{now: ms.time.Now()}
+	sim := ms.simulation(clock)
+	log.Infof(ctx, i18n.G("Simulating garbage collection for %d days with %v state saves per day"), days, rate)
+
+	var r GCSimulation
+	// Current states, which are the only ones reported when removed.
+	current := make(map[string]bool)
+	for _, m := range sim.all {
+		for id := range m.History {
+			current[id] = true
+		}
+		for _, us := range m.AllUsersStates {
+			for id := range us {
+				current[id] = true
+			}
+		}
+	}
+
+	var interval time.Duration
+	if rate > 0 {
+		interval = time.Duration(float64(24*time.Hour) / rate)
+	}
+	nextSave := clock.now.Add(interval)
+	var nSaved int
+	var decisions []GCDecision
+	for day := 1; day <= days; day++ {
+		end := clock.now.Add(24 * time.Hour)
+		d := GCSimulatedDay{Date: end}
+		for interval > 0 && !nextSave.After(end) {
+			nSaved++
+			sim.saveSimulatedState(nextSave, nSaved)
+			d.Saved++
+			nextSave = nextSave.Add(interval)
+		}
+
+		clock.now = end
+		var err error
+		if decisions, err = sim.gc(ctx, false, true); err != nil {
+			return GCSimulation{}, fmt.Errorf(i18n.G("couldn't simulate garbage collection on %s: %v"), end.Format(timeFormat), err)
+		}
+
+		for _, dec := range decisions {
+			if !dec.Remove || dec.Machine == "" {
+				continue
+			}
+			d.Removed++
+			m := sim.all[dec.Machine]
+			if dec.User == "" {
+				delete(m.History, dec.State)
+			} else {
+				delete(m.AllUsersStates[dec.User], dec.State)
+			}
+			if current[dec.State] {
+				r.Removals = append(r.Removals, GCSimulatedRemoval{
+					Machine:   dec.Machine,
+					User:      dec.User,
+					State:     dec.State,
+					LastUsed:  dec.LastUsed,
+					RemovedOn: end,
+					Reason:    dec.Reason,
+				})
+			}
+		}
+
+		d.System, d.Users = sim.current.historyCount()
+		r.Days = append(r.Days, d)
+	}
+
+	// Buckets of the last run, in the order of the decisions.
+	index := make(map[string]int)
+	for _, dec := range decisions {
+		if dec.Remove || dec.Machine != sim.current.ID || dec.Bucket == "" {
+			continue
+		}
+		k := dec.User + "|" + dec.Bucket
+		i, ok := index[k]
+		if !ok {
+			i = len(r.Buckets)
+			index[k] = i
+			r.Buckets = append(r.Buckets, GCSimulatedBucket{User: dec.User, Bucket: dec.Bucket})
+		}
+		r.Buckets[i].States++
+	}
+
+	return r, nil
+}
+
+// simulation returns a copy of machines with its own history, running on clock.
+// Datasets are shared and must not be modified.
+func (ms *Machines) simulation(clock Nower) *Machines {
+	sim := &Machines{
+		all:                   make(map[string]*Machine),
+		cmdline:               ms.cmdline,
+		allSystemDatasets:     ms.allSystemDatasets,
+		allUsersDatasets:      ms.allUsersDatasets,
+		allPersistentDatasets: ms.allPersistentDatasets,
+		z:                     ms.z,
+		conf:                  ms.conf,
+		time:                  clock,
+	}
+	sim.conf.GC.TargetFreePoolSpace = 0
+	sim.conf.GC.MaxStateSize = 0
+
+	for id, m := range ms.all {
+		nm := *m
+		nm.History = make(map[string]*State)
+		for k, s := range m.History {
+			nm.History[k] = s
+		}
+		nm.AllUsersStates = make(map[string]map[string]*State)
+		for user, us := range m.AllUsersStates {
+			nm.AllUsersStates[user] = make(map[string]*State)
+			for k, s := range us {
+				nm.AllUsersStates[user][k] = s
+			}
+		}
+		sim.all[id] = &nm
+		if m == ms.current {
+			sim.current = &nm
+		}
+	}
+	return sim
+}
+
+// saveSimulatedState adds to the current machine history an automated system state, with the states of its
+// current users, as saved at t.
+func (ms *Machines) saveSimulatedState(t time.Time, n int) {
+	m := ms.current
+	name := fmt.Sprintf("%ssimulated_%d", automatedSnapshotPrefix, n)
+
+	s := &State{ID: m.ID + "@" + name, LastUsed: t, Users: make(map[string]*State)}
+	for user, us := range m.Users {
+		s.Users[user] = &State{ID: us.ID + "@" + name, LastUsed: t}
+		if m.AllUsersStates[user] == nil {
+			m.AllUsersStates[user] = make(map[string]*State)
+		}
+		m.AllUsersStates[user][s.Users[user].ID] = s.Users[user]
+	}
+	m.History[s.ID] = s
+}
+
+// historyCount returns the number of system states and of states of each user in history.
+func (m *Machine) historyCount() (system int, users map[string]int) {
+	for user, us := range m.AllUsersStates {
+		for _, s := range us {
+			if m.Users[user] == s {
+				continue
+			}
+			if users == nil {
+				users = make(map[string]int)
+			}
+			users[user]++
+		}
+	}
+	return len(m.History), users
+}
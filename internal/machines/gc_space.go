@@ -0,0 +1,248 @@
+package machines
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+// spaceCandidate is a state the space based GC can remove.
+type spaceCandidate struct {
+	*State
+	machine     *Machine
+	user        string
+	reclaimable uint64
+	pools       []string
+}
+
+// gcForSpace removes the oldest collectable states until each pool reaches the free space target,
+// as well as any collectable state freeing more than the maximum state size.
+// States kept by the history policy rules (keep last, clones, manual snapshots…) are never removed.
+// Pool free space is read again after each pass, the space freed by a state being only estimated.
+// In dry run mode, states in removed are ignored and the selected ones are only recorded in plan, after a single pass,
+// relying on this estimation.
+func (ms *Machines) gcForSpace(ctx context.Context, all, dryRun bool, removed map[string]bool, plan *gcPlan) error {
+	target := ms.conf.GC.TargetFreePoolSpace
+	maxStateSize := uint64(ms.conf.GC.MaxStateSize) * 1024 * 1024
+	if target <= 0 && maxStateSize == 0 {
+		return nil
+	}
+
+	log.Debug(ctx, i18n.G("Space based GC"))
+
+	// Bytes left to free on each pool below target
+	var needs map[string]uint64
+	var nRemoved int
+	var freed uint64
+	failedDeletions := make(map[string]bool)
+	for gcPassNum := 1; ; gcPassNum++ {
+		log.Debugf(ctx, "GC Space Pass #%d", gcPassNum)
+
+		// Reclaimable space is only a lower bound: check what previous pass really freed.
+		needs = ms.poolsNeeds(ctx, target)
+
+		var toRemove []spaceCandidate
+		for _, c := range ms.spaceGCCandidates(ctx, all, failedDeletions, removed) {
+			reason := removeReasonMaxStateSize
+			if maxStateSize > 0 && c.reclaimable > maxStateSize {
+				log.Infof(ctx, i18n.G("Selecting state %s to remove as it frees %s, more than %dMiB"), c.ID, humanSize(c.reclaimable), ms.conf.GC.MaxStateSize)
+			} else if p := firstPoolInNeed(c.pools, needs); p != "" {
+				log.Infof(ctx, i18n.G("Selecting state %s to remove to free space on pool %s"), c.ID, p)
+				reason = removeReasonFreeSpaceTarget
+			} else {
+				continue
+			}
+			consumeNeeds(needs, c.pools, c.reclaimable)
+			plan.add(c.machine, c.user, c.State, nil, true, reason)
+			toRemove = append(toRemove, c)
+		}
+
+		if len(toRemove) == 0 || dryRun {
+			break
+		}
+
+		for _, c := range toRemove {
+			if err := c.remove(ctx, ms, ""); err != nil {
+				log.Errorf(ctx, i18n.G("Couldn't fully destroy state %s: %v\nPutting it in keep list."), c.ID, err)
+				failedDeletions[c.ID] = true
+				plan.add(c.machine, c.user, c.State, nil, false, keepReasonDeletionFailed)
+				continue
+			}
+			log.RemotePrintf(ctx, i18n.G("Removed state %s, freeing %s\n"), c.ID, humanSize(c.reclaimable))
+			nRemoved++
+			freed += c.reclaimable
+		}
+
+		if err := ms.Refresh(ctx); err != nil {
+			return err
+		}
+	}
+
+	if nRemoved > 0 {
+		log.RemotePrintf(ctx, i18n.G("Space based garbage collection removed %d state(s), freeing %s\n"), nRemoved, humanSize(freed))
+	}
+	for p, need := range needs {
+		if need > 0 && !dryRun {
+			log.Warningf(ctx, i18n.G("Couldn't reach free space target on pool %s: no more state can be collected"), p)
+		}
+	}
+
+	return nil
+}
+
+// poolsNeeds returns the bytes left to free on each pool below the target percentage of free space.
+func (ms *Machines) poolsNeeds(ctx context.Context, target int) map[string]uint64 {
+	needs := make(map[string]uint64)
+	if target <= 0 {
+		return needs
+	}
+	for _, p := range ms.statesPools() {
+		free, err := ms.z.GetPoolFreeSpace(p)
+		if err != nil {
+			log.Warningf(ctx, i18n.G("Couldn't get free space of pool %s, ignoring: %v"), p, err)
+			continue
+		}
+		if free >= target {
+			continue
+		}
+		size, err := ms.z.GetPoolSize(p)
+		if err != nil {
+			log.Warningf(ctx, i18n.G("Couldn't get size of pool %s, ignoring: %v"), p, err)
+			continue
+		}
+		needs[p] = size / 100 * uint64(target-free)
+		log.Infof(ctx, i18n.G("Pool %s has %d%% of free space, below the %d%% target: %s to free"), p, free, target, humanSize(needs[p]))
+	}
+	return needs
+}
+
+// spaceGCCandidates returns all states which can be collected, following the history policy keep rules, oldest first.
+// States in removed are ignored.
+func (ms *Machines) spaceGCCandidates(ctx context.Context, all bool, failedDeletions, removed map[string]bool) []spaceCandidate {
+	byOrigin, snapshotsByDS := ms.datasetsRelations()
+	keepLast := ms.conf.History.System.KeepLast
+
+	var states []spaceCandidate
+	seen := make(map[*State]bool)
+	for _, k := range sortedMachineKeys(ms.all) {
+		m := ms.all[k]
+
+		if m.isZsys() {
+			var i int
+			for _, id := range m.HistoryIDs() {
+				s := m.History[id]
+				if removed[s.ID] {
+					continue
+				}
+				i++
+				if systemStateKeepReason(ctx, s, i-1, keepLast, all, failedDeletions, byOrigin, snapshotsByDS) != keepReasonNone {
+					continue
+				}
+				states = append(states, spaceCandidate{State: s, machine: m})
+			}
+		}
+
+		for user := range m.AllUsersStates {
+			var i int
+		nextUserState:
+			for _, uid := range m.UserStateIDs(user) {
+				s := m.AllUsersStates[user][uid]
+				if removed[s.ID] {
+					continue
+				}
+				// exclude "current" user state fom history
+				for _, us := range m.State.Users {
+					if us == s {
+						continue nextUserState
+					}
+				}
+				i++
+				// A user state can be attached to multiple system states
+				if seen[s] {
+					continue
+				}
+				seen[s] = true
+				if userStateKeepReason(ctx, m, s, i-1, ms.conf.History.ForUser(user).KeepLast, all, failedDeletions, byOrigin, removed) != keepReasonNone {
+					continue
+				}
+				states = append(states, spaceCandidate{State: s, machine: m, user: user})
+			}
+		}
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		if states[i].LastUsed.Equal(states[j].LastUsed) {
+			return states[i].ID < states[j].ID
+		}
+		return states[i].LastUsed.Before(states[j].LastUsed)
+	})
+
+	for i := range states {
+		states[i].reclaimable = ms.StateSpace(ctx, states[i].State).Reclaimable
+		states[i].pools = statePools(states[i].State)
+	}
+	return states
+}
+
+// statesPools returns the sorted list of managed pools having at least one state dataset.
+func (ms *Machines) statesPools() []string {
+	pools := make(map[string]bool)
+	for _, d := range append(append(ms.allSystemDatasets, ms.allUsersDatasets...), ms.unmanagedDatasets...) {
+		if p := poolName(d.Name); ms.isManagedPool(p) {
+			pools[p] = true
+		}
+	}
+	var r []string
+	for p := range pools {
+		r = append(r, p)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// statePools returns the sorted list of pools s datasets are on.
+func statePools(s *State) []string {
+	pools := make(map[string]bool)
+	for _, d := range s.getDatasets() {
+		pools[poolName(d.Name)] = true
+	}
+	var r []string
+	for p := range pools {
+		r = append(r, p)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// poolName returns the pool of a dataset or snapshot name.
+func poolName(name string) string {
+	return strings.SplitN(strings.SplitN(name, "@", 2)[0], "/", 2)[0]
+}
+
+// firstPoolInNeed returns the first pool of pools still needing some space to be freed, or an empty string.
+func firstPoolInNeed(pools []string, needs map[string]uint64) string {
+	for _, p := range pools {
+		if needs[p] > 0 {
+			return p
+		}
+	}
+	return ""
+}
+
+// consumeNeeds decreases needs of each pool of pools by freed bytes.
+func consumeNeeds(needs map[string]uint64, pools []string, freed uint64) {
+	for _, p := range pools {
+		need, ok := needs[p]
+		if !ok {
+			continue
+		}
+		if freed >= need {
+			needs[p] = 0
+			continue
+		}
+		needs[p] = need - freed
+	}
+}
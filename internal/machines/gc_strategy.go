@@ -0,0 +1,165 @@
+package machines
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+// gcStrategy is a retention model of the history policy.
+// States are sorted in buckets, each of them keeping a number of states. States with keep constraints are always
+// kept and count in their bucket samples.
+type gcStrategy interface {
+	// buckets returns the buckets in which states are sorted, from the most recent to the oldest, given current time.
+	buckets(ctx context.Context, now time.Time) []bucket
+	// selectStatesToRemove returns the states of a bucket exceeding its samples.
+	selectStatesToRemove(ctx context.Context, b bucket, states []stateWithKeep) []*State
+}
+
+// newGCStrategy returns the strategy selected by the history rules.
+// Rules are validated when loading the configuration: any unknown strategy is the default buckets one.
+func newGCStrategy(rules config.HistoryRules) gcStrategy {
+	switch rules.Strategy {
+	case config.StrategyCalendar:
+		return calendarStrategy{rules: rules}
+	case config.StrategyMaxAge:
+		return maxAgeStrategy{rules: rules}
+	case config.StrategyMaxCount:
+		return maxCountStrategy{rules: rules}
+	}
+	return bucketsStrategy{rules: rules}
+}
+
+// bucketsStrategy keeps states evenly spread in buckets sliding with current time.
+type bucketsStrategy struct {
+	rules config.HistoryRules
+}
+
+func (s bucketsStrategy) buckets(ctx context.Context, now time.Time) []bucket {
+	return computeBuckets(ctx, now, s.rules)
+}
+
+func (bucketsStrategy) selectStatesToRemove(ctx context.Context, b bucket, states []stateWithKeep) []*State {
+	return selectStatesToRemove(ctx, b.samples, states)
+}
+
+// calendarStrategy keeps the newest state of each local day, week and month, after the most recent days where
+// all states are kept.
+type calendarStrategy struct {
+	rules config.HistoryRules
+}
+
+func (s calendarStrategy) buckets(ctx context.Context, now time.Time) (buckets []bucket) {
+	log.Debugf(ctx, "calculating calendar buckets")
+	end := startOfDay(now).AddDate(0, 0, -int(s.rules.GCStartAfter))
+	buckets = append(buckets, bucket{start: end, end: now, samples: -1})
+
+	for _, period := range []struct {
+		n     int
+		start func(time.Time) time.Time
+	}{
+		{s.rules.Calendar.Days, startOfDay},
+		{s.rules.Calendar.Weeks, startOfWeek},
+		{s.rules.Calendar.Months, startOfMonth},
+	} {
+		// A period overlapping the previous ones only holds its remaining part.
+		for i := 0; i < period.n; i++ {
+			start := period.start(end.Add(-time.Nanosecond))
+			buckets = append(buckets, bucket{start: start, end: end, samples: 1})
+			end = start
+		}
+	}
+
+	buckets = append(buckets, bucket{start: time.Time{}, end: end, samples: 0})
+	for _, b := range buckets {
+		log.Debugf(ctx, "  -  %s", b)
+	}
+	return buckets
+}
+
+func (calendarStrategy) selectStatesToRemove(ctx context.Context, b bucket, states []stateWithKeep) []*State {
+	return selectOldestStatesToRemove(ctx, b.samples, states)
+}
+
+// maxAgeStrategy removes states older than a number of days.
+type maxAgeStrategy struct {
+	rules config.HistoryRules
+}
+
+func (s maxAgeStrategy) buckets(ctx context.Context, now time.Time) []bucket {
+	limit := now.AddDate(0, 0, -int(s.rules.MaxAge))
+	log.Debugf(ctx, "removing states older than %s", limit.Format(timeFormat))
+	return []bucket{
+		{start: limit, end: now, samples: -1},
+		{start: time.Time{}, end: limit, samples: 0},
+	}
+}
+
+func (maxAgeStrategy) selectStatesToRemove(ctx context.Context, b bucket, states []stateWithKeep) []*State {
+	return selectOldestStatesToRemove(ctx, b.samples, states)
+}
+
+// maxCountStrategy keeps a number of the most recent states.
+type maxCountStrategy struct {
+	rules config.HistoryRules
+}
+
+func (s maxCountStrategy) buckets(ctx context.Context, now time.Time) []bucket {
+	log.Debugf(ctx, "keeping the %d most recent states", s.rules.MaxCount)
+	return []bucket{{start: time.Time{}, end: now, samples: s.rules.MaxCount}}
+}
+
+func (maxCountStrategy) selectStatesToRemove(ctx context.Context, b bucket, states []stateWithKeep) []*State {
+	return selectOldestStatesToRemove(ctx, b.samples, states)
+}
+
+// selectOldestStatesToRemove keeps the most recent states of a bucket, up to samples, and returns the others.
+// States to keep count in samples.
+func selectOldestStatesToRemove(ctx context.Context, samples int, states []stateWithKeep) (statesToRemove []*State) {
+	log.Debug(ctx, "selecting oldest states to remove")
+
+	sorted := make([]stateWithKeep, len(states))
+	copy(sorted, states)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].LastUsed.Equal(sorted[j].LastUsed) {
+			return sorted[i].ID < sorted[j].ID
+		}
+		return sorted[i].LastUsed.After(sorted[j].LastUsed)
+	})
+
+	kept := 0
+	for _, s := range sorted {
+		if s.keep == keepYes {
+			kept++
+		}
+	}
+	for _, s := range sorted {
+		if s.keep == keepYes {
+			continue
+		}
+		if kept < samples {
+			kept++
+			continue
+		}
+		statesToRemove = append(statesToRemove, s.State)
+	}
+	return statesToRemove
+}
+
+// startOfDay returns the local midnight starting the day of t.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the local midnight starting the week, on Monday, of t.
+func startOfWeek(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
+
+// startOfMonth returns the local midnight starting the month of t.
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -178,6 +178,24 @@ func sortedStateKeys(m map[string]*State) []string {
 	return keys
 }
 
+// sortedStateKeysByLastUsed returns state keys, most recently used first.
+func sortedStateKeysByLastUsed(m map[string]*State) []string {
+	timeToKey := make(map[string]string)
+	var tKeys []string
+	for k, s := range m {
+		tk := fmt.Sprintf("%010d_%s", s.LastUsed.Unix(), k)
+		timeToKey[tk] = k
+		tKeys = append(tKeys, tk)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(tKeys)))
+
+	keys := make([]string, 0, len(tKeys))
+	for _, tk := range tKeys {
+		keys = append(keys, timeToKey[tk])
+	}
+	return keys
+}
+
 // splitSnapshotName return base and trailing names
 func splitSnapshotName(name string) (string, string) {
 	i := strings.LastIndex(name, "@")
@@ -0,0 +1,112 @@
+package machines
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// holdTagPrefix starts the tag of holds placed by zsys, followed by the request id.
+const holdTagPrefix = "zsys-"
+
+// holdTag returns the tag of the holds zsys places on snapshots while handling the request attached to ctx.
+// A random id is used if there is no request attached, like on boot.
+func (ms *Machines) holdTag(ctx context.Context) string {
+	id, err := log.IDFromContext(ctx)
+	if err != nil {
+		return holdTagPrefix + ms.z.GenerateID(8)
+	}
+	// Only keep the request id, without the requester id.
+	return holdTagPrefix + id[strings.LastIndex(id, ":")+1:]
+}
+
+// holdSnapshots places a zsys hold on every snapshot of datasets, so that no one can destroy them until the returned
+// function releases them. Snapshots already released or destroyed are ignored on release.
+func (ms *Machines) holdSnapshots(ctx context.Context, datasets []*zfs.Dataset) (release func(), err error) {
+	tag := ms.holdTag(ctx)
+	nt := ms.z.NewNoTransaction(ctx)
+
+	var held []string
+	release = func() {
+		for _, n := range held {
+			if err := nt.Release(n, tag); err != nil {
+				log.Warningf(ctx, i18n.G("Couldn't release hold %q on %s: %v"), tag, n, err)
+			}
+		}
+	}
+
+	for _, d := range datasets {
+		if !d.IsSnapshot {
+			continue
+		}
+		if err := nt.Hold(d.Name, tag); err != nil {
+			release()
+			return nil, err
+		}
+		held = append(held, d.Name)
+	}
+	log.Debugf(ctx, i18n.G("Holding %d snapshots with %q"), len(held), tag)
+	return release, nil
+}
+
+// ReleaseStaleHolds releases every hold placed by zsys on snapshots. It must only be called when no request is
+// running, like on daemon start, as those are then left over by an interrupted request.
+func (ms *Machines) ReleaseStaleHolds(ctx context.Context) {
+	nt := ms.z.NewNoTransaction(ctx)
+
+	var released bool
+	for _, d := range ms.z.Datasets() {
+		if !d.IsSnapshot {
+			continue
+		}
+		// Release updates d.Holds
+		for _, tag := range append([]string(nil), d.Holds...) {
+			if !strings.HasPrefix(tag, holdTagPrefix) {
+				continue
+			}
+			log.Infof(ctx, i18n.G("Releasing stale hold %q on %s"), tag, d.Name)
+			if err := nt.Release(d.Name, tag); err != nil {
+				log.Warningf(ctx, i18n.G("Couldn't release hold %q on %s: %v"), tag, d.Name, err)
+				continue
+			}
+			released = true
+		}
+	}
+
+	if released {
+		ms.refresh(ctx)
+	}
+}
+
+// holds returns the sorted tags of all holds on the state snapshots.
+func (s State) holds() []string {
+	return s.holdsFiltered(true)
+}
+
+// externalHolds returns the sorted tags of holds on the state snapshots which weren't placed by zsys.
+func (s State) externalHolds() []string {
+	return s.holdsFiltered(false)
+}
+
+func (s State) holdsFiltered(withZsys bool) []string {
+	tags := make(map[string]bool)
+	for _, d := range s.getDatasets() {
+		for _, t := range d.Holds {
+			if !withZsys && strings.HasPrefix(t, holdTagPrefix) {
+				continue
+			}
+			tags[t] = true
+		}
+	}
+
+	var r []string
+	for t := range tags {
+		r = append(r, t)
+	}
+	sort.Strings(r)
+	return r
+}
@@ -0,0 +1,23 @@
+package machines
+
+import (
+	"sort"
+
+	"github.com/ubuntu/zsys/internal/hooks"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// hooksRunner returns the hooks runner matching current configuration, so that a reload is taken into account.
+func (ms *Machines) hooksRunner() hooks.Runner {
+	return hooks.New(ms.conf)
+}
+
+// hooksEnv returns the hooks environment for the state id of user, made of datasets, sorted by name.
+func hooksEnv(id, user string, datasets []*zfs.Dataset) hooks.Env {
+	env := hooks.Env{State: id, User: user}
+	for _, d := range datasets {
+		env.Datasets = append(env.Datasets, d.Name)
+	}
+	sort.Strings(env.Datasets)
+	return env
+}
@@ -506,6 +506,54 @@ func TestRemoveInternal(t *testing.T) {
 	}
 }
 
+func TestHumanSize(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		size uint64
+		want string
+	}{
+		"Zero":              {size: 0, want: "0B"},
+		"Bytes":             {size: 1023, want: "1023B"},
+		"Exact kibibyte":    {size: 1024, want: "1K"},
+		"Fractional":        {size: 1536, want: "1.5K"},
+		"Rounded":           {size: 1234567, want: "1.18M"},
+		"Gibibytes":         {size: 5 * 1024 * 1024 * 1024, want: "5G"},
+		"Largest unit caps": {size: 1 << 63, want: "8E"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, humanSize(tc.size), "Human readable size should match")
+		})
+	}
+}
+
+func TestFormatUserUsage(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		referenced  uint64
+		quota       uint64
+		reservation uint64
+		want        string
+	}{
+		"No quota nor reservation": {referenced: 1024, want: ""},
+		"Quota":                    {referenced: 256 * 1024 * 1024, quota: 1024 * 1024 * 1024, want: "256M used of 1G quota (25%)"},
+		"Reservation":              {referenced: 1024, reservation: 512 * 1024 * 1024, want: "1K used, 512M reserved"},
+		"Quota and reservation":    {referenced: 1024 * 1024 * 1024, quota: 1024 * 1024 * 1024, reservation: 1024, want: "1G used of 1G quota (100%), 1K reserved"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			d := &zfs.Dataset{DatasetProp: zfs.DatasetProp{Referenced: tc.referenced, Quota: tc.quota, Reservation: tc.reservation}}
+			assert.Equal(t, tc.want, formatUserUsage(d), "User usage should match")
+		})
+	}
+}
+
 func TestSelectStatesToRemove(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
@@ -564,6 +612,94 @@ func TestSelectStatesToRemove(t *testing.T) {
 	}
 }
 
+func TestSelectOldestStatesToRemove(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		samples       int     // Number of slots in the bucket
+		statesToKeep  []int64 // List of seconds from startOfTime
+		statesToPlace []int64 // List of seconds from startOfTime
+
+		wantStates []string
+	}{
+		"keep all - bucket has enough capacity": {samples: 5, statesToKeep: []int64{1, 3}, statesToPlace: []int64{2}, wantStates: nil},
+		"keep none - bucket is already full":    {samples: 3, statesToKeep: []int64{1, 3, 5}, statesToPlace: []int64{2, 4, 6}, wantStates: []string{"p2", "p1", "p0"}},
+		"do not remove keep states":             {samples: 3, statesToKeep: []int64{1, 3, 5, 7}, wantStates: nil},
+		"remove oldest":                         {samples: 4, statesToKeep: []int64{1, 3}, statesToPlace: []int64{2, 4, 6}, wantStates: []string{"p0"}},
+		"remove all for empty bucket":           {samples: 0, statesToPlace: []int64{2, 4}, wantStates: []string{"p1", "p0"}},
+		"same timestamp - keep first id":        {samples: 1, statesToPlace: []int64{2, 2}, wantStates: []string{"p1"}},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var states []stateWithKeep
+			for i, s := range tc.statesToKeep {
+				s := State{ID: "k" + strconv.Itoa(i), LastUsed: time.Unix(s, 0)}
+				states = append(states, stateWithKeep{State: &s, keep: keepYes})
+			}
+			for i, s := range tc.statesToPlace {
+				s := State{ID: "p" + strconv.Itoa(i), LastUsed: time.Unix(s, 0)}
+				states = append(states, stateWithKeep{State: &s, keep: keepUnknown})
+			}
+
+			got := selectOldestStatesToRemove(context.Background(), tc.samples, states)
+			assertStatesToKeepMatch(t, tc.wantStates, got)
+		})
+	}
+}
+
+func TestStrategyBuckets(t *testing.T) {
+	t.Parallel()
+	// Wednesday
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time { return time.Date(2019, month, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := map[string]struct {
+		rules config.HistoryRules
+
+		want []bucket
+	}{
+		"Calendar aligns days, weeks and months": {
+			rules: config.HistoryRules{Strategy: config.StrategyCalendar, GCStartAfter: 1, Calendar: config.CalendarRules{Days: 2, Weeks: 2, Months: 2}},
+			want: []bucket{
+				{start: day(12, 31), end: now, samples: -1},
+				{start: day(12, 30), end: day(12, 31), samples: 1},
+				{start: day(12, 29), end: day(12, 30), samples: 1},
+				{start: day(12, 23), end: day(12, 29), samples: 1},
+				{start: day(12, 16), end: day(12, 23), samples: 1},
+				{start: day(12, 1), end: day(12, 16), samples: 1},
+				{start: day(11, 1), end: day(12, 1), samples: 1},
+				{start: time.Time{}, end: day(11, 1), samples: 0},
+			}},
+		"Calendar without periods keeps recent states only": {
+			rules: config.HistoryRules{Strategy: config.StrategyCalendar},
+			want: []bucket{
+				{start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), end: now, samples: -1},
+				{start: time.Time{}, end: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), samples: 0},
+			}},
+		"Max age": {
+			rules: config.HistoryRules{Strategy: config.StrategyMaxAge, MaxAge: 7},
+			want: []bucket{
+				{start: time.Date(2019, 12, 25, 12, 0, 0, 0, time.UTC), end: now, samples: -1},
+				{start: time.Time{}, end: time.Date(2019, 12, 25, 12, 0, 0, 0, time.UTC), samples: 0},
+			}},
+		"Max count": {
+			rules: config.HistoryRules{Strategy: config.StrategyMaxCount, MaxCount: 5},
+			want:  []bucket{{start: time.Time{}, end: now, samples: 5}},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := newGCStrategy(tc.rules).buckets(context.Background(), now)
+			assert.Equal(t, tc.want, got, "Buckets should match")
+		})
+	}
+}
+
 func assertStatesToKeepMatch(t *testing.T, want []string, got []*State) {
 	var gotIDs []string
 
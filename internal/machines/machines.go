@@ -13,6 +13,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/ubuntu/zsys/internal/bootloader"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
@@ -33,9 +34,12 @@ type Machines struct {
 	// cantmount noauto or off datasets, which are not system, users or persistent
 	unmanagedDatasets []*zfs.Dataset
 
-	z    *zfs.Zfs
-	conf config.ZConfig
-	time Nower
+	z       *zfs.Zfs
+	conf    config.ZConfig
+	time    Nower
+	mounter Mounter
+
+	statesObserver func([]StateChange)
 }
 
 // Machine is a group of Main and its History children states
@@ -69,6 +73,7 @@ type State struct {
 
 const (
 	userdatasetsContainerName = "/userdata/"
+	rootdatasetsContainerName = "/root/"
 	bootdatasetsContainerName = "/boot/"
 	bootfsdatasetsSeparator   = ","
 )
@@ -93,9 +98,11 @@ func WithConfig(path string) func(o *options) error {
 }
 
 type options struct {
-	configPath string
-	libzfs     libzfs.Interface
-	time       Nower
+	configPath     string
+	libzfs         libzfs.Interface
+	time           Nower
+	mounter        Mounter
+	statesObserver func([]StateChange)
 }
 
 type option func(*options) error
@@ -118,6 +125,7 @@ func New(ctx context.Context, cmdline string, opts ...option) (Machines, error)
 		configPath: config.DefaultPath,
 		libzfs:     &libzfs.Adapter{},
 		time:       timeAdapter{},
+		mounter:    mounterAdapter{},
 	}
 	for _, o := range opts {
 		if err := o(&args); err != nil {
@@ -132,7 +140,7 @@ func New(ctx context.Context, cmdline string, opts ...option) (Machines, error)
 
 	conf, err := config.Load(ctx, args.configPath)
 	if err != nil {
-		return Machines{}, fmt.Errorf(i18n.G("couldn't load zsys configuration"), err)
+		return Machines{}, fmt.Errorf(i18n.G("couldn't load zsys configuration: ")+config.ErrorFormat, err)
 	}
 
 	machines := Machines{
@@ -141,8 +149,11 @@ func New(ctx context.Context, cmdline string, opts ...option) (Machines, error)
 		z:       z,
 		conf:    conf,
 		time:    args.time,
+		mounter: args.mounter,
 	}
 	machines.refresh(ctx)
+	// Only observe changes after the initial scan.
+	machines.statesObserver = args.statesObserver
 	return machines, nil
 }
 
@@ -156,17 +167,45 @@ func (ms *Machines) Refresh(ctx context.Context) error {
 	return nil
 }
 
+// DatasetsProperties returns the properties of every dataset and snapshot, by name.
+// Space usage, which changes on its own while datasets are in use, is left out.
+func (ms *Machines) DatasetsProperties() map[string]zfs.DatasetProp {
+	r := make(map[string]zfs.DatasetProp)
+	for _, d := range ms.z.Datasets() {
+		p := d.DatasetProp
+		p.Used, p.Referenced, p.UsedBySnapshots, p.Written = 0, 0, 0, 0
+		r[d.Name] = p
+	}
+	return r
+}
+
 // refresh reloads the list of machines, based on already loaded zfs datasets state
 func (ms *Machines) refresh(ctx context.Context) {
+	previousStates := ms.currentStates()
+
 	machines := Machines{
 		all:     make(map[string]*Machine),
 		cmdline: ms.cmdline,
 		z:       ms.z,
 		conf:    ms.conf,
 		time:    ms.time,
+		mounter: ms.mounter,
+
+		statesObserver: ms.statesObserver,
 	}
 
-	datasets := machines.z.Datasets()
+	// Exported states are replicated with their original properties: ignore them.
+	backups := make(map[string]bool)
+	var datasets []*zfs.Dataset
+	for _, d := range machines.z.Datasets() {
+		if d.Backup {
+			backups[d.Name] = true
+		}
+		if isBackup(d, backups) {
+			continue
+		}
+		datasets = append(datasets, d)
+	}
 
 	// Sort datasets so that children datasets are after their parents.
 	sortedDataset := sortedDataset(datasets)
@@ -400,6 +439,12 @@ func (ms *Machines) refresh(ctx context.Context) {
 	machines.current = m
 
 	*ms = machines
+	if ms.statesObserver != nil {
+		if changes := statesChanges(previousStates, ms.currentStates()); len(changes) > 0 {
+			ms.statesObserver(changes)
+		}
+	}
+
 	l, err := log.LevelFromContext(ctx)
 	if (err == nil && l == log.DebugLevel) || // remote connected and send logs
 		log.GetLevel() == log.DebugLevel { // local log output
@@ -418,6 +463,13 @@ func (ms *Machines) populate(ctx context.Context, allDatasets []*zfs.Dataset, or
 	for _, d := range allDatasets {
 		// we are taking the d address. Ensure we have a local variable that isn’t going to be reused
 		d := d
+		// System datasets on pools which aren't configured for them are left alone.
+		if !inPools(d.Name, ms.conf.Pools.Root) && isSystemDataset(*d) {
+			log.Debugf(ctx, i18n.G("ignoring %q: system dataset on a pool which isn't configured for system datasets"), d.Name)
+			unmanagedDatasets = append(unmanagedDatasets, d)
+			continue
+		}
+
 		// Main active system dataset building up a machine
 		m := newMachineFromDataset(d, origins[d.Name])
 		if m != nil {
@@ -434,7 +486,12 @@ func (ms *Machines) populate(ctx context.Context, allDatasets []*zfs.Dataset, or
 
 		// Extract boot datasets if any. We can't attach them directly with machines as if they are on another pool:
 		// the machine will not necessiraly loaded yet.
-		if strings.Contains(strings.ToLower(d.Name), bootdatasetsContainerName) && strings.HasPrefix(d.Mountpoint, "/boot") {
+		if isBootDataset(d.Name) && strings.HasPrefix(d.Mountpoint, "/boot") {
+			if !inPools(d.Name, ms.conf.Pools.Boot) {
+				log.Debugf(ctx, i18n.G("ignoring %q: boot dataset on a pool which isn't configured for boot datasets"), d.Name)
+				unmanagedDatasets = append(unmanagedDatasets, d)
+				continue
+			}
 			boots = append(boots, d)
 			continue
 		}
@@ -442,6 +499,11 @@ func (ms *Machines) populate(ctx context.Context, allDatasets []*zfs.Dataset, or
 		// Extract zsys user datasets if any. We can't attach them directly with machines as if they are on another pool,
 		// the machine is not necessiraly loaded yet.
 		if isUserDataset(d.Name) {
+			if !inPools(d.Name, ms.conf.Pools.UserData) {
+				log.Debugf(ctx, i18n.G("ignoring %q: user dataset on a pool which isn't configured for user datasets"), d.Name)
+				unmanagedDatasets = append(unmanagedDatasets, d)
+				continue
+			}
 			userdatas = append(userdatas, d)
 			continue
 		}
@@ -634,6 +696,49 @@ func (ms *Machines) CurrentIsZsys() bool {
 	return ms.current.isZsys()
 }
 
+// Config returns the configuration in use.
+func (ms *Machines) Config() config.ZConfig {
+	return ms.conf
+}
+
+// BootStates returns all system states of zsys machines the bootloader can boot on.
+// States of the current machine are first, each machine main state being followed by its history from the most recent.
+func (ms *Machines) BootStates() []bootloader.State {
+	var machines []*Machine
+	if ms.current.isZsys() {
+		machines = append(machines, ms.current)
+	}
+	for _, k := range sortedMachineKeys(ms.all) {
+		m := ms.all[k]
+		if m == ms.current || !m.isZsys() {
+			continue
+		}
+		machines = append(machines, m)
+	}
+
+	var states []bootloader.State
+	for _, m := range machines {
+		history := make([]*State, 0, len(m.History))
+		for _, k := range sortedStateKeys(m.History) {
+			history = append(history, m.History[k])
+		}
+		sort.SliceStable(history, func(i, j int) bool { return history[i].LastUsed.After(history[j].LastUsed) })
+
+		for _, s := range append([]*State{&m.State}, history...) {
+			var kernel string
+			if ds := s.Datasets[s.ID]; len(ds) > 0 {
+				kernel = ds[0].LastBootedKernel
+			}
+			states = append(states, bootloader.State{
+				ID:       s.ID,
+				Kernel:   kernel,
+				LastUsed: s.LastUsed,
+			})
+		}
+	}
+	return states
+}
+
 // isZsys returns if the machine is a zsys one.
 func (m *Machine) isZsys() bool {
 	if m == nil {
@@ -689,15 +794,59 @@ func (ms Machines) GetMachine(ID string) (*Machine, error) {
 	return machines[0], nil
 }
 
+// SortedMachines returns all machines, the current one first, then the others sorted by ID.
+func (ms Machines) SortedMachines() []*Machine {
+	var machines []*Machine
+	if ms.current != nil {
+		machines = append(machines, ms.current)
+	}
+	for _, k := range sortedMachineKeys(ms.all) {
+		if ms.current != nil && k == ms.current.ID {
+			continue
+		}
+		machines = append(machines, ms.all[k])
+	}
+	return machines
+}
+
+// IsCurrent returns if m is the machine we are currently running on.
+func (ms Machines) IsCurrent(m *Machine) bool {
+	return ms.current != nil && m != nil && ms.current.ID == m.ID
+}
+
+// HistoryIDs returns the IDs of the machine history states, most recently used first.
+func (m Machine) HistoryIDs() []string {
+	return sortedStateKeysByLastUsed(m.History)
+}
+
+// UserStateIDs returns the unique IDs of all user states for user on this machine, most recently used first.
+func (m Machine) UserStateIDs(user string) []string {
+	return sortedStateKeysByLastUsed(m.AllUsersStates[user])
+}
+
 // Info returns detailed machine informations.
-func (m Machine) Info(full bool) (string, error) {
+// History states are ordered by sortBy. Full informations include space accounting for each state.
+func (ms *Machines) Info(ctx context.Context, m *Machine, full bool, sortBy string) (string, error) {
+	historyIDs, err := ms.SortedHistoryIDs(ctx, m, sortBy)
+	if err != nil {
+		return "", err
+	}
+
+	spaceOf := func(s *State) *Space {
+		if !full {
+			return nil
+		}
+		space := ms.StateSpace(ctx, s)
+		return &space
+	}
+
 	var out bytes.Buffer
 	w := tabwriter.NewWriter(&out, 0, 0, 1, ' ', 0)
 	fmt.Fprintf(w, i18n.G("Name:\t%s\n"), m.ID)
 	fmt.Fprintf(w, i18n.G("ZSys:\t%t\n"), m.isZsys())
 
 	// Main machine state
-	m.toWriter(w, false, full)
+	m.toWriter(w, false, full, spaceOf(&m.State))
 
 	if full {
 		if len(m.PersistentDatasets) == 0 {
@@ -714,40 +863,36 @@ func (m Machine) Info(full bool) (string, error) {
 	if len(m.History) > 0 {
 		fmt.Fprintf(w, i18n.G("History:\t\n"))
 	}
-	timeToState := make(map[string]*State)
-	for id, s := range m.History {
-		k := fmt.Sprintf("%010d_%s", s.LastUsed.Unix(), id)
-		timeToState[k] = s
-	}
-	var keys []string
-	for k := range timeToState {
-		keys = append(keys, k)
-	}
-	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
-
-	for _, k := range keys {
-		timeToState[k].toWriter(w, true, full)
+	for _, id := range historyIDs {
+		m.History[id].toWriter(w, true, full, spaceOf(m.History[id]))
 	}
 
 	// Users
-	keys = nil
-	for k := range m.AllUsersStates {
-		keys = append(keys, k)
+	var users []string
+	for u := range m.AllUsersStates {
+		users = append(users, u)
 	}
-	sort.Sort(sort.StringSlice(keys))
+	sort.Strings(users)
 
 	fmt.Fprintf(w, i18n.G("Users:\n"))
 
-	for _, user := range keys {
+	for _, user := range users {
 		fmt.Fprintf(w, i18n.G("  - Name:\t%s\n"), user)
+		if us, ok := m.State.Users[user]; ok {
+			if usage := formatUserUsage(us.Datasets[us.ID][0]); usage != "" {
+				fmt.Fprintf(w, i18n.G("    Usage:\t%s\n"), usage)
+			}
+		}
 
 		if len(m.AllUsersStates[user]) > 1 {
 			fmt.Fprintf(w, i18n.G("    History:\t\n"))
 		}
 
-		timeToState := make(map[string]*State)
+		// We can’t use s.ID here because some user states can be duplicated (user state attached to 2 system states)
+		// and we want to display the unique generated id to the user as it’s what should be used in RemoveState()
 	nextUserState:
-		for id, s := range m.AllUsersStates[user] {
+		for _, uid := range m.UserStateIDs(user) {
+			s := m.AllUsersStates[user][uid]
 			// exclude "current" user state fom history
 			for _, us := range m.State.Users {
 				if us == s {
@@ -755,21 +900,13 @@ func (m Machine) Info(full bool) (string, error) {
 				}
 			}
 
-			k := fmt.Sprintf("%010d_%s", s.LastUsed.Unix(), id)
-			timeToState[k] = s
-		}
-		var keys []string
-		for k := range timeToState {
-			keys = append(keys, k)
-		}
-		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
-
-		for _, k := range keys {
-			s := timeToState[k]
-
-			// We can’t use s.ID here because some user states can be duplicated (user state attached to 2 system states)
-			// and we want to display the unique generated id to the user as it’s what should be used in RemoveState()
-			uid := strings.SplitN(k, "_", 2)[1]
+			var pinned string
+			if s.IsPinned() {
+				pinned = i18n.G(" [pinned]")
+			}
+			if d := s.Description(); d != "" {
+				pinned += fmt.Sprintf(" %q", d)
+			}
 			if full {
 				var ud []string
 				for _, ds := range s.Datasets {
@@ -777,10 +914,10 @@ func (m Machine) Info(full bool) (string, error) {
 						ud = append(ud, d.Name)
 					}
 				}
-				fmt.Fprintf(w, i18n.G("     - %s (%s): %s\n"), uid, s.LastUsed.Format("2006-01-02 15:04:05"), strings.Join(ud, ", "))
+				fmt.Fprintf(w, i18n.G("     - %s (%s)%s: %s\n"), uid, s.LastUsed.Format("2006-01-02 15:04:05"), pinned, strings.Join(ud, ", "))
 				continue
 			}
-			fmt.Fprintf(w, i18n.G("     - %s (%s)\n"), uid, s.LastUsed.Format("2006-01-02 15:04:05"))
+			fmt.Fprintf(w, i18n.G("     - %s (%s)%s\n"), uid, s.LastUsed.Format("2006-01-02 15:04:05"), pinned)
 		}
 	}
 	if err := w.Flush(); err != nil {
@@ -805,8 +942,8 @@ func sortedDatasetNames(datasets map[string][]*zfs.Dataset) (dNames []string) {
 	return dNames
 }
 
-// toWriter forwards dataset state to a writer
-func (s State) toWriter(w io.Writer, isHistory, full bool) {
+// toWriter forwards dataset state to a writer. space is printed if not nil.
+func (s State) toWriter(w io.Writer, isHistory, full bool, space *Space) {
 	var prefix string
 	if isHistory {
 		fmt.Fprintf(w, i18n.G("  - Name:\t%s\n"), s.ID)
@@ -824,9 +961,23 @@ func (s State) toWriter(w io.Writer, isHistory, full bool) {
 	} else {
 		fmt.Fprintf(w, i18n.G("%sCreated on:\t%s\n"), prefix, lu)
 	}
+	if s.IsPinned() {
+		fmt.Fprintf(w, i18n.G("%sPinned:\tyes\n"), prefix)
+	}
+	if d := s.Description(); d != "" {
+		fmt.Fprintf(w, i18n.G("%sDescription:\t%s\n"), prefix, d)
+	}
+	if labels := s.Labels(); len(labels) > 0 {
+		fmt.Fprintf(w, i18n.G("%sLabels:\t%s\n"), prefix, formatLabels(labels))
+	}
 
 	if full {
 		fmt.Fprintf(w, i18n.G("%sLast Booted Kernel:\t%s\n"), prefix, s.Datasets[s.ID][0].LastBootedKernel)
+		if space != nil {
+			fmt.Fprintf(w, i18n.G("%sSpace:\tused %s, referenced %s, unique %s, written %s, reclaimable %s\n"), prefix,
+				humanSize(space.Used), humanSize(space.Referenced), humanSize(space.Unique), humanSize(space.Written), humanSize(space.Reclaimable))
+		}
+		fmt.Fprintf(w, i18n.G("%sPools:\t%s\n"), prefix, formatPools(s.Pools()))
 		fmt.Fprintf(w, i18n.G("%sSystem Datasets:\n"), prefix)
 
 		for _, n := range sortedDatasetNames(s.Datasets) {
@@ -853,28 +1004,9 @@ func (ms Machines) List() (string, error) {
 	fmt.Fprint(w, i18n.G("ID\tZSys\tLast Used\n"))
 	fmt.Fprint(w, i18n.G("--\t----\t---------\n"))
 
-	var keys, presentationOrder []string
-	for k := range ms.all {
-		keys = append(keys, k)
-	}
-	sort.Sort(sort.StringSlice(keys))
-	var currentID string
-	if ms.current != nil {
-		currentID = ms.current.ID
-		presentationOrder = []string{currentID}
-	}
-
-	for _, k := range keys {
-		if k == currentID {
-			continue
-		}
-		presentationOrder = append(presentationOrder, k)
-	}
-
-	for _, id := range presentationOrder {
-		m := ms.all[id]
+	for _, m := range ms.SortedMachines() {
 		lu := m.LastUsed.Format("2006-01-02 15:04:05")
-		if id == currentID {
+		if ms.IsCurrent(m) {
 			lu = i18n.G("current")
 		}
 		fmt.Fprintf(w, i18n.G("%s\t%t\t%s\n"), m.ID, m.IsZsys, lu)
@@ -891,7 +1023,7 @@ func (ms Machines) List() (string, error) {
 func (ms *Machines) Reload(ctx context.Context) error {
 	conf, err := config.Load(ctx, ms.conf.Path)
 	if err != nil {
-		return fmt.Errorf(i18n.G("couldn't load zsys configuration"), err)
+		return fmt.Errorf(i18n.G("couldn't load zsys configuration: ")+config.ErrorFormat, err)
 	}
 
 	ms.conf = conf
@@ -3,7 +3,11 @@ package machines_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
@@ -11,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/k0kubun/pp"
 	"github.com/stretchr/testify/assert"
+	"github.com/ubuntu/zsys/internal/bootloader"
 	"github.com/ubuntu/zsys/internal/config"
 	"github.com/ubuntu/zsys/internal/machines"
 	"github.com/ubuntu/zsys/internal/testutils"
@@ -25,6 +30,7 @@ func TestNew(t *testing.T) {
 		def            string
 		cmdline        string
 		mountedDataset string
+		configPath     string
 	}{
 		"One machine, one dataset":            {def: "d_one_machine_one_dataset.yaml"},
 		"One disabled machine":                {def: "d_one_disabled_machine.yaml"},
@@ -127,6 +133,13 @@ func TestNew(t *testing.T) {
 		"Selected machine doesn't exist":             {def: "d_one_machine_one_dataset.yaml", cmdline: generateCmdLine("foo")},
 		"Select existing dataset but not a machine":  {def: "m_with_persistent.yaml", cmdline: generateCmdLine("rpool/ROOT")},
 
+		// Configured pools
+		"Configured pools, user datasets on user data pool":             {def: "m_with_userdata_on_other_pool.yaml", configPath: "pools_separate.conf"},
+		"Configured pools, boot dataset on boot pool":                   {def: "m_with_separate_boot.yaml", configPath: "pools_separate.conf"},
+		"Configured pools, user datasets on another pool are unmanaged": {def: "m_with_userdata_on_other_pool.yaml", configPath: "pools_rpool_only.conf"},
+		"Configured pools, boot dataset on another pool is unmanaged":   {def: "m_with_separate_boot.yaml", configPath: "pools_rpool_only.conf"},
+		"Configured pools, system on another pool isn't a machine":      {def: "d_two_machines_one_dataset.yaml", configPath: "pools_separate.conf"},
+
 		// Error cases
 		"Clone, origin doesn't exist": {def: "m_clone_origin_doesnt_exist.yaml"},
 	}
@@ -147,7 +160,12 @@ func TestNew(t *testing.T) {
 				lzfs.SetDatasetAsMounted(tc.mountedDataset, true)
 			}
 
-			got, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if tc.configPath == "" {
+				tc.configPath = "default.conf"
+			}
+			tc.configPath = filepath.Join("testdata", "confs", tc.configPath)
+
+			got, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs), machines.WithConfig(tc.configPath))
 			if err != nil {
 				t.Error("expected success but got an error scanning for machines", err)
 			}
@@ -182,6 +200,8 @@ func TestBoot(t *testing.T) {
 		def            string
 		cmdline        string
 		mountedDataset string
+		locked         bool
+		setPoolSize    string
 
 		cloneErr       bool
 		scanErr        bool
@@ -226,6 +246,23 @@ func TestBoot(t *testing.T) {
 			cmdline:        generateCmdLineWithRevert("rpool/ROOT/ubuntu_1234@snap1"),
 			mountedDataset: "rpool/ROOT/ubuntu_4242"},
 
+		"Reverting userdata keeps quota": {def: "m_clone_with_userdata_with_children_reverting_quota.yaml",
+			cmdline:        generateCmdLineWithRevert("rpool/ROOT/ubuntu_1234@snap1"),
+			mountedDataset: "rpool/ROOT/ubuntu_4242"},
+		"Reverting userdata moves reservation on pool too small for two": {def: "m_clone_with_userdata_with_children_reverting_quota.yaml",
+			cmdline:        generateCmdLineWithRevert("rpool/ROOT/ubuntu_1234@snap1"),
+			mountedDataset: "rpool/ROOT/ubuntu_4242", setPoolSize: "2147483648"},
+
+		// Encrypted userdata
+		"Reverting encrypted userdata, with children": {def: "m_clone_with_encrypted_userdata_with_children_reverting.yaml",
+			cmdline:        generateCmdLineWithRevert("rpool/ROOT/ubuntu_1234@snap1"),
+			mountedDataset: "rpool/ROOT/ubuntu_4242"},
+		"Reverting locked encrypted userdata is delayed": {def: "m_clone_with_encrypted_userdata_with_children_reverting.yaml",
+			cmdline:        generateCmdLineWithRevert("rpool/ROOT/ubuntu_1234@snap1"),
+			mountedDataset: "rpool/ROOT/ubuntu_4242", locked: true},
+		"Pending user revert on encrypted snapshot":                {def: "m_with_encrypted_userdata_pending_user_revert_snapshot.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+		"Pending user revert on locked encrypted snapshot is kept": {def: "m_with_encrypted_userdata_pending_user_revert_snapshot.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234"), locked: true, isNoOp: true},
+
 		// Booting on snapshot on real machines
 		"Desktop revert on snapshot": {def: "m_layout1_machines_with_snapshots_clones_reverting.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678@snap3"), mountedDataset: "rpool/ROOT/ubuntu_4242"},
 		"Desktop revert on snapshot with userdata revert": {def: "m_layout1_machines_with_snapshots_clones_reverting.yaml",
@@ -236,6 +273,11 @@ func TestBoot(t *testing.T) {
 			cmdline:        generateCmdLineWithRevert("rpool/ROOT/ubuntu_5678@snap3"),
 			mountedDataset: "rpool/ROOT/ubuntu_4242"},
 
+		// Pending user revert
+		"Pending user revert on snapshot":                 {def: "m_with_userdata_pending_user_revert_snapshot.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+		"Pending user revert on clone":                    {def: "m_with_userdata_pending_user_revert_clone.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+		"Pending user revert on unknown state is dropped": {def: "m_with_userdata_pending_user_revert_unknown.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+
 		// Error cases
 		"No booted state found does nothing":       {def: "m_layout1_machines_with_snapshots_clones_reverting.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678@snap3"), isNoOp: true},
 		"SetProperty fails":                        {def: "m_layout1_machines_with_snapshots_clones_reverting.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678@snap3"), mountedDataset: "rpool/ROOT/ubuntu_4242", setPropertyErr: true, wantErr: true},
@@ -259,6 +301,9 @@ func TestBoot(t *testing.T) {
 			if tc.mountedDataset != "" {
 				lzfs.SetDatasetAsMounted(tc.mountedDataset, true)
 			}
+			if tc.locked {
+				lzfs.UnloadKeys()
+			}
 
 			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
 			if err != nil {
@@ -269,6 +314,9 @@ func TestBoot(t *testing.T) {
 			lzfs.ErrOnClone(tc.cloneErr)
 			lzfs.ErrOnScan(tc.scanErr)
 			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+			if tc.setPoolSize != "" {
+				lzfs.SetPoolSize("rpool", tc.setPoolSize)
+			}
 
 			hasChanged, err := ms.EnsureBoot(context.Background())
 			if err != nil {
@@ -451,6 +499,10 @@ func TestCommit(t *testing.T) {
 		"Server without user revert":  {def: "m_layout2_machines_with_snapshots_clones_no_user_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_9876")},
 		"Server with user revert":     {def: "m_layout2_machines_with_snapshots_clones_user_revert.yaml", cmdline: generateCmdLineWithRevert("rpool/ROOT/ubuntu_9876")},
 
+		// Pending system revert
+		"Pending system revert is reset when booting on it":      {def: "m_with_pending_system_revert.yaml", cmdline: generateCmdLineWithRevert("rpool/ROOT/ubuntu_5678")},
+		"Pending system revert is reset when booting on current": {def: "m_with_pending_system_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+
 		// Error cases
 		"SetProperty fails (first)":  {def: "m_clone_with_userdata_with_children_to_promote_no_user_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678"), setPropertyErr: true, wantErr: true},
 		"SetProperty fails (second)": {def: "m_clone_with_userdata_to_promote_no_user_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_5678"), setPropertyErr: true, wantErr: true},
@@ -617,10 +669,13 @@ func TestUpdateLastUsed(t *testing.T) {
 func TestCreateUserData(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
-		def      string
-		user     string
-		homePath string
-		cmdline  string
+		def        string
+		user       string
+		homePath   string
+		cmdline    string
+		configPath string
+		encrypt    string
+		passphrase string
 
 		setPropertyErr bool
 		createErr      bool
@@ -641,6 +696,24 @@ func TestCreateUserData(t *testing.T) {
 		"Prefer system pool (try other pool) for userdata": {def: "m_without_userdata_prefer_system_pool.yaml", cmdline: generateCmdLine("rpool2/ROOT/ubuntu_1234")},
 		"No attached userdata on second pool":              {def: "m_no_attached_userdata_second_pool.yaml"},
 
+		// Configured pools cases
+		"Create user data container on configured pool":            {def: "m_without_userdata_prefer_system_pool.yaml", configPath: "pools_separate.conf"},
+		"Ignore user data container on pool not configured for it": {def: "m_no_attached_userdata_second_pool.yaml", configPath: "pools_rpool_only.conf"},
+		"Reuse user data container on configured pool":             {def: "m_with_userdata_only_on_other_pool.yaml", configPath: "pools_separate.conf"},
+
+		// Encryption cases
+		"Encrypt user dataset":                                  {def: "m_with_userdata.yaml", encrypt: "on", passphrase: "secret"},
+		"Encrypt user dataset by default":                       {def: "m_with_userdata.yaml", configPath: "userdata_encryption.conf", passphrase: "secret"},
+		"Don't encrypt user dataset despite configuration":      {def: "m_with_userdata.yaml", configPath: "userdata_encryption.conf", encrypt: "off"},
+		"Passphrase is ignored on unencrypted user dataset":     {def: "m_with_userdata.yaml", passphrase: "secret"},
+		"Encrypted user dataset needs a passphrase":             {def: "m_with_userdata.yaml", encrypt: "on", wantErr: true, isNoOp: true},
+		"Encrypted by default user dataset needs a passphrase":  {def: "m_with_userdata.yaml", configPath: "userdata_encryption.conf", wantErr: true, isNoOp: true},
+		"Existing user dataset is reused as is when encrypting": {def: "m_with_userdata.yaml", user: "user1", encrypt: "on", passphrase: "secret"},
+
+		// Quota cases
+		"Apply configured quota and reservation":    {def: "m_with_userdata.yaml", configPath: "userdata_quota.conf"},
+		"Existing user dataset keeps its own quota": {def: "m_with_userdata_quota.yaml", configPath: "userdata_quota.conf", user: "user1", homePath: "/home/user1", isNoOp: true},
+
 		// User or home edge cases
 		"No user set":                                           {def: "m_with_userdata.yaml", user: "[empty]", wantErr: true, isNoOp: true},
 		"No home path set":                                      {def: "m_with_userdata.yaml", homePath: "[empty]", wantErr: true, isNoOp: true},
@@ -664,6 +737,7 @@ func TestCreateUserData(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			tc.cmdline = getDefaultValue(tc.cmdline, generateCmdLine("rpool/ROOT/ubuntu_1234"))
+			tc.configPath = filepath.Join("testdata", "confs", getDefaultValue(tc.configPath, "default.conf"))
 
 			dir, cleanup := testutils.TempDir(t)
 			defer cleanup()
@@ -674,7 +748,7 @@ func TestCreateUserData(t *testing.T) {
 			lzfs := libzfs.(*mock.LibZFS)
 			lzfs.ForceLastUsedTime(true)
 
-			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs), machines.WithConfig(tc.configPath))
 			if err != nil {
 				t.Error("expected success but got an error scanning for machines", err)
 			}
@@ -685,7 +759,15 @@ func TestCreateUserData(t *testing.T) {
 			lzfs.ErrOnScan(tc.scanErr)
 			lzfs.ErrOnSetProperty(tc.setPropertyErr)
 
-			err = ms.CreateUserData(context.Background(), getDefaultValue(tc.user, "userfoo"), getDefaultValue(tc.homePath, "/home/foo"))
+			opts := []machines.UserDataOption{machines.WithPassphrase([]byte(tc.passphrase))}
+			switch tc.encrypt {
+			case "on":
+				opts = append(opts, machines.WithEncryption(true))
+			case "off":
+				opts = append(opts, machines.WithEncryption(false))
+			}
+
+			err = ms.CreateUserData(context.Background(), getDefaultValue(tc.user, "userfoo"), getDefaultValue(tc.homePath, "/home/foo"), opts...)
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
@@ -703,7 +785,7 @@ func TestCreateUserData(t *testing.T) {
 				assertMachinesNotEquals(t, initMachines, ms)
 			}
 
-			machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs), machines.WithConfig(tc.configPath))
 			if err != nil {
 				t.Error("expected success but got an error scanning for machines", err)
 			}
@@ -844,6 +926,199 @@ func TestCurrentIsZsys(t *testing.T) {
 	}
 }
 
+func TestBootStates(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def     string
+		cmdline string
+	}{
+		"One machine with snapshot and clone": {def: "state_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+		"Other machine is current":            {def: "state_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_9999")},
+		"Non zsys machines are ignored":       {def: "d_two_machines_one_zsys_one_non_zsys.yaml", cmdline: generateCmdLine("rpool2")},
+		"No machine":                          {def: "d_no_machine.yaml", cmdline: generateCmdLine("rpool")},
+		"States with kernel":                  {def: "d_one_machine_with_clone_dataset.yaml", cmdline: generateCmdLine("rpool/main")},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			got := ms.BootStates()
+			// Goldens don't depend on the local timezone.
+			for i := range got {
+				got[i].LastUsed = got[i].LastUsed.UTC()
+			}
+			var want []bootloader.State
+			testutils.LoadFromGoldenFile(t, got, &want)
+
+			assert.Equal(t, want, got, "Boot states should match")
+		})
+	}
+}
+
+func TestSortedMachines(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def     string
+		cmdline string
+	}{
+		"Current machine first":        {def: "state_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_9999")},
+		"No current machine":           {def: "state_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/doesntexist")},
+		"History and users by recency": {def: "gc_system_with_users.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+		"No machine":                   {def: "d_no_machine.yaml", cmdline: generateCmdLine("rpool")},
+	}
+
+	type machineOrder struct {
+		ID         string
+		Current    bool
+		History    []string
+		UserStates map[string][]string
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			var got []machineOrder
+			for _, m := range ms.SortedMachines() {
+				mo := machineOrder{ID: m.ID, Current: ms.IsCurrent(m), History: m.HistoryIDs()}
+				for u := range m.AllUsersStates {
+					if mo.UserStates == nil {
+						mo.UserStates = make(map[string][]string)
+					}
+					mo.UserStates[u] = m.UserStateIDs(u)
+				}
+				got = append(got, mo)
+			}
+			var want []machineOrder
+			testutils.LoadFromGoldenFile(t, got, &want)
+
+			assert.Equal(t, want, got, "Machines order should match")
+		})
+	}
+}
+
+func TestStateSpace(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def     string
+		cmdline string
+	}{
+		"States with space accounting":    {def: "state_space.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+		"States without space accounting": {def: "state_revert.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_1234")},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			got := make(map[string]machines.Space)
+			for _, m := range ms.SortedMachines() {
+				got[m.ID] = ms.StateSpace(context.Background(), &m.State)
+				for id, s := range m.History {
+					got[id] = ms.StateSpace(context.Background(), s)
+				}
+				for _, states := range m.AllUsersStates {
+					for id, s := range states {
+						got[id] = ms.StateSpace(context.Background(), s)
+					}
+				}
+			}
+			want := make(map[string]machines.Space)
+			testutils.LoadFromGoldenFile(t, got, &want)
+
+			assert.Equal(t, want, got, "States space should match")
+		})
+	}
+}
+
+func TestSortedHistoryIDs(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		sortBy string
+
+		want    []string
+		wantErr bool
+	}{
+		"Default sort by last used": {want: []string{"rpool/ROOT/ubuntu_5678", "rpool/ROOT/ubuntu_1234@snap1"}},
+		"Sort by last used":         {sortBy: machines.SortByLastUsed, want: []string{"rpool/ROOT/ubuntu_5678", "rpool/ROOT/ubuntu_1234@snap1"}},
+		"Sort by reclaimable":       {sortBy: machines.SortByReclaimable, want: []string{"rpool/ROOT/ubuntu_1234@snap1", "rpool/ROOT/ubuntu_5678"}},
+
+		"Error on unknown sort key": {sortBy: "size", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_space.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			m, err := ms.GetMachine("")
+			if err != nil {
+				t.Fatal("expected a current machine but got an error", err)
+			}
+
+			got, err := ms.SortedHistoryIDs(context.Background(), m, tc.sortBy)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assert.Equal(t, tc.want, got, "History states order should match")
+		})
+	}
+}
+
 func TestChangeHomeOnUserData(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
@@ -927,6 +1202,8 @@ func TestCreateSystemSnapshot(t *testing.T) {
 		def          string
 		cmdline      string
 		snapshotName string
+		description  string
+		labels       map[string]string
 
 		setCapOnPool string
 		capValue     string
@@ -943,6 +1220,11 @@ func TestCreateSystemSnapshot(t *testing.T) {
 
 		"No associated userdata": {def: "d_one_machine_with_children.yaml", cmdline: generateCmdLine("rpool")},
 
+		// Metadata
+		"Snapshot with description and labels": {def: "m_with_userdata_children_on_system.yaml", snapshotName: "my_snapshot", description: "Before installing foo", labels: map[string]string{"origin": "apt", "packages": "foo bar"}},
+		"Error on invalid label key":           {def: "m_with_userdata.yaml", labels: map[string]string{"Invalid Key": "value"}, wantErr: true, isNoOp: true},
+		"Error on empty label value":           {def: "m_with_userdata.yaml", labels: map[string]string{"key": ""}, wantErr: true, isNoOp: true},
+
 		// Free space handling
 		"Not enough free space on system pool":                {def: "m_with_userdata_on_other_pool.yaml", setCapOnPool: "rpool", capValue: "99", wantErr: true},
 		"Not enough free space on user pool":                  {def: "m_with_userdata_on_other_pool.yaml", setCapOnPool: "rpool2", capValue: "99", wantErr: true},
@@ -989,7 +1271,7 @@ func TestCreateSystemSnapshot(t *testing.T) {
 
 			initMachines := ms.CopyForTests(t)
 
-			snapshotName, err := ms.CreateSystemSnapshot(context.Background(), tc.snapshotName)
+			snapshotName, err := ms.CreateSystemSnapshot(context.Background(), tc.snapshotName, tc.description, tc.labels)
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
@@ -1034,6 +1316,8 @@ func TestCreateUserSnapshot(t *testing.T) {
 		cmdline      string
 		snapshotName string
 		userName     string
+		description  string
+		labels       map[string]string
 
 		setCapOnPool string
 		capValue     string
@@ -1048,6 +1332,9 @@ func TestCreateUserSnapshot(t *testing.T) {
 		"Children on user datasets": {def: "m_with_userdata_children_on_user.yaml"},
 		"Children on user datasets with one child non associated with current machine": {def: "m_with_userdata_child_associated_one_state.yaml", cmdline: generateCmdLine("rpool/ROOT/ubuntu_9999")},
 
+		"Snapshot with description and labels": {def: "m_with_userdata_children_on_user.yaml", snapshotName: "my_snapshot", description: "Before cleaning up", labels: map[string]string{"reason": "cleanup"}},
+		"Error on invalid label key":           {def: "m_with_userdata.yaml", labels: map[string]string{"key,with,commas": "value"}, wantErr: true, isNoOp: true},
+
 		// Space handling
 		"Not enough free space on user pool":                       {def: "m_with_userdata_on_other_pool.yaml", setCapOnPool: "rpool2", capValue: "99", wantErr: true},
 		"Take user snapshot, not enough free space on other pools": {def: "m_with_userdata_on_other_pool.yaml", setCapOnPool: "rpool", capValue: "99"},
@@ -1098,7 +1385,7 @@ func TestCreateUserSnapshot(t *testing.T) {
 
 			initMachines := ms.CopyForTests(t)
 
-			snapshotName, err := ms.CreateUserSnapshot(context.Background(), tc.userName, tc.snapshotName)
+			snapshotName, err := ms.CreateUserSnapshot(context.Background(), tc.userName, tc.snapshotName, tc.description, tc.labels)
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
@@ -1187,6 +1474,19 @@ func TestRemoveState(t *testing.T) {
 		"Remove shared user state as a dependency of other state. Deps are removed":                        {def: "m_shared_userstate_on_clones.yaml", state: "rpool/USERDATA/user_abcd", user: "user", force: true},
 		"Remove shared user state on different matchines as a dependency of other state. Deps are removed": {def: "m_shared_userstate_on_two_machines.yaml", state: "rpool/USERDATA/user_abcd", user: "user", force: true},
 
+		// Pinned states
+		"Can’t remove pinned system snapshot":          {def: "state_pinned.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234@snap1", wantErr: true, wantConfirmationErr: true, isNoOp: true},
+		"Remove pinned system snapshot, forced":        {def: "state_pinned.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234@snap1", force: true},
+		"Can’t remove pinned system state":             {def: "state_pinned.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_5678", wantErr: true, wantConfirmationErr: true, isNoOp: true},
+		"Can’t remove pinned user state":               {def: "state_pinned.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/USERDATA/user1_abcd@snap1", user: "user1", wantErr: true, wantConfirmationErr: true, isNoOp: true},
+		"Remove unpinned state next to a pinned state": {def: "state_pinned.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234@snap2"},
+
+		// Held states
+		"Can’t remove held system snapshot":         {def: "state_held.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234@snap1", wantErr: true, isNoOp: true},
+		"Can’t remove held system snapshot, forced": {def: "state_held.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234@snap1", force: true, wantErr: true, isNoOp: true},
+		"Can’t remove held user state":              {def: "state_held.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/USERDATA/user1_abcd@snap1", user: "user1", wantErr: true, isNoOp: true},
+		"Remove unheld state next to a held state":  {def: "state_held.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234@snap2"},
+
 		"No state given": {def: "m_with_userdata.yaml", wantErr: true, isNoOp: true},
 		"Error on trying to remove current state":    {def: "m_with_userdata.yaml", currentStateID: "rpool/ROOT/ubuntu_1234", state: "rpool/ROOT/ubuntu_1234", wantErr: true, isNoOp: true},
 		"Error on destroy state, one dataset":        {def: "m_with_userdata.yaml", state: "rpool/ROOT/ubuntu_1234", destroyErrDS: []string{}, wantErr: true, isNoOp: true},
@@ -1318,87 +1618,32 @@ func TestIDToState(t *testing.T) {
 	}
 }
 
-func TestGC(t *testing.T) {
+func TestRevertSystemState(t *testing.T) {
 	t.Parallel()
 	tests := map[string]struct {
-		def        string
-		all        bool
-		configPath string
+		def            string
+		currentStateID string
+		state          string
+		revertUserData bool
 
-		destroyErrDS []string
+		setPropertyErr bool
+		selectErr      bool
 
-		isNoOp  bool
 		wantErr bool
 	}{
-		/***** System states only tests *****/
-		"Follow bucket policy":                       {def: "gc_system_only.yaml"},
-		"Follow bucket policy with one empty bucket": {def: "gc_system_only.yaml", configPath: "one_empty_bucket.conf"},
-		"Existing buckets have enough capacity":      {def: "gc_system_only.yaml", configPath: "not_enough_snapshots.conf"},
-
-		"No snapshot, keep everything":                         {def: "m_with_userdata.yaml", isNoOp: true},
-		"Keep previous and current day, purge everything else": {def: "gc_system_only.yaml", configPath: "purge_all_zsys.conf"},
-		"Keep current day, purge everything else":              {def: "gc_system_only.yaml", configPath: "purge_but_previous_zsys.conf"},
-		"Non zsys systems are ignored":                         {def: "gc_system_only_non_zsys.yaml", isNoOp: true},
-		"Keep more snapshots than simply last day has":         {def: "gc_system_only.yaml", configPath: "keep_many_snapshots.conf"},
-
-		"Manual snapshot which should be deleted is kept":     {def: "gc_system_only_with_manual_snapshot.yaml"},
-		"Manual snapshot which should be deleted isnt't kept": {def: "gc_system_only_with_manual_snapshot.yaml", all: true},
-
-		"Clone and dependencies are collected within the same bucket":                  {def: "gc_system_only_with_clone_same_bucket.yaml"},
-		"Manual clone without last used is collected":                                  {def: "gc_system_only_with_manual_clone.yaml"},
-		"Clone having snapshots and dependencies are collected within the same bucket": {def: "gc_system_only_with_clone_same_bucket_with_dep.yaml"},
-		"Keep clone and dependencies having manual snapshots":                          {def: "gc_system_only_with_clone_same_bucket_with_manual_dep.yaml"},
-		"Clone cloned and all dependencies are collected within the same bucket":       {def: "gc_system_only_with_clone_same_bucket_with_clone.yaml"},
-		"Clone and dependencies are collected trans bucket (clone after snapshot)":     {def: "gc_system_only_with_clone_different_buckets.yaml"},
-		"Clone with dependencies in bucket before, same and after":                     {def: "gc_system_only_with_clone_and_snapshots_different_buckets.yaml"},
-
-		"Subdataset with some snapshots shared with it":              {def: "gc_system_only_with_children.yaml"},
-		"Subdataset with some snapshots only it are kept":            {def: "gc_system_only_with_children_snapshots_only_on_subdataset.yaml"},
-		"Subdataset with snapshot keeps clone":                       {def: "gc_system_only_with_clone_same_bucket_with_snapshot_on_subdataset.yaml"},
-		"Subdataset and clone are collected":                         {def: "gc_system_only_with_clone_same_bucket_with_snapshot_on_subdataset_and_main_state.yaml"},
-		"Subdataset with manual clone on subdataset isn’t collected": {def: "gc_system_only_with_clone_same_bucket_with_snapshot_on_subdataset_and_main_state_with_manual_clone_on_subdataset.yaml"},
-
-		/***** User states tests *****/
-		"Follow bucket policy with users":                      {def: "gc_system_with_users.yaml"},
-		"Follow bucket policy with users and one empty bucket": {def: "gc_system_with_users_one_empty_bucket.yaml", configPath: "one_empty_bucket.conf", isNoOp: true},
-		"Keep more user snapshots than simply last day has":    {def: "gc_system_with_users.yaml", configPath: "keep_many_snapshots.conf"},
-
-		// User clones
-		"Remove user clone state":                                                                     {def: "gc_system_with_users_clone.yaml"},
-		"Remove user clone state with subdataset":                                                     {def: "gc_system_with_users_clone_subdataset.yaml"},
-		"Don't remove user clone state with snapshot on it kept":                                      {def: "gc_system_with_users_clone_with_manual_snapshot.yaml", isNoOp: true},
-		"Don't remove user clone state with snapshot on subdataset":                                   {def: "gc_system_with_users_clone_subdataset_with_manual_snapshot.yaml", isNoOp: true},
-		"Don't remove user clone state linked to a system state":                                      {def: "gc_system_with_users_clone_linked_to_system_state.yaml", isNoOp: true},
-		"Ensure user clones are accounted by policy":                                                  {def: "gc_system_with_users_with_untagged_clones.yaml"},
-		"Remove unassociated user clone after deleting its snapshot":                                  {def: "gc_system_with_users_clone_with_auto_snapshot.yaml"},
-		"Remove unassociated user clone after deleting its snapshot which was linked to system state": {def: "gc_system_with_users_clone_with_auto_snapshot_attached_to_system_state.yaml"},
-
-		// User clone attached to multiple states/machines
-		"Users and clones on shared system state":                     {def: "gc_system_with_users_and_clones_shared_system_state.yaml"},
-		"Users and clones on different machines history":              {def: "gc_system_with_users_and_clones_different_machines_history_only.yaml"},
-		"Users and clones on different machines, one is active state": {def: "gc_system_with_users_and_clones_different_machines.yaml"},
-
-		// Unlinked user test cases
-		"Remove simple unlinked user dataset":                                             {def: "gc_system_with_unlinked_users.yaml"},
-		"Remove unlinked user dataset and any snapshot":                                   {def: "gc_system_with_unlinked_users_and_snapshot.yaml"},
-		"Remove unlinked user dataset and any unmanaged user clone":                       {def: "gc_system_with_unlinked_users_unmanaged_clone.yaml"},
-		"Can't remove unlinked user dataset if it has a manual clone in USERDATA":         {def: "gc_system_with_unlinked_users_unmanaged_clone_nobootfs_dataset.yaml", isNoOp: true},
-		"Can't remove unlinked user dataset if it has a manual clone outside of USERDATA": {def: "gc_system_with_unlinked_users_unmanaged_user_clone.yaml", isNoOp: true},
-		"Remove unlinked clone of a manual filesystem dataset":                            {def: "gc_system_with_unlinked_users_unmanaged_clone_bootfs_on_clone.yaml"},
-
-		// Failed revert
-		"Failed revert - no lastused on system":        {def: "gc_system_failed_revert.yaml"},
-		"Failed boot - no lastused on user and system": {def: "gc_system_with_users_failed_boot.yaml"},
-
-		// Deletion prevention (no infinite loop)
-		"Manual user snapshot which should be deleted is kept": {def: "gc_system_with_users_manual_snapshots.yaml", isNoOp: true},
-		"Users and clones with undeletable snapshot":           {def: "gc_system_with_users_and_clones_undeletable_snapshot.yaml"},
-		"Destroy failed on system dataset":                     {def: "gc_system_with_users_clone_with_auto_snapshot_attached_to_system_state.yaml", destroyErrDS: []string{"rpool/ROOT/ubuntu_1234@autozsys_20191230-1710"}, isNoOp: true},
-		"Destroy failed on user dataset":                       {def: "gc_system_with_users_clone.yaml", destroyErrDS: []string{"rpool/USERDATA/user1_clone"}, isNoOp: true},
-		"Destroy failed on unlinked user dataset":              {def: "gc_system_with_unlinked_users_unmanaged_clone_bootfs_on_clone.yaml", destroyErrDS: []string{"rpool/USERDATA/user2_clone"}, isNoOp: true},
-
-		// Error cases
-		"Error fails to destroy state are kept": {def: "gc_system_with_users.yaml", destroyErrDS: []string{}, isNoOp: true},
+		"Revert to snapshot":                {def: "state_revert.yaml", state: "rpool/ROOT/ubuntu_1234@snap1"},
+		"Revert to snapshot with user data": {def: "state_revert.yaml", state: "snap1", revertUserData: true},
+		"Revert to clone":                   {def: "state_revert.yaml", state: "5678"},
+		"Revert overrides pending revert":   {def: "m_with_pending_system_revert.yaml", state: "rpool/ROOT/ubuntu_1234@snap1"},
+
+		"Error on no state given":                  {def: "state_revert.yaml", wantErr: true},
+		"Error on unknown state":                   {def: "state_revert.yaml", state: "doesntexist", wantErr: true},
+		"Error on reverting to current state":      {def: "state_revert.yaml", state: "rpool/ROOT/ubuntu_1234", wantErr: true},
+		"Error on state from another machine":      {def: "state_revert.yaml", state: "rpool/ROOT/ubuntu_9999@snap9", wantErr: true},
+		"Error on non zsys machine":                {def: "d_one_machine_one_dataset_non_zsys.yaml", currentStateID: "rpool", state: "rpool", wantErr: true},
+		"Error on no current machine":              {def: "state_revert.yaml", currentStateID: "-", state: "snap1", wantErr: true},
+		"Error on setting pending revert property": {def: "state_revert.yaml", state: "snap1", setPropertyErr: true, wantErr: true},
+		"Error on selecting next boot":             {def: "state_revert.yaml", state: "snap1", selectErr: true, wantErr: true},
 	}
 
 	for name, tc := range tests {
@@ -1412,14 +1657,1187 @@ func TestGC(t *testing.T) {
 			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
 			defer fPools.Create(dir)()
 
-			if tc.configPath == "" {
-				tc.configPath = "default.conf"
+			cmdline := generateCmdLine(getDefaultValue(tc.currentStateID, "rpool/ROOT/ubuntu_1234"))
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
 			}
-			tc.configPath = filepath.Join("testdata", "confs", tc.configPath)
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
 
-			z, err := zfs.New(context.Background(), zfs.WithLibZFS(libzfs))
-			if err != nil {
-				t.Fatalf("couldn’t create original zfs datasets state")
+			initPendingRevert, _ := ms.PendingRevert()
+			var selected string
+			selectNextBoot := func(ctx context.Context, stateID string, revertUserData bool) error {
+				// The revert is only recorded once the bootloader selected the state.
+				pendingRevert, _ := ms.PendingRevert()
+				assert.Equal(t, initPendingRevert, pendingRevert, "pending revert when selecting next boot")
+				if tc.selectErr {
+					return errors.New("Bootloader error")
+				}
+				selected = stateID
+				return nil
+			}
+
+			s, err := ms.RevertSystemState(context.Background(), tc.state, tc.revertUserData, selectNextBoot)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assert.Equal(t, s.ID, selected, "state selected for next boot")
+			id, revertUserData := ms.PendingRevert()
+			assert.Equal(t, s.ID, id, "pending revert state")
+			assert.Equal(t, tc.revertUserData, revertUserData, "pending user data revert")
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestRevertUserState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def   string
+		state string
+		user  string
+
+		setPropertyErr bool
+
+		wantErr bool
+	}{
+		"Revert to user snapshot":         {def: "state_revert.yaml", state: "usersnap", user: "user1"},
+		"Revert to user snapshot by path": {def: "state_revert.yaml", state: "rpool/USERDATA/user1_abcd@snap1", user: "user1"},
+		"Revert to user clone":            {def: "state_revert.yaml", state: "rpool/USERDATA/user1_efgh", user: "user1"},
+
+		"Error on no user given":                   {def: "state_revert.yaml", state: "usersnap", wantErr: true},
+		"Error on unknown user":                    {def: "state_revert.yaml", state: "usersnap", user: "userfoo", wantErr: true},
+		"Error on no state given":                  {def: "state_revert.yaml", user: "user1", wantErr: true},
+		"Error on unknown state":                   {def: "state_revert.yaml", state: "doesntexist", user: "user1", wantErr: true},
+		"Error on reverting to current state":      {def: "state_revert.yaml", state: "rpool/USERDATA/user1_abcd", user: "user1", wantErr: true},
+		"Error on state from another machine":      {def: "state_revert.yaml", state: "rpool/USERDATA/user1_wxyz@snap9", user: "user1", wantErr: true},
+		"Error on setting pending revert property": {def: "state_revert.yaml", state: "usersnap", user: "user1", setPropertyErr: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+
+			_, err = ms.RevertUserState(context.Background(), tc.state, tc.user)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestRestoreUserState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def   string
+		state string
+		user  string
+
+		locked         bool
+		cloneErr       bool
+		setPropertyErr bool
+		unmountErr     bool
+		mountErr       bool
+		setPoolSize    string
+		mounted        map[string]string
+
+		wantUserState string
+		wantMounted   map[string]string
+		wantErr       bool
+	}{
+		"Restore user snapshot": {def: "state_revert.yaml", state: "usersnap", user: "user1", wantUserState: "rpool/USERDATA/user1_xxxxxx"},
+		"Restore user snapshot with children, parents mounted first": {def: "m_snapshot_with_userdata_with_children.yaml", state: "snap1", user: "user1",
+			mounted:       map[string]string{"/home/user1": "rpool/USERDATA/user1_abcd", "/home/user1/tools": "rpool/USERDATA/user1_abcd/tools"},
+			wantUserState: "rpool/USERDATA/user1_xxxxxx",
+			wantMounted:   map[string]string{"/home/user1": "rpool/USERDATA/user1_xxxxxx", "/home/user1/tools": "rpool/USERDATA/user1_xxxxxx/tools"}},
+		"Restore user snapshot by path":     {def: "state_revert.yaml", state: "rpool/USERDATA/user1_abcd@snap1", user: "user1", wantUserState: "rpool/USERDATA/user1_xxxxxx"},
+		"Restore user clone":                {def: "state_revert.yaml", state: "rpool/USERDATA/user1_efgh", user: "user1", wantUserState: "rpool/USERDATA/user1_efgh"},
+		"Restore user snapshot keeps quota": {def: "state_revert_quota.yaml", state: "usersnap", user: "user1", wantUserState: "rpool/USERDATA/user1_xxxxxx"},
+		"Restore user snapshot moves reservation on pool too small for two": {def: "state_revert_quota.yaml", state: "usersnap", user: "user1",
+			setPoolSize: "2147483648", wantUserState: "rpool/USERDATA/user1_xxxxxx"},
+		"Restore user snapshot resets pending revert": {def: "m_with_userdata_pending_user_revert_snapshot.yaml", state: "snap1", user: "user1", wantUserState: "rpool/USERDATA/user1_xxxxxx"},
+
+		"Error on no user given":              {def: "state_revert.yaml", state: "usersnap", wantErr: true},
+		"Error on unknown user":               {def: "state_revert.yaml", state: "usersnap", user: "userfoo", wantErr: true},
+		"Error on no state given":             {def: "state_revert.yaml", user: "user1", wantErr: true},
+		"Error on unknown state":              {def: "state_revert.yaml", state: "doesntexist", user: "user1", wantErr: true},
+		"Error on restoring current state":    {def: "state_revert.yaml", state: "rpool/USERDATA/user1_abcd", user: "user1", wantErr: true},
+		"Error on state from another machine": {def: "state_revert.yaml", state: "rpool/USERDATA/user1_wxyz@snap9", user: "user1", wantErr: true},
+		"Error on cloning user snapshot":      {def: "state_revert.yaml", state: "usersnap", user: "user1", cloneErr: true, wantErr: true},
+		"Error on setting property":           {def: "state_revert.yaml", state: "usersnap", user: "user1", setPropertyErr: true, wantErr: true},
+		"Error on locked user snapshot":       {def: "m_with_encrypted_userdata.yaml", state: "usersnap", user: "user1", locked: true, wantErr: true},
+		"Error on unmounting current state":   {def: "state_revert.yaml", state: "usersnap", user: "user1", unmountErr: true, wantErr: true},
+		"Error on mounting restored state": {def: "state_revert.yaml", state: "usersnap", user: "user1", mountErr: true,
+			wantMounted: map[string]string{"/home/user1": "rpool/USERDATA/user1_abcd"}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			lzfs := libzfs.(*mock.LibZFS)
+			if tc.locked {
+				lzfs.UnloadKeys()
+			}
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			mounter := &mounterMock{errOnUnmount: tc.unmountErr, errOnMount: tc.mountErr, mounted: tc.mounted}
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs), machines.WithMounter(mounter))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+			lzfs.ErrOnClone(tc.cloneErr)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+			if tc.setPoolSize != "" {
+				lzfs.SetPoolSize("rpool", tc.setPoolSize)
+			}
+
+			s, err := ms.RestoreUserState(context.Background(), tc.state, tc.user)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				assert.Equal(t, tc.wantMounted, mounter.mounted, "Previous mounts should be restored")
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assert.Equal(t, tc.wantUserState, s.ID, "restored user state")
+			if tc.wantMounted == nil {
+				tc.wantMounted = map[string]string{"/home/user1": tc.wantUserState}
+			}
+			assert.Equal(t, tc.wantMounted, mounter.mounted, "Restored user state should be mounted")
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestUnlockUserData(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def        string
+		user       string
+		passphrase string
+		unlocked   bool
+		mountErr   bool
+
+		wantMounted map[string]string
+		wantErr     bool
+		isNoOp      bool
+	}{
+		"Unlock user": {def: "m_with_encrypted_userdata.yaml",
+			wantMounted: map[string]string{"/home/user1": "rpool/USERDATA/user1_abcd"}},
+		"Unlock user and apply pending revert": {def: "m_with_encrypted_userdata_pending_user_revert_snapshot.yaml",
+			wantMounted: map[string]string{"/home/user1": "rpool/USERDATA/user1_xxxxxx"}},
+		"Already unlocked user": {def: "m_with_encrypted_userdata.yaml", unlocked: true, isNoOp: true},
+		"Unencrypted user":      {def: "m_with_userdata.yaml", isNoOp: true},
+
+		"Error on no user given":    {def: "m_with_encrypted_userdata.yaml", user: "[empty]", wantErr: true},
+		"Error on unknown user":     {def: "m_with_encrypted_userdata.yaml", user: "userfoo", wantErr: true},
+		"Error on no passphrase":    {def: "m_with_encrypted_userdata.yaml", passphrase: "[empty]", wantErr: true},
+		"Error on wrong passphrase": {def: "m_with_encrypted_userdata.yaml", passphrase: "wrong", wantErr: true},
+		"Error on mounting":         {def: "m_with_encrypted_userdata.yaml", mountErr: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			lzfs := libzfs.(*mock.LibZFS)
+			if !tc.unlocked {
+				lzfs.UnloadKeys()
+			}
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			mounter := &mounterMock{errOnMount: tc.mountErr}
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs), machines.WithMounter(mounter))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			err = ms.UnlockUserData(context.Background(), getDefaultValue(tc.user, "user1"), []byte(getDefaultValue(tc.passphrase, "passphrase1")))
+			assert.Equal(t, tc.wantMounted, mounter.mounted, "Mounted datasets should match")
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				// Keys stay loaded when mounting fails.
+				if !tc.mountErr {
+					assertMachinesEquals(t, initMachines, ms)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			} else {
+				assertMachinesToGolden(t, ms)
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestSetUserQuota(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def         string
+		user        string
+		quota       uint64
+		reservation uint64
+
+		setPropertyErr bool
+
+		wantErr bool
+	}{
+		"Set quota":                    {def: "m_with_userdata.yaml", quota: 1024 * 1024 * 1024},
+		"Set reservation":              {def: "m_with_userdata.yaml", reservation: 512 * 1024 * 1024},
+		"Set quota and reservation":    {def: "m_with_userdata.yaml", quota: 1024 * 1024 * 1024, reservation: 512 * 1024 * 1024},
+		"Change quota":                 {def: "m_with_userdata_quota.yaml", quota: 2 * 1024 * 1024 * 1024, reservation: 512 * 1024 * 1024},
+		"Remove quota and reservation": {def: "m_with_userdata_quota.yaml"},
+
+		"Error on no user given":    {def: "m_with_userdata.yaml", user: "[empty]", quota: 1024, wantErr: true},
+		"Error on unknown user":     {def: "m_with_userdata.yaml", user: "userfoo", quota: 1024, wantErr: true},
+		"Error on system not zsys":  {def: "m_with_userdata_no_zsys.yaml", quota: 1024, wantErr: true},
+		"Error on setting property": {def: "m_with_userdata.yaml", quota: 1024, setPropertyErr: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+
+			err = ms.SetUserQuota(context.Background(), getDefaultValue(tc.user, "user1"), tc.quota, tc.reservation)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestReleaseStaleHolds(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def string
+
+		isNoOp bool
+	}{
+		"Release zsys holds and keep others": {def: "state_held_by_zsys.yaml"},
+		"No zsys hold":                       {def: "state_held.yaml", isNoOp: true},
+		"No hold":                            {def: "m_with_userdata.yaml", isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			ms.ReleaseStaleHolds(context.Background())
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			} else {
+				assertMachinesToGolden(t, ms)
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestPinState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		state string
+		user  string
+		unpin bool
+
+		setPropertyErr bool
+
+		wantErr bool
+	}{
+		"Pin system snapshot, with linked user snapshots": {state: "rpool/ROOT/ubuntu_1234@snap2"},
+		"Pin user snapshot": {state: "rpool/USERDATA/user1_abcd@snap2", user: "user1"},
+		"Unpin system snapshot, with linked user snapshots": {state: "rpool/ROOT/ubuntu_1234@snap1", unpin: true},
+		"Unpin system state": {state: "rpool/ROOT/ubuntu_5678", unpin: true},
+
+		"Error on no state given":       {wantErr: true},
+		"Error on unknown state":        {state: "doesntexist", wantErr: true},
+		"Error on setting pin property": {state: "rpool/ROOT/ubuntu_1234@snap2", setPropertyErr: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_pinned.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnSetProperty(tc.setPropertyErr)
+
+			setPinned := ms.PinState
+			if tc.unpin {
+				setPinned = ms.UnpinState
+			}
+			s, err := setPinned(context.Background(), tc.state, tc.user)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assert.Equal(t, !tc.unpin, s.IsPinned(), "state pinned status")
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestRenameState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		state         string
+		user          string
+		newName       string
+		pendingRevert bool
+
+		renameErrOn []string
+
+		wantID  string
+		wantErr bool
+	}{
+		"Rename system snapshot, with linked user snapshots": {state: "rpool/ROOT/ubuntu_1234@snap1", newName: "renamed", wantID: "rpool/ROOT/ubuntu_1234@renamed"},
+		"Rename system snapshot, clones follow":              {state: "rpool/ROOT/ubuntu_1234@snap2", newName: "renamed", wantID: "rpool/ROOT/ubuntu_1234@renamed"},
+		"Rename user snapshot":                               {state: "rpool/USERDATA/root_bcde@snap3", user: "root", newName: "renamed", wantID: "rpool/USERDATA/root_bcde@renamed"},
+		"Rename system snapshot, pending revert follows":     {state: "rpool/ROOT/ubuntu_1234@snap1", newName: "renamed", pendingRevert: true, wantID: "rpool/ROOT/ubuntu_1234@renamed"},
+
+		"Error on unknown state":                {state: "doesntexist", newName: "renamed", wantErr: true},
+		"Error on state which isn't a snapshot": {state: "rpool/ROOT/ubuntu_5678", newName: "renamed", wantErr: true},
+		"Error on invalid name":                 {state: "rpool/ROOT/ubuntu_1234@snap1", newName: "-renamed", wantErr: true},
+		"Error on same name":                    {state: "rpool/ROOT/ubuntu_1234@snap1", newName: "snap1", wantErr: true},
+		"Error on existing state name":          {state: "rpool/ROOT/ubuntu_1234@snap1", newName: "snap3", wantErr: true},
+		"Error on rename failure reverts all":   {state: "rpool/ROOT/ubuntu_1234@snap1", newName: "renamed", renameErrOn: []string{"rpool/USERDATA/root_bcde@snap1"}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_snapshot_with_userdata_01.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			if tc.pendingRevert {
+				if _, err := ms.RevertSystemState(context.Background(), tc.state, true, nil); err != nil {
+					t.Fatalf("setup failed: couldn't schedule revert: %v", err)
+				}
+				if err := ms.Refresh(context.Background()); err != nil {
+					t.Fatalf("setup failed: couldn't refresh: %v", err)
+				}
+			}
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnRenameDS(tc.renameErrOn)
+
+			id, err := ms.RenameState(context.Background(), tc.state, tc.user, tc.newName)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assert.Equal(t, tc.wantID, id, "New state ID should match")
+			if tc.pendingRevert {
+				pendingID, _ := ms.PendingRevert()
+				assert.Equal(t, tc.wantID, pendingID, "Pending revert should follow the renamed state")
+			}
+			assertMachinesToGolden(t, ms)
+			assertMachinesNotEquals(t, initMachines, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestListStates(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		user        string
+		description string
+		labels      map[string]string
+
+		want    []string
+		wantErr bool
+	}{
+		"All system states":                   {want: []string{"rpool/ROOT/ubuntu_1234", "rpool/ROOT/ubuntu_1234@snap3", "rpool/ROOT/ubuntu_1234@snap2", "rpool/ROOT/ubuntu_1234@snap1"}},
+		"Filter on description":               {description: "VIM", want: []string{"rpool/ROOT/ubuntu_1234@snap1"}},
+		"Filter on label key":                 {labels: map[string]string{"origin": ""}, want: []string{"rpool/ROOT/ubuntu_1234@snap2", "rpool/ROOT/ubuntu_1234@snap1"}},
+		"Filter on label key and value":       {labels: map[string]string{"origin": "apt"}, want: []string{"rpool/ROOT/ubuntu_1234@snap1"}},
+		"Filter on multiple labels":           {labels: map[string]string{"origin": "", "packages": "vim"}, want: []string{"rpool/ROOT/ubuntu_1234@snap1"}},
+		"Filter on description and labels":    {description: "backup", labels: map[string]string{"origin": "apt"}},
+		"User states":                         {user: "user1", want: []string{"rpool/USERDATA/user1_abcd", "rpool/USERDATA/user1_abcd@usersnap", "rpool/USERDATA/user1_abcd@snap1"}},
+		"User states filtered by label":       {user: "user1", labels: map[string]string{"reason": ""}, want: []string{"rpool/USERDATA/user1_abcd@usersnap"}},
+		"User states filtered by description": {user: "user1", description: "vim", want: []string{"rpool/USERDATA/user1_abcd@snap1"}},
+
+		"Error on unknown user": {user: "doesntexist", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_metadata.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			states, err := ms.ListStates(context.Background(), tc.user, machines.StateFilter{Description: tc.description, Labels: tc.labels})
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			var got []string
+			for _, s := range states {
+				got = append(got, s.ID)
+			}
+			assert.Equal(t, tc.want, got, "Listed states should match")
+		})
+	}
+}
+
+func TestExportState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		state        string
+		user         string
+		target       string
+		toFile       bool
+		existingFile bool
+
+		wantErr bool
+	}{
+		"Export system state with linked user states": {state: "rpool/ROOT/ubuntu_1234@snap2", target: "backup/new"},
+		"Export system state incrementally":           {state: "rpool/ROOT/ubuntu_1234@snap2", target: "backup/previous"},
+		"Export already exported system state":        {state: "rpool/ROOT/ubuntu_1234@snap1", target: "backup/previous"},
+		"Export user state":                           {state: "rpool/USERDATA/user1_abcd@usersnap", user: "user1", target: "backup/new"},
+		"Export to a new dataset in an existing one":  {state: "rpool/ROOT/ubuntu_1234@snap2", target: "backup/empty/new"},
+		"Export system state to a file":               {state: "rpool/ROOT/ubuntu_1234@snap2", toFile: true},
+		"Export user state to a file":                 {state: "rpool/USERDATA/user1_abcd@usersnap", user: "user1", toFile: true},
+
+		"Error on current system state":                 {state: "rpool/ROOT/ubuntu_1234", target: "backup/new", wantErr: true},
+		"Error on unknown state":                        {state: "doesntexist", target: "backup/new", wantErr: true},
+		"Error on existing target dataset not exported": {state: "rpool/ROOT/ubuntu_1234@snap2", target: "backup/empty", wantErr: true},
+		"Error on missing parent of target dataset":     {state: "rpool/ROOT/ubuntu_1234@snap2", target: "backup/doesntexist/new", wantErr: true},
+		"Error on target dataset in a system container": {state: "rpool/ROOT/ubuntu_1234@snap2", target: "rpool/ROOT/backup", wantErr: true},
+		"Error on target dataset in a user container":   {state: "rpool/ROOT/ubuntu_1234@snap2", target: "rpool/USERDATA/backup", wantErr: true},
+		"Error on target dataset in a boot container":   {state: "rpool/ROOT/ubuntu_1234@snap2", target: "rpool/BOOT/backup", wantErr: true},
+		"Error on target without common snapshot":       {state: "rpool/ROOT/ubuntu_1234@snap1", target: "backup/unrelated", wantErr: true},
+		"Error on existing target file":                 {state: "rpool/ROOT/ubuntu_1234@snap2", toFile: true, existingFile: true, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_export.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			if tc.toFile {
+				tc.target = filepath.Join(dir, "state.export")
+			}
+			if tc.existingFile {
+				if err := ioutil.WriteFile(tc.target, []byte("existing"), 0600); err != nil {
+					t.Fatalf("couldn't create existing export file: %v", err)
+				}
+			}
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			err = ms.ExportState(context.Background(), tc.state, tc.user, tc.target)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			// Exported states are ignored by zsys
+			if diff := cmp.Diff(initMachines.AllMachines(), ms.AllMachines(), cmpopts.EquateEmpty(),
+				cmpopts.IgnoreUnexported(zfs.Dataset{}, zfs.DatasetProp{})); diff != "" {
+				t.Errorf("Machines mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.toFile {
+				b, err := ioutil.ReadFile(tc.target)
+				if err != nil {
+					t.Fatalf("couldn't read export file: %v", err)
+				}
+				got := string(b)
+				var want string
+				testutils.LoadFromGoldenFile(t, got, &want)
+				assert.Equal(t, want, got, "export file content")
+				return
+			}
+
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatalf("couldn't rescan zfs datasets: %v", err)
+			}
+			var got []zfs.Dataset
+			for _, d := range z.Datasets() {
+				if d.Name == tc.target || strings.HasPrefix(d.Name, tc.target+"/") {
+					got = append(got, *d)
+				}
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i].Name < got[j].Name })
+			var want []zfs.Dataset
+			testutils.LoadFromGoldenFile(t, got, &want)
+			if diff := cmp.Diff(want, got, cmpopts.EquateEmpty(), cmpopts.IgnoreUnexported(zfs.Dataset{}, zfs.DatasetProp{})); diff != "" {
+				t.Errorf("Exported datasets mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImportState(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def            string
+		source         string
+		name           string
+		fromExport     string
+		mountedDataset string
+
+		wantErr bool
+	}{
+		"Import state from a dataset":                       {source: "backup/previous", name: "oldsnap"},
+		"Import state from a dataset incrementally":         {def: "state_import_incremental.yaml", source: "backup/previous", name: "usersnap"},
+		"Import state from a dataset by its full name":      {source: "backup/previous", name: "rpool/ROOT/ubuntu_9999@oldsnap"},
+		"Import state from a dataset already on the system": {source: "backup/previous", name: "snap1"},
+		"Import state from a file":                          {def: "state_import.yaml", fromExport: "rpool/ROOT/ubuntu_1234@snap2"},
+
+		"Error on missing source dataset":       {source: "backup/doesntexist", name: "oldsnap", wantErr: true},
+		"Error on no state name from a dataset": {source: "backup/previous", wantErr: true},
+		"Error on unknown state in dataset":     {source: "backup/previous", name: "doesntexist", wantErr: true},
+		"Error on missing file":                 {source: "/doesntexist.export", wantErr: true},
+		"Error on file with existing datasets":  {fromExport: "rpool/ROOT/ubuntu_1234@snap2", wantErr: true},
+		"Error on importing onto a mounted filesystem": {def: "state_import_incremental.yaml", source: "backup/previous", name: "usersnap",
+			mountedDataset: "rpool/USERDATA/user1_abcd", wantErr: true},
+		"Error on destination with a newer snapshot than the source": {def: "state_import_incremental_diverged.yaml", source: "backup/previous", name: "usersnap", wantErr: true},
+		"Error on destination modified since its newest snapshot":    {def: "state_import_incremental_modified.yaml", source: "backup/previous", name: "usersnap", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			if tc.def == "" {
+				tc.def = "state_export.yaml"
+			}
+
+			// Export the state to a file from another system
+			if tc.fromExport != "" {
+				tc.source = filepath.Join(dir, "state.export")
+				exportDir := filepath.Join(dir, "export")
+				if err := os.MkdirAll(exportDir, 0700); err != nil {
+					t.Fatalf("couldn't create export pools directory: %v", err)
+				}
+				libzfs := testutils.GetMockZFS(t)
+				fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_export.yaml"), testutils.WithLibZFS(libzfs))
+				defer fPools.Create(exportDir)()
+				ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+				if err != nil {
+					t.Fatalf("expected success but got an error scanning for machines: %v", err)
+				}
+				if err := ms.ExportState(context.Background(), tc.fromExport, "", tc.source); err != nil {
+					t.Fatalf("couldn't export state to import: %v", err)
+				}
+			}
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+			if tc.mountedDataset != "" {
+				libzfs.(*mock.LibZFS).SetDatasetAsMounted(tc.mountedDataset, true)
+			}
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			if tc.def == "state_import.yaml" {
+				cmdline = generateCmdLine("rpool/ROOT/ubuntu_5678")
+			}
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			err = ms.ImportState(context.Background(), tc.source, tc.name)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				assertMachinesEquals(t, initMachines, ms)
+				return
+			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			assertMachinesToGolden(t, ms)
+
+			machinesAfterRescan, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestStateDiff(t *testing.T) {
+	t.Parallel()
+	changes := map[[2]string][]libzfsadapter.DiffEntry{
+		{"rpool/ROOT/ubuntu_1234@snap1", "rpool/ROOT/ubuntu_1234"}: {
+			{Change: libzfsadapter.DiffModified, Path: "/etc"},
+			{Change: libzfsadapter.DiffAdded, Path: "/etc/hostname"},
+			{Change: libzfsadapter.DiffRenamed, Path: "/etc/hosts", NewPath: "/etc/hosts.old"},
+			{Change: libzfsadapter.DiffRemoved, Path: "/etcetera"},
+			{Change: libzfsadapter.DiffRenamed, Path: "/tmp/hosts", NewPath: "/etc/hosts"},
+		},
+		{"rpool/ROOT/ubuntu_1234@snap1", "rpool/ROOT/ubuntu_5678"}: {
+			{Change: libzfsadapter.DiffRemoved, Path: "/var/log/syslog"},
+		},
+		{"rpool/USERDATA/user1_abcd@snap1", "rpool/USERDATA/user1_abcd"}: {
+			{Change: libzfsadapter.DiffAdded, Path: "/home/user1/.bashrc"},
+		},
+		{"rpool/USERDATA/user1_abcd@usersnap", "rpool/USERDATA/user1_abcd"}: {
+			{Change: libzfsadapter.DiffModified, Path: "/home/user1/.profile"},
+		},
+	}
+
+	tests := map[string]struct {
+		currentStateID string
+		from           string
+		to             string
+		user           string
+		withUsers      bool
+		pathFilter     string
+
+		wantErr bool
+	}{
+		"Snapshot to current state":            {from: "snap1"},
+		"Snapshot to clone":                    {from: "snap1", to: "5678"},
+		"Snapshot to current state with users": {from: "snap1", withUsers: true},
+		"User snapshot to current user state":  {from: "usersnap", user: "user1"},
+		"Filter on path":                       {from: "snap1", pathFilter: "/etc/"},
+		"Filter on path with renames":          {from: "snap1", pathFilter: "/tmp"},
+		"No change":                            {from: "rpool/ROOT/ubuntu_9999@snap9", to: "rpool/ROOT/ubuntu_9999"},
+
+		"Error on unknown state":          {from: "doesntexist", wantErr: true},
+		"Error on unknown target state":   {from: "snap1", to: "doesntexist", wantErr: true},
+		"Error on comparing from a clone": {from: "5678", wantErr: true},
+		"Error on comparing to itself":    {from: "snap1", to: "rpool/ROOT/ubuntu_1234@snap1", wantErr: true},
+		"Error on no current machine":     {currentStateID: "-", from: "snap1", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_revert.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+			lzfs := libzfs.(*mock.LibZFS)
+			for k, v := range changes {
+				lzfs.SetDatasetDiff(k[0], k[1], v)
+			}
+
+			ms, err := machines.New(context.Background(), generateCmdLine(getDefaultValue(tc.currentStateID, "rpool/ROOT/ubuntu_1234")), machines.WithLibZFS(libzfs))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			var got []machines.DatasetDiff
+			err = ms.StateDiff(context.Background(), tc.from, tc.to, tc.user, tc.withUsers, tc.pathFilter, func(d machines.DatasetDiff) error {
+				got = append(got, d)
+				return nil
+			})
+			if tc.wantErr {
+				assert.Error(t, err, "StateDiff should have failed")
+				return
+			}
+			assert.NoError(t, err, "StateDiff shouldn't have failed")
+
+			var want []machines.DatasetDiff
+			testutils.LoadFromGoldenFile(t, got, &want)
+			assert.Equal(t, want, got, "StateDiff returned value")
+		})
+	}
+}
+
+func TestStateDiffSendsBatches(t *testing.T) {
+	t.Parallel()
+	dir, cleanup := testutils.TempDir(t)
+	defer cleanup()
+
+	libzfs := testutils.GetMockZFS(t)
+	fPools := testutils.NewFakePools(t, filepath.Join("testdata", "state_revert.yaml"), testutils.WithLibZFS(libzfs))
+	defer fPools.Create(dir)()
+	var changes []libzfsadapter.DiffEntry
+	for i := 0; i < machines.DiffBatchSize+1; i++ {
+		changes = append(changes, libzfsadapter.DiffEntry{Change: libzfsadapter.DiffAdded, Path: fmt.Sprintf("/etc/file%d", i)})
+	}
+	libzfs.(*mock.LibZFS).SetDatasetDiff("rpool/ROOT/ubuntu_1234@snap1", "rpool/ROOT/ubuntu_1234", changes)
+
+	ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs))
+	if err != nil {
+		t.Error("expected success but got an error scanning for machines", err)
+	}
+
+	var got []machines.DatasetDiff
+	err = ms.StateDiff(context.Background(), "snap1", "", "", false, "", func(d machines.DatasetDiff) error {
+		got = append(got, d)
+		return nil
+	})
+	assert.NoError(t, err, "StateDiff shouldn't have failed")
+
+	var sent []libzfsadapter.DiffEntry
+	for _, d := range got {
+		assert.True(t, len(d.Changes) <= machines.DiffBatchSize, "Changes are sent by batches")
+		if d.From == "rpool/ROOT/ubuntu_1234@snap1" {
+			sent = append(sent, d.Changes...)
+		}
+	}
+	assert.Equal(t, changes, sent, "All changes of a dataset are sent in order")
+
+	err = ms.StateDiff(context.Background(), "snap1", "", "", false, "", func(machines.DatasetDiff) error {
+		return errors.New("Send error")
+	})
+	assert.Error(t, err, "StateDiff should fail when sending fails")
+}
+
+func TestGC(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def        string
+		all        bool
+		configPath string
+
+		destroyErrDS    []string
+		setPoolCapacity string
+		setPoolSize     string
+
+		isNoOp  bool
+		wantErr bool
+	}{
+		/***** System states only tests *****/
+		"Follow bucket policy":                       {def: "gc_system_only.yaml"},
+		"Follow bucket policy with one empty bucket": {def: "gc_system_only.yaml", configPath: "one_empty_bucket.conf"},
+		"Existing buckets have enough capacity":      {def: "gc_system_only.yaml", configPath: "not_enough_snapshots.conf"},
+
+		"No snapshot, keep everything":                         {def: "m_with_userdata.yaml", isNoOp: true},
+		"Keep previous and current day, purge everything else": {def: "gc_system_only.yaml", configPath: "purge_all_zsys.conf"},
+		"Keep current day, purge everything else":              {def: "gc_system_only.yaml", configPath: "purge_but_previous_zsys.conf"},
+		"Non zsys systems are ignored":                         {def: "gc_system_only_non_zsys.yaml", isNoOp: true},
+		"Keep more snapshots than simply last day has":         {def: "gc_system_only.yaml", configPath: "keep_many_snapshots.conf"},
+
+		"Manual snapshot which should be deleted is kept":     {def: "gc_system_only_with_manual_snapshot.yaml"},
+		"Manual snapshot which should be deleted isnt't kept": {def: "gc_system_only_with_manual_snapshot.yaml", all: true},
+		"Pinned snapshot is kept":                             {def: "gc_system_only_with_pinned.yaml"},
+		"Pinned snapshot is kept even when collecting all":    {def: "gc_system_only_with_pinned.yaml", all: true},
+		"Held snapshot is kept":                               {def: "gc_system_only_with_held.yaml"},
+		"Held snapshot is kept even when collecting all":      {def: "gc_system_only_with_held.yaml", all: true},
+
+		"Clone and dependencies are collected within the same bucket":                  {def: "gc_system_only_with_clone_same_bucket.yaml"},
+		"Manual clone without last used is collected":                                  {def: "gc_system_only_with_manual_clone.yaml"},
+		"Clone having snapshots and dependencies are collected within the same bucket": {def: "gc_system_only_with_clone_same_bucket_with_dep.yaml"},
+		"Keep clone and dependencies having manual snapshots":                          {def: "gc_system_only_with_clone_same_bucket_with_manual_dep.yaml"},
+		"Clone cloned and all dependencies are collected within the same bucket":       {def: "gc_system_only_with_clone_same_bucket_with_clone.yaml"},
+		"Clone and dependencies are collected trans bucket (clone after snapshot)":     {def: "gc_system_only_with_clone_different_buckets.yaml"},
+		"Clone with dependencies in bucket before, same and after":                     {def: "gc_system_only_with_clone_and_snapshots_different_buckets.yaml"},
+
+		"Subdataset with some snapshots shared with it":              {def: "gc_system_only_with_children.yaml"},
+		"Subdataset with some snapshots only it are kept":            {def: "gc_system_only_with_children_snapshots_only_on_subdataset.yaml"},
+		"Subdataset with snapshot keeps clone":                       {def: "gc_system_only_with_clone_same_bucket_with_snapshot_on_subdataset.yaml"},
+		"Subdataset and clone are collected":                         {def: "gc_system_only_with_clone_same_bucket_with_snapshot_on_subdataset_and_main_state.yaml"},
+		"Subdataset with manual clone on subdataset isn’t collected": {def: "gc_system_only_with_clone_same_bucket_with_snapshot_on_subdataset_and_main_state_with_manual_clone_on_subdataset.yaml"},
+
+		/***** User states tests *****/
+		"Follow bucket policy with users":                      {def: "gc_system_with_users.yaml"},
+		"Follow bucket policy with users and one empty bucket": {def: "gc_system_with_users_one_empty_bucket.yaml", configPath: "one_empty_bucket.conf", isNoOp: true},
+		"Keep more user snapshots than simply last day has":    {def: "gc_system_with_users.yaml", configPath: "keep_many_snapshots.conf"},
+		"Users have their own history rules":                   {def: "gc_system_with_users.yaml", configPath: "history_users_keep_many_snapshots.conf"},
+		"Users have rules overridden per user":                 {def: "gc_system_with_users.yaml", configPath: "history_peruser_keep_many_snapshots.conf"},
+		"System has its own history rules":                     {def: "gc_system_with_users.yaml", configPath: "history_system_purge_all.conf"},
+		"Calendar strategy keeps newest state per day":         {def: "gc_system_with_users.yaml", configPath: "history_strategy_calendar.conf"},
+		"Max age strategy removes old states":                  {def: "gc_system_with_users.yaml", configPath: "history_strategy_maxage.conf"},
+		"Max count strategy keeps most recent states":          {def: "gc_system_with_users.yaml", configPath: "history_strategy_maxcount.conf"},
+
+		// User clones
+		"Remove user clone state":                                                                     {def: "gc_system_with_users_clone.yaml"},
+		"Remove user clone state with subdataset":                                                     {def: "gc_system_with_users_clone_subdataset.yaml"},
+		"Don't remove user clone state with snapshot on it kept":                                      {def: "gc_system_with_users_clone_with_manual_snapshot.yaml", isNoOp: true},
+		"Don't remove user clone state with snapshot on subdataset":                                   {def: "gc_system_with_users_clone_subdataset_with_manual_snapshot.yaml", isNoOp: true},
+		"Don't remove user clone state linked to a system state":                                      {def: "gc_system_with_users_clone_linked_to_system_state.yaml", isNoOp: true},
+		"Ensure user clones are accounted by policy":                                                  {def: "gc_system_with_users_with_untagged_clones.yaml"},
+		"Remove unassociated user clone after deleting its snapshot":                                  {def: "gc_system_with_users_clone_with_auto_snapshot.yaml"},
+		"Remove unassociated user clone after deleting its snapshot which was linked to system state": {def: "gc_system_with_users_clone_with_auto_snapshot_attached_to_system_state.yaml"},
+
+		// User clone attached to multiple states/machines
+		"Users and clones on shared system state":                     {def: "gc_system_with_users_and_clones_shared_system_state.yaml"},
+		"Users and clones on different machines history":              {def: "gc_system_with_users_and_clones_different_machines_history_only.yaml"},
+		"Users and clones on different machines, one is active state": {def: "gc_system_with_users_and_clones_different_machines.yaml"},
+
+		// Unlinked user test cases
+		"Keep unlinked user dataset on pool not configured for user data":                 {def: "gc_system_with_unlinked_users.yaml", configPath: "pools_separate.conf", isNoOp: true},
+		"Remove simple unlinked user dataset":                                             {def: "gc_system_with_unlinked_users.yaml"},
+		"Remove unlinked user dataset and any snapshot":                                   {def: "gc_system_with_unlinked_users_and_snapshot.yaml"},
+		"Remove unlinked user dataset and any unmanaged user clone":                       {def: "gc_system_with_unlinked_users_unmanaged_clone.yaml"},
+		"Can't remove unlinked user dataset if it has a manual clone in USERDATA":         {def: "gc_system_with_unlinked_users_unmanaged_clone_nobootfs_dataset.yaml", isNoOp: true},
+		"Can't remove unlinked user dataset if it has a manual clone outside of USERDATA": {def: "gc_system_with_unlinked_users_unmanaged_user_clone.yaml", isNoOp: true},
+		"Remove unlinked clone of a manual filesystem dataset":                            {def: "gc_system_with_unlinked_users_unmanaged_clone_bootfs_on_clone.yaml"},
+
+		// Failed revert
+		"Failed revert - no lastused on system":        {def: "gc_system_failed_revert.yaml"},
+		"Failed boot - no lastused on user and system": {def: "gc_system_with_users_failed_boot.yaml"},
+
+		// Deletion prevention (no infinite loop)
+		"Manual user snapshot which should be deleted is kept": {def: "gc_system_with_users_manual_snapshots.yaml", isNoOp: true},
+		"Users and clones with undeletable snapshot":           {def: "gc_system_with_users_and_clones_undeletable_snapshot.yaml"},
+		"Destroy failed on system dataset":                     {def: "gc_system_with_users_clone_with_auto_snapshot_attached_to_system_state.yaml", destroyErrDS: []string{"rpool/ROOT/ubuntu_1234@autozsys_20191230-1710"}, isNoOp: true},
+		"Destroy failed on user dataset":                       {def: "gc_system_with_users_clone.yaml", destroyErrDS: []string{"rpool/USERDATA/user1_clone"}, isNoOp: true},
+		"Destroy failed on unlinked user dataset":              {def: "gc_system_with_unlinked_users_unmanaged_clone_bootfs_on_clone.yaml", destroyErrDS: []string{"rpool/USERDATA/user2_clone"}, isNoOp: true},
+
+		// Space based policy
+		"Remove oldest states until pool free space target is reached": {def: "gc_space.yaml", configPath: "space_target.conf", setPoolCapacity: "85", setPoolSize: "100000"},
+		"Remove states bigger than max state size":                     {def: "gc_space.yaml", configPath: "space_max_state_size.conf"},
+		"Pool free space target already reached":                       {def: "gc_space.yaml", configPath: "space_target.conf", setPoolSize: "100000", isNoOp: true},
+		"Free space target not reachable keeps protected states":       {def: "gc_space.yaml", configPath: "space_target.conf", setPoolCapacity: "99", setPoolSize: "100000000"},
+		"Destroy failed on state removed for space":                    {def: "gc_space.yaml", configPath: "space_max_state_size.conf", destroyErrDS: []string{"rpool/ROOT/ubuntu_1234@autozsys_20191210-1000"}, isNoOp: true},
+
+		// Error cases
+		"Error fails to destroy state are kept": {def: "gc_system_with_users.yaml", destroyErrDS: []string{}, isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			if tc.configPath == "" {
+				tc.configPath = "default.conf"
+			}
+			tc.configPath = filepath.Join("testdata", "confs", tc.configPath)
+
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatalf("couldn’t create original zfs datasets state")
+			}
+			for _, d := range z.Datasets() {
+				if d.BootfsDatasets == "-" {
+					tz, _ := z.NewTransaction(context.Background())
+					defer tz.Done()
+					if err := tz.SetProperty(libzfsadapter.BootfsDatasetsProp, "", d.Name, false); err != nil {
+						t.Fatalf("couldn’t erase  BootfsDatasetsProp: %v", err)
+					}
+					tz.Done()
+				}
+			}
+			ms, err := machines.New(context.Background(), "", machines.WithLibZFS(libzfs),
+				machines.WithTime(testutils.FixedTime{}), machines.WithConfig(tc.configPath))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+
+			initMachines := ms.CopyForTests(t)
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ErrOnDestroyDS(tc.destroyErrDS)
+			if tc.setPoolCapacity != "" {
+				lzfs.SetPoolCapacity("rpool", tc.setPoolCapacity)
+			}
+			if tc.setPoolSize != "" {
+				lzfs.SetPoolSize("rpool", tc.setPoolSize)
+			}
+
+			err = ms.GC(context.Background(), tc.all)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			} else {
+				assertMachinesToGolden(t, ms)
+				assertMachinesNotEquals(t, initMachines, ms)
+			}
+
+			machinesAfterRescan, err := machines.New(context.Background(), "", machines.WithLibZFS(libzfs), machines.WithConfig(tc.configPath))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			assertMachinesEquals(t, machinesAfterRescan, ms)
+		})
+	}
+}
+
+func TestGCDryRun(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def             string
+		all             bool
+		configPath      string
+		setPoolCapacity string
+		setPoolSize     string
+	}{
+		"Follow bucket policy":                                {def: "gc_system_only.yaml"},
+		"Follow bucket policy with users":                     {def: "gc_system_with_users.yaml"},
+		"Manual snapshot which should be deleted is kept":     {def: "gc_system_only_with_manual_snapshot.yaml"},
+		"Manual snapshot which should be deleted isnt't kept": {def: "gc_system_only_with_manual_snapshot.yaml", all: true},
+		"Clone and dependencies are collected":                {def: "gc_system_only_with_clone_same_bucket.yaml"},
+		"Keep clone and dependencies having manual snapshots": {def: "gc_system_only_with_clone_same_bucket_with_manual_dep.yaml"},
+		"Unlinked user datasets":                              {def: "gc_system_with_unlinked_users_unmanaged_clone.yaml"},
+		"Unlinked user datasets with dependencies are kept":   {def: "gc_system_with_unlinked_users_unmanaged_user_clone.yaml"},
+		"Space based policy":                                  {def: "gc_space.yaml", configPath: "space_target.conf", setPoolCapacity: "85", setPoolSize: "100000"},
+		"No snapshot, keep everything":                        {def: "m_with_userdata.yaml"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			if tc.configPath == "" {
+				tc.configPath = "default.conf"
+			}
+			tc.configPath = filepath.Join("testdata", "confs", tc.configPath)
+
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(libzfs))
+			if err != nil {
+				t.Fatalf("couldn’t create original zfs datasets state")
 			}
 			for _, d := range z.Datasets() {
 				if d.BootfsDatasets == "-" {
@@ -1439,18 +2857,203 @@ func TestGC(t *testing.T) {
 
 			initMachines := ms.CopyForTests(t)
 			lzfs := libzfs.(*mock.LibZFS)
-			lzfs.ErrOnDestroyDS(tc.destroyErrDS)
+			if tc.setPoolCapacity != "" {
+				lzfs.SetPoolCapacity("rpool", tc.setPoolCapacity)
+			}
+			if tc.setPoolSize != "" {
+				lzfs.SetPoolSize("rpool", tc.setPoolSize)
+			}
 
-			err = ms.GC(context.Background(), tc.all)
+			got, err := ms.GCDryRun(context.Background(), tc.all)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if got == nil {
+				got = []machines.GCDecision{}
+			}
+			// Goldens don't depend on the local timezone.
+			for i := range got {
+				got[i].LastUsed = got[i].LastUsed.UTC()
+			}
+
+			want := []machines.GCDecision{}
+			testutils.LoadFromGoldenFile(t, got, &want)
+			assert.Equal(t, want, got, "GC decisions should match")
+
+			assertMachinesEquals(t, initMachines, ms)
+
+			// Any state the dry run plans to remove is removed by the real GC.
+			if err := ms.GC(context.Background(), tc.all); err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			remaining := make(map[string]bool)
+			for _, m := range ms.SortedMachines() {
+				remaining[m.ID] = true
+				for id := range m.History {
+					remaining[id] = true
+				}
+				for _, states := range m.AllUsersStates {
+					for id := range states {
+						remaining[id] = true
+					}
+				}
+			}
+			for _, d := range got {
+				if d.Remove && d.Machine != "" {
+					assert.False(t, remaining[d.State], "State %s planned for removal should be removed by GC", d.State)
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateGC(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def        string
+		cmdline    string
+		configPath string
+		days       int
+		rate       float64
+
+		wantErr bool
+	}{
+		"Current states only":                  {def: "gc_system_with_users.yaml", days: 10},
+		"One save per day":                     {def: "gc_system_with_users.yaml", days: 30, rate: 1},
+		"Several saves per day":                {def: "gc_system_with_users.yaml", days: 14, rate: 4},
+		"Save every other day":                 {def: "gc_system_only.yaml", days: 20, rate: 0.5},
+		"Follow configured strategy":           {def: "gc_system_with_users.yaml", configPath: "history_strategy_maxcount.conf", days: 10, rate: 2},
+		"Users have their own history rules":   {def: "gc_system_with_users.yaml", configPath: "history_users_keep_many_snapshots.conf", days: 10, rate: 2},
+		"Space based rules aren't simulated":   {def: "gc_system_with_users.yaml", configPath: "space_max_state_size.conf", days: 5, rate: 1},
+		"Pinned states are never removed":      {def: "gc_system_only_with_pinned.yaml", days: 30, rate: 1},
+		"Manual snapshots are never removed":   {def: "gc_system_only_with_manual_snapshot.yaml", days: 30, rate: 1},
+		"Clone and dependencies are simulated": {def: "gc_system_only_with_clone_same_bucket.yaml", days: 30, rate: 1},
+
+		"Error on no day to simulate":      {def: "gc_system_with_users.yaml", days: 0, rate: 1, wantErr: true},
+		"Error on negative rate":           {def: "gc_system_with_users.yaml", days: 10, rate: -1, wantErr: true},
+		"Error on non zsys current system": {def: "gc_system_with_users.yaml", cmdline: "aaaaa bbbbb root=/dev/sda1 ccccc", days: 10, rate: 1, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			if tc.cmdline == "" {
+				tc.cmdline = generateCmdLine("rpool/ROOT/ubuntu_1234")
+			}
+			tc.configPath = filepath.Join("testdata", "confs", getDefaultValue(tc.configPath, "default.conf"))
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs),
+				machines.WithTime(testutils.FixedTime{}), machines.WithConfig(tc.configPath))
+			if err != nil {
+				t.Fatalf("expected success but got an error scanning for machines: %v", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			got, err := ms.SimulateGC(context.Background(), tc.days, tc.rate)
 			if err != nil {
 				if !tc.wantErr {
 					t.Fatalf("expected no error but got: %v", err)
 				}
 				return
 			}
+			if tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			// Goldens don't depend on the local timezone.
+			for i := range got.Days {
+				got.Days[i].Date = got.Days[i].Date.UTC()
+			}
+			for i := range got.Removals {
+				got.Removals[i].LastUsed = got.Removals[i].LastUsed.UTC()
+				got.Removals[i].RemovedOn = got.Removals[i].RemovedOn.UTC()
+			}
+
+			var want machines.GCSimulation
+			testutils.LoadFromGoldenFile(t, got, &want)
+			assert.Equal(t, want, got, "GC simulation should match")
+
+			// Nothing is saved or removed on the system.
+			if err := ms.Refresh(context.Background()); err != nil {
+				t.Fatalf("expected no error on refresh but got: %v", err)
+			}
+			assertMachinesEquals(t, initMachines, ms)
+		})
+	}
+}
+
+func TestRunSchedule(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def        string
+		configPath string
+		cmdline    string
+
+		wantSystemSaved bool
+		wantErr         bool
+		isNoOp          bool
+	}{
+		"No rule":                       {def: "schedule.yaml", isNoOp: true},
+		"System state is due":           {def: "schedule.yaml", configPath: "schedule_system_due.conf", wantSystemSaved: true},
+		"System state isn't due":        {def: "schedule.yaml", configPath: "schedule_system_not_due.conf", isNoOp: true},
+		"Only due user states":          {def: "schedule.yaml", configPath: "schedule_users.conf"},
+		"Specific users":                {def: "schedule.yaml", configPath: "schedule_specific_users.conf"},
+		"Specific users aren't due":     {def: "schedule.yaml", configPath: "schedule_users_not_due.conf", isNoOp: true},
+		"Invalid rules are skipped":     {def: "schedule.yaml", configPath: "schedule_invalid_rules.conf", isNoOp: true},
+		"Non zsys machine is a no-op":   {def: "m_with_userdata_no_zsys.yaml", configPath: "schedule_system_due.conf", isNoOp: true},
+		"Error on unknown user":         {def: "schedule.yaml", configPath: "schedule_unknown_user.conf", wantErr: true, isNoOp: true},
+		"No current machine is a no-op": {def: "schedule.yaml", configPath: "schedule_system_due.conf", cmdline: generateCmdLine("rpool/ROOT/nomachine"), isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			if tc.configPath == "" {
+				tc.configPath = "default.conf"
+			}
+			tc.configPath = filepath.Join("testdata", "confs", tc.configPath)
+			if tc.cmdline == "" {
+				tc.cmdline = generateCmdLine("rpool/ROOT/ubuntu_1234")
+			}
+
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs),
+				machines.WithTime(testutils.FixedTime{}), machines.WithConfig(tc.configPath))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			lzfs := libzfs.(*mock.LibZFS)
+			lzfs.ForceLastUsedTime(true)
+
+			initMachines := ms.CopyForTests(t)
+
+			systemSaved, err := ms.RunSchedule(context.Background())
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("expected no error but got: %v", err)
+				}
+			}
 			if err == nil && tc.wantErr {
 				t.Fatal("expected an error but got none")
 			}
+			if systemSaved != tc.wantSystemSaved {
+				t.Errorf("system state saved: want %v, got %v", tc.wantSystemSaved, systemSaved)
+			}
 
 			if tc.isNoOp {
 				assertMachinesEquals(t, initMachines, ms)
@@ -1459,7 +3062,7 @@ func TestGC(t *testing.T) {
 				assertMachinesNotEquals(t, initMachines, ms)
 			}
 
-			machinesAfterRescan, err := machines.New(context.Background(), "", machines.WithLibZFS(libzfs))
+			machinesAfterRescan, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs))
 			if err != nil {
 				t.Error("expected success but got an error scanning for machines", err)
 			}
@@ -1468,6 +3071,293 @@ func TestGC(t *testing.T) {
 	}
 }
 
+func TestStatesObserver(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		def     string
+		action  func(ms *machines.Machines) error
+		cmdline string
+
+		want []machines.StateChange
+	}{
+		"Save system state": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error {
+				_, err := ms.CreateSystemSnapshot(context.Background(), "snap1", "", nil)
+				return err
+			},
+			want: []machines.StateChange{
+				{Machine: "rpool/ROOT/ubuntu_1234", ID: "rpool/ROOT/ubuntu_1234@snap1"},
+				{Machine: "rpool/ROOT/ubuntu_1234", ID: "rpool/USERDATA/root_bcde@snap1", User: "root"},
+				{Machine: "rpool/ROOT/ubuntu_1234", ID: "rpool/USERDATA/user1_abcd@snap1", User: "user1"},
+			}},
+		"Save user state": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error {
+				_, err := ms.CreateUserSnapshot(context.Background(), "user1", "snap1", "", nil)
+				return err
+			},
+			want: []machines.StateChange{
+				{Machine: "rpool/ROOT/ubuntu_1234", ID: "rpool/USERDATA/user1_abcd@snap1", User: "user1"},
+			}},
+		"Remove system state": {def: "state_snapshot_with_userdata_01.yaml",
+			action: func(ms *machines.Machines) error {
+				return ms.RemoveState(context.Background(), "rpool/ROOT/ubuntu_1234@snap1", "", true, false)
+			},
+			want: []machines.StateChange{
+				{Machine: "rpool/ROOT/ubuntu_1234", ID: "rpool/ROOT/ubuntu_1234@snap1", Removed: true},
+			}},
+		"Refresh without change": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error { return ms.Refresh(context.Background()) }},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			if tc.cmdline == "" {
+				tc.cmdline = generateCmdLine("rpool/ROOT/ubuntu_1234")
+			}
+
+			var got []machines.StateChange
+			ms, err := machines.New(context.Background(), tc.cmdline, machines.WithLibZFS(libzfs),
+				machines.WithStatesObserver(func(changes []machines.StateChange) { got = append(got, changes...) }))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			if got != nil {
+				t.Fatalf("initial scan shouldn't notify any change, got: %v", got)
+			}
+
+			if err := tc.action(&ms); err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			assert.Equal(t, tc.want, got, "States changes should match")
+		})
+	}
+}
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	// record appends the hook event and its environment to the output file.
+	const record = `echo "$ZSYS_EVENT [$ZSYS_STATE_ID] [$ZSYS_USER] [$ZSYS_DATASETS]" >> {{out}}`
+
+	tests := map[string]struct {
+		def    string
+		action func(ms *machines.Machines) error
+		hooks  map[string]string
+
+		wantCalls string
+		wantErr   bool
+		isNoOp    bool
+	}{
+		"Save system state": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error {
+				_, err := ms.CreateSystemSnapshot(context.Background(), "snap1", "", nil)
+				return err
+			},
+			hooks: map[string]string{"pre-save": record, "post-save": record},
+			wantCalls: "pre-save [rpool/ROOT/ubuntu_1234@snap1] [] [rpool/ROOT/ubuntu_1234 rpool/USERDATA/root_bcde rpool/USERDATA/user1_abcd]\n" +
+				"post-save [rpool/ROOT/ubuntu_1234@snap1] [] [rpool/ROOT/ubuntu_1234 rpool/USERDATA/root_bcde rpool/USERDATA/user1_abcd]\n"},
+		"Save user state": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error {
+				_, err := ms.CreateUserSnapshot(context.Background(), "user1", "snap1", "", nil)
+				return err
+			},
+			hooks: map[string]string{"pre-save": record, "post-save": record},
+			wantCalls: "pre-save [rpool/USERDATA/user1_abcd@snap1] [user1] [rpool/USERDATA/user1_abcd]\n" +
+				"post-save [rpool/USERDATA/user1_abcd@snap1] [user1] [rpool/USERDATA/user1_abcd]\n"},
+		"Remove system state": {def: "state_snapshot_with_userdata_01.yaml",
+			action: func(ms *machines.Machines) error {
+				return ms.RemoveState(context.Background(), "rpool/ROOT/ubuntu_1234@snap1", "", true, false)
+			},
+			hooks: map[string]string{"pre-remove": record, "post-remove": record},
+			wantCalls: "pre-remove [rpool/ROOT/ubuntu_1234@snap1] [] [bpool/BOOT/ubuntu_1234/grub@snap1 bpool/BOOT/ubuntu_1234@snap1 rpool/ROOT/ubuntu_1234/opt@snap1 rpool/ROOT/ubuntu_1234@snap1]\n" +
+				"post-remove [rpool/ROOT/ubuntu_1234@snap1] [] [bpool/BOOT/ubuntu_1234/grub@snap1 bpool/BOOT/ubuntu_1234@snap1 rpool/ROOT/ubuntu_1234/opt@snap1 rpool/ROOT/ubuntu_1234@snap1]\n"},
+		"Dry run removal doesn't run hooks": {def: "state_snapshot_with_userdata_01.yaml",
+			action: func(ms *machines.Machines) error {
+				return ms.RemoveState(context.Background(), "rpool/ROOT/ubuntu_1234@snap1", "", true, true)
+			},
+			hooks:  map[string]string{"pre-remove": record, "post-remove": record},
+			isNoOp: true},
+		"Garbage collection": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error { return ms.GC(context.Background(), false) },
+			hooks:  map[string]string{"post-gc": record}, wantCalls: "post-gc [] [] []\n"},
+		"Failing post hook doesn't fail the operation": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error {
+				_, err := ms.CreateUserSnapshot(context.Background(), "user1", "snap1", "", nil)
+				return err
+			},
+			hooks:     map[string]string{"post-save": record + "; exit 1"},
+			wantCalls: "post-save [rpool/USERDATA/user1_abcd@snap1] [user1] [rpool/USERDATA/user1_abcd]\n"},
+
+		"Error on failing pre save hook aborts save": {def: "m_with_userdata.yaml",
+			action: func(ms *machines.Machines) error {
+				_, err := ms.CreateSystemSnapshot(context.Background(), "snap1", "", nil)
+				return err
+			},
+			hooks:     map[string]string{"pre-save": record + "; exit 1", "post-save": record},
+			wantCalls: "pre-save [rpool/ROOT/ubuntu_1234@snap1] [] [rpool/ROOT/ubuntu_1234 rpool/USERDATA/root_bcde rpool/USERDATA/user1_abcd]\n",
+			wantErr:   true, isNoOp: true},
+		"Error on failing pre remove hook aborts removal": {def: "state_snapshot_with_userdata_01.yaml",
+			action: func(ms *machines.Machines) error {
+				return ms.RemoveState(context.Background(), "rpool/ROOT/ubuntu_1234@snap1", "", true, false)
+			},
+			hooks:   map[string]string{"pre-remove": "exit 1", "post-remove": record},
+			wantErr: true, isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			out := filepath.Join(dir, "calls")
+			hooksDir := filepath.Join(dir, "hooks.d")
+			for event, content := range tc.hooks {
+				if err := os.MkdirAll(filepath.Join(hooksDir, event+".d"), 0755); err != nil {
+					t.Fatalf("couldn't create hooks directory: %v", err)
+				}
+				script := "#!/bin/sh\n" + strings.ReplaceAll(content, "{{out}}", out) + "\n"
+				if err := ioutil.WriteFile(filepath.Join(hooksDir, event+".d", "01-hook"), []byte(script), 0755); err != nil {
+					t.Fatalf("couldn't write %s hook: %v", event, err)
+				}
+			}
+			conf := filepath.Join(dir, "zsys.conf")
+			if err := ioutil.WriteFile(conf, []byte(fmt.Sprintf("hooks:\n  dir: %s\n  timeout: 5\n", hooksDir)), 0644); err != nil {
+				t.Fatalf("couldn't write configuration: %v", err)
+			}
+
+			cmdline := generateCmdLine("rpool/ROOT/ubuntu_1234")
+			ms, err := machines.New(context.Background(), cmdline, machines.WithLibZFS(libzfs), machines.WithConfig(conf),
+				machines.WithTime(testutils.FixedTime{}))
+			if err != nil {
+				t.Error("expected success but got an error scanning for machines", err)
+			}
+			initMachines := ms.CopyForTests(t)
+
+			err = tc.action(&ms)
+			if tc.wantErr {
+				assert.Error(t, err, "Operation should have failed")
+			} else {
+				assert.NoError(t, err, "Operation shouldn't have failed")
+			}
+
+			calls, err := ioutil.ReadFile(out)
+			if err != nil && !os.IsNotExist(err) {
+				t.Fatalf("couldn't read hooks calls: %v", err)
+			}
+			assert.Equal(t, tc.wantCalls, string(calls), "Hooks calls should match")
+
+			if tc.isNoOp {
+				assertMachinesEquals(t, initMachines, ms)
+			}
+		})
+	}
+}
+
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		configPath string
+
+		wantSystemKeepLast int
+		wantUser1KeepLast  int
+		wantUser2KeepLast  int
+		wantErr            string
+	}{
+		"Top level rules apply to every scope": {configPath: "default.conf", wantSystemKeepLast: 3, wantUser1KeepLast: 3, wantUser2KeepLast: 3},
+		"Users rules override system ones":     {configPath: "history_users_keep_many_snapshots.conf", wantSystemKeepLast: 3, wantUser1KeepLast: 15, wantUser2KeepLast: 15},
+		"Per user rules override users ones":   {configPath: "history_peruser_keep_many_snapshots.conf", wantSystemKeepLast: 3, wantUser1KeepLast: 3, wantUser2KeepLast: 15},
+		"System rules don't apply to users":    {configPath: "history_system_purge_all.conf", wantSystemKeepLast: 0, wantUser1KeepLast: 3, wantUser2KeepLast: 3},
+
+		"Drop-in fragments are merged over the configuration": {configPath: "config_dropins.conf", wantSystemKeepLast: 3, wantUser1KeepLast: 7, wantUser2KeepLast: 15},
+
+		"Error on invalid rules keeps previous configuration":    {configPath: "history_invalid_rules.conf", wantSystemKeepLast: 20, wantUser1KeepLast: 20, wantUser2KeepLast: 20, wantErr: "needs at least one bucket"},
+		"Error on unknown strategy keeps previous configuration": {configPath: "history_unknown_strategy.conf", wantSystemKeepLast: 20, wantUser1KeepLast: 20, wantUser2KeepLast: 20, wantErr: "unknown strategy"},
+		"Error on unknown element with its line":                 {configPath: "config_unknown_key.conf", wantSystemKeepLast: 20, wantUser1KeepLast: 20, wantUser2KeepLast: 20, wantErr: "line 8: field samplesperbuckets not found"},
+		"Error on duplicated element with its line":              {configPath: "config_duplicated_key.conf", wantSystemKeepLast: 20, wantUser1KeepLast: 20, wantUser2KeepLast: 20, wantErr: "line 4: field keeplast already set"},
+		"Error on free space out of range":                       {configPath: "config_free_space_out_of_range.conf", wantSystemKeepLast: 20, wantUser1KeepLast: 20, wantUser2KeepLast: 20, wantErr: "between 0 and 100"},
+		"Error on rules defined twice":                           {configPath: "config_duplicated_rules.conf", wantSystemKeepLast: 20, wantUser1KeepLast: 20, wantUser2KeepLast: 20, wantErr: "defined more than once"},
+		"Error on invalid drop-in fragment with its line":        {configPath: "config_invalid_dropin.conf", wantSystemKeepLast: 20, wantUser1KeepLast: 20, wantUser2KeepLast: 20, wantErr: "10-users.conf: yaml: unmarshal errors:\n  line 4: field keeplasts not found"},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			libzfs := testutils.GetMockZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", "gc_system_with_users.yaml"), testutils.WithLibZFS(libzfs))
+			defer fPools.Create(dir)()
+
+			// Start from the default configuration, then reload the one under test from the same path.
+			conf := filepath.Join(dir, "zsys.conf")
+			if err := ioutil.WriteFile(conf, []byte("history:\n  keeplast: 20\n"), 0644); err != nil {
+				t.Fatalf("couldn't write configuration: %v", err)
+			}
+			ms, err := machines.New(context.Background(), generateCmdLine("rpool/ROOT/ubuntu_1234"), machines.WithLibZFS(libzfs),
+				machines.WithConfig(conf), machines.WithTime(testutils.FixedTime{}))
+			if err != nil {
+				t.Fatalf("expected success but got an error scanning for machines: %v", err)
+			}
+
+			content, err := ioutil.ReadFile(filepath.Join("testdata", "confs", tc.configPath))
+			if err != nil {
+				t.Fatalf("couldn't read configuration %q: %v", tc.configPath, err)
+			}
+			if err := ioutil.WriteFile(conf, content, 0644); err != nil {
+				t.Fatalf("couldn't write configuration: %v", err)
+			}
+			dropIns, err := filepath.Glob(filepath.Join("testdata", "confs", tc.configPath+".d", "*"))
+			if err != nil {
+				t.Fatalf("couldn't list configuration fragments: %v", err)
+			}
+			for _, p := range dropIns {
+				if err := os.MkdirAll(conf+".d", 0755); err != nil {
+					t.Fatalf("couldn't create configuration fragments directory: %v", err)
+				}
+				content, err := ioutil.ReadFile(p)
+				if err != nil {
+					t.Fatalf("couldn't read configuration fragment %q: %v", p, err)
+				}
+				if err := ioutil.WriteFile(filepath.Join(conf+".d", filepath.Base(p)), content, 0644); err != nil {
+					t.Fatalf("couldn't write configuration fragment: %v", err)
+				}
+			}
+
+			err = ms.Reload(context.Background())
+			if tc.wantErr != "" {
+				if assert.Error(t, err, "Reload should have failed") {
+					assert.Contains(t, err.Error(), tc.wantErr, "Reload error should explain the issue")
+				}
+			} else {
+				assert.NoError(t, err, "Reload shouldn't have failed")
+			}
+
+			history := ms.Config().History
+			assert.Equal(t, tc.wantSystemKeepLast, history.System.KeepLast, "system states keep last")
+			assert.Equal(t, tc.wantUser1KeepLast, history.ForUser("user1").KeepLast, "user1 states keep last")
+			assert.Equal(t, tc.wantUser2KeepLast, history.ForUser("user2").KeepLast, "user2 states keep last")
+		})
+	}
+}
+
 func BenchmarkNewDesktop(b *testing.B) {
 	config.SetVerboseMode(0)
 	defer func() { config.SetVerboseMode(1) }()
@@ -1539,6 +3429,51 @@ func assertMachinesNotEquals(t *testing.T, m1, m2 machines.Machines) {
 	}
 }
 
+// mounterMock records mounted datasets by mountpoint instead of mounting them.
+type mounterMock struct {
+	errOnMount   bool
+	errOnUnmount bool
+
+	mounted map[string]string
+}
+
+func (m *mounterMock) Mount(dataset, mountpoint string) error {
+	if m.errOnMount {
+		m.errOnMount = false
+		return errors.New("Mount error")
+	}
+	// Mounting over a nested mountpoint would hide it.
+	if nested := m.nestedIn(mountpoint); nested != "" {
+		return fmt.Errorf("%s is mounted below %s", nested, mountpoint)
+	}
+	if m.mounted == nil {
+		m.mounted = make(map[string]string)
+	}
+	m.mounted[mountpoint] = dataset
+	return nil
+}
+
+func (m *mounterMock) Unmount(mountpoint string) error {
+	if m.errOnUnmount {
+		return errors.New("Unmount error")
+	}
+	if nested := m.nestedIn(mountpoint); nested != "" {
+		return fmt.Errorf("%s is busy: %s is mounted below it", mountpoint, nested)
+	}
+	delete(m.mounted, mountpoint)
+	return nil
+}
+
+// nestedIn returns a mounted mountpoint below mountpoint, if any.
+func (m *mounterMock) nestedIn(mountpoint string) string {
+	for mp := range m.mounted {
+		if strings.HasPrefix(mp, mountpoint+"/") {
+			return mp
+		}
+	}
+	return ""
+}
+
 // generateCmdLine returns a command line with fake boot arguments
 func generateCmdLine(datasetAndBoot string) string {
 	return "aaaaa bbbbb root=ZFS=" + datasetAndBoot + " ccccc"
@@ -0,0 +1,159 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// maxMetadataLen is the maximum length of a description or label value. ZFS limits user property values
+// to 8192 bytes, and we append the source to them on snapshots.
+const maxMetadataLen = 8192 - len(":local")
+
+// labelKeyRegexp matches label keys valid in ZFS user property names.
+// Commas are excluded as they separate keys in the labels index property.
+var labelKeyRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9._:-]*$`)
+
+// Description returns the free-form description of the state, stored on its main dataset.
+func (s State) Description() string {
+	if ds := s.Datasets[s.ID]; len(ds) > 0 {
+		return ds[0].Description
+	}
+	return ""
+}
+
+// Labels returns the key/value labels of the state, stored on its main dataset.
+func (s State) Labels() map[string]string {
+	if ds := s.Datasets[s.ID]; len(ds) > 0 {
+		return ds[0].Labels
+	}
+	return nil
+}
+
+// formatLabels returns labels as a sorted list of key=value pairs.
+func formatLabels(labels map[string]string) string {
+	var r []string
+	for k, v := range labels {
+		r = append(r, k+"="+v)
+	}
+	sort.Strings(r)
+	return strings.Join(r, ", ")
+}
+
+// StateFilter selects states from their metadata. An empty filter matches every state.
+type StateFilter struct {
+	// Description matches states whose description contains it, case insensitively.
+	Description string
+	// Labels matches states having all those labels. An empty value matches any value of the label.
+	Labels map[string]string
+}
+
+// Match returns if s matches every criterion of the filter.
+func (f StateFilter) Match(s *State) bool {
+	if f.Description != "" && !strings.Contains(strings.ToLower(s.Description()), strings.ToLower(f.Description)) {
+		return false
+	}
+	labels := s.Labels()
+	for k, v := range f.Labels {
+		l, ok := labels[k]
+		if !ok || (v != "" && l != v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListedState is a state returned by ListStates.
+type ListedState struct {
+	// ID is the identifier to use to reference the state in other state operations.
+	// It differs from State.ID for user states attached to multiple system states.
+	ID    string
+	State *State
+}
+
+// ListStates returns the states of the current machine matching filter, most recently used first.
+// System states, starting with the current one, are listed if user is empty, otherwise the states of user.
+func (ms *Machines) ListStates(ctx context.Context, user string, filter StateFilter) ([]ListedState, error) {
+	m := ms.current
+	if !m.isZsys() {
+		return nil, errors.New(i18n.G("Current machine isn't Zsys, no state to list"))
+	}
+
+	var r []ListedState
+	if user == "" {
+		for _, s := range append([]*State{&m.State}, m.historyStates()...) {
+			if filter.Match(s) {
+				r = append(r, ListedState{ID: s.ID, State: s})
+			}
+		}
+		return r, nil
+	}
+
+	if _, ok := m.AllUsersStates[user]; !ok {
+		return nil, fmt.Errorf(i18n.G("user %q doesn't exist"), user)
+	}
+	for _, id := range m.UserStateIDs(user) {
+		s := m.AllUsersStates[user][id]
+		if filter.Match(s) {
+			r = append(r, ListedState{ID: id, State: s})
+		}
+	}
+	return r, nil
+}
+
+// historyStates returns the system history states of the machine, most recently used first.
+func (m Machine) historyStates() []*State {
+	var r []*State
+	for _, id := range m.HistoryIDs() {
+		r = append(r, m.History[id])
+	}
+	return r
+}
+
+// validateMetadata checks that description and labels can be stored as ZFS user properties.
+func validateMetadata(description string, labels map[string]string) error {
+	if len(description) > maxMetadataLen {
+		return fmt.Errorf(i18n.G("description is too long: %d bytes, maximum is %d"), len(description), maxMetadataLen)
+	}
+	for k, v := range labels {
+		if !labelKeyRegexp.MatchString(k) || len(libzfs.LabelPropPrefix+k) > 256 {
+			return fmt.Errorf(i18n.G("invalid label key %q: only lower case letters, digits and '.', '_', ':', '-' are supported"), k)
+		}
+		if v == "" {
+			return fmt.Errorf(i18n.G("label %q has an empty value"), k)
+		}
+		if len(v) > maxMetadataLen {
+			return fmt.Errorf(i18n.G("value of label %q is too long: %d bytes, maximum is %d"), k, len(v), maxMetadataLen)
+		}
+	}
+	return nil
+}
+
+// setMetadata stores description and labels on dataset, as part of the transaction t.
+func setMetadata(t *zfs.Transaction, dataset, description string, labels map[string]string) error {
+	if description != "" {
+		if err := t.SetProperty(libzfs.DescriptionProp, description, dataset, true); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set description on %q: ")+config.ErrorFormat, dataset, err)
+		}
+	}
+
+	var keys []string
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := t.SetProperty(libzfs.LabelPropPrefix+k, labels[k], dataset, true); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set label %q on %q: ")+config.ErrorFormat, k, dataset, err)
+		}
+	}
+	return nil
+}
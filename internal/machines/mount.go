@@ -0,0 +1,74 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"syscall"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// Mounter mounts and unmounts zfs filesystems, and allows overriding the system calls with a mock.
+// Unmounting a mountpoint which isn't mounted, like a locked encrypted dataset, isn't an error.
+type Mounter interface {
+	Mount(dataset, mountpoint string) error
+	Unmount(mountpoint string) error
+}
+
+type mounterAdapter struct{}
+
+func (mounterAdapter) Mount(dataset, mountpoint string) error {
+	return syscall.Mount(dataset, mountpoint, "zfs", 0, "zfsutil")
+}
+
+func (mounterAdapter) Unmount(mountpoint string) error {
+	if err := syscall.Unmount(mountpoint, 0); err != nil && err != syscall.EINVAL {
+		return err
+	}
+	return nil
+}
+
+// switchMounts unmounts oldDatasets, children first, and mounts newDatasets, parents first.
+// On error, what was already switched is restored.
+func (ms *Machines) switchMounts(ctx context.Context, oldDatasets, newDatasets []*zfs.Dataset) (err error) {
+	// A mountpoint sorts after the ones it is nested in.
+	oldDatasets = append([]*zfs.Dataset(nil), oldDatasets...)
+	sort.SliceStable(oldDatasets, func(i, j int) bool { return oldDatasets[i].Mountpoint > oldDatasets[j].Mountpoint })
+	newDatasets = append([]*zfs.Dataset(nil), newDatasets...)
+	sort.SliceStable(newDatasets, func(i, j int) bool { return newDatasets[i].Mountpoint < newDatasets[j].Mountpoint })
+
+	var unmounted, mounted []*zfs.Dataset
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(mounted) - 1; i >= 0; i-- {
+			if errUndo := ms.mounter.Unmount(mounted[i].Mountpoint); errUndo != nil {
+				log.Warningf(ctx, i18n.G("Couldn't unmount %s: %v"), mounted[i].Mountpoint, errUndo)
+			}
+		}
+		for i := len(unmounted) - 1; i >= 0; i-- {
+			if errUndo := ms.mounter.Mount(unmounted[i].Name, unmounted[i].Mountpoint); errUndo != nil {
+				log.Warningf(ctx, i18n.G("Couldn't mount %s: %v"), unmounted[i].Mountpoint, errUndo)
+			}
+		}
+	}()
+
+	for _, d := range oldDatasets {
+		if err := ms.mounter.Unmount(d.Mountpoint); err != nil {
+			return fmt.Errorf(i18n.G("couldn't unmount %s: ")+config.ErrorFormat, d.Mountpoint, err)
+		}
+		unmounted = append(unmounted, d)
+	}
+	for _, d := range newDatasets {
+		if err := ms.mounter.Mount(d.Name, d.Mountpoint); err != nil {
+			return fmt.Errorf(i18n.G("couldn't mount %s: ")+config.ErrorFormat, d.Mountpoint, err)
+		}
+		mounted = append(mounted, d)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package machines
+
+import (
+	"sort"
+)
+
+// StateChange is a state of the current machine added or removed by a refresh.
+type StateChange struct {
+	// Machine is the ID of the machine owning the state.
+	Machine string
+	// ID is the identifier of the state.
+	ID string
+	// User is the owner of a user state. It is empty for system states.
+	User string
+	// Removed is set if the state disappeared, otherwise it was added.
+	Removed bool
+}
+
+// WithStatesObserver calls observer with the states added or removed on the current machine on each refresh.
+// It is called with the lock of the request which modified the states.
+func WithStatesObserver(observer func([]StateChange)) func(o *options) error {
+	return func(o *options) error {
+		o.statesObserver = observer
+		return nil
+	}
+}
+
+// currentStates returns every system and user state of the current machine.
+func (ms *Machines) currentStates() map[StateChange]bool {
+	r := make(map[StateChange]bool)
+	m := ms.current
+	if !m.isZsys() {
+		return r
+	}
+
+	r[StateChange{Machine: m.ID, ID: m.ID}] = true
+	for id := range m.History {
+		r[StateChange{Machine: m.ID, ID: id}] = true
+	}
+	for user, states := range m.AllUsersStates {
+		for id := range states {
+			r[StateChange{Machine: m.ID, ID: id, User: user}] = true
+		}
+	}
+	return r
+}
+
+// statesChanges returns the states of after not in before as added, and the states of before not in after as removed.
+// System states are listed before user states, then by ID.
+func statesChanges(before, after map[StateChange]bool) []StateChange {
+	var r []StateChange
+	for s := range after {
+		if !before[s] {
+			r = append(r, s)
+		}
+	}
+	for s := range before {
+		if !after[s] {
+			s.Removed = true
+			r = append(r, s)
+		}
+	}
+
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].User != r[j].User {
+			return r[i].User < r[j].User
+		}
+		if r[i].ID != r[j].ID {
+			return r[i].ID < r[j].ID
+		}
+		return !r[i].Removed
+	})
+	return r
+}
@@ -0,0 +1,60 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// PinState protects the system or user state matching name from garbage collection and removal.
+// User states linked to a system state are pinned with it.
+func (ms *Machines) PinState(ctx context.Context, name, user string) (*State, error) {
+	return ms.setStatePinned(ctx, name, user, true)
+}
+
+// UnpinState removes the protection of the system or user state matching name, and of its linked user states.
+func (ms *Machines) UnpinState(ctx context.Context, name, user string) (*State, error) {
+	return ms.setStatePinned(ctx, name, user, false)
+}
+
+func (ms *Machines) setStatePinned(ctx context.Context, name, user string, pinned bool) (*State, error) {
+	s, err := ms.IDToState(ctx, name, user)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+
+	var value string
+	if pinned {
+		value = "yes"
+		log.Infof(ctx, i18n.G("Pinning state %s"), s.ID)
+	} else {
+		log.Infof(ctx, i18n.G("Unpinning state %s"), s.ID)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	// Set explicitly on every dataset: snapshots don't inherit user properties from their parents.
+	for _, d := range append(s.getDatasets(), s.getUsersDatasets()...) {
+		if err := t.SetProperty(libzfs.PinnedProp, value, d.Name, true); err != nil {
+			cancel()
+			return nil, fmt.Errorf(i18n.G("couldn't change pinned property on %q: ")+config.ErrorFormat, d.Name, err)
+		}
+	}
+
+	return s, nil
+}
+
+// IsPinned returns if the state is protected from garbage collection and removal.
+func (s State) IsPinned() bool {
+	for _, d := range s.getDatasets() {
+		if d.Pinned {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package machines
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+// Roles a pool can hold for a state.
+const (
+	// PoolRoleSystem is a pool holding system datasets, in a ROOT container.
+	PoolRoleSystem = "system"
+	// PoolRoleBoot is a pool holding boot datasets, in a BOOT container.
+	PoolRoleBoot = "boot"
+	// PoolRoleUserData is a pool holding user datasets, in a USERDATA container.
+	PoolRoleUserData = "userdata"
+)
+
+// PoolMembership is a pool a state has datasets on, with the roles it holds for this state.
+type PoolMembership struct {
+	Name  string
+	Roles []string
+}
+
+// inPools returns if the dataset or snapshot name is on one of pools. An empty pools list matches any pool.
+func inPools(name string, pools []string) bool {
+	if len(pools) == 0 {
+		return true
+	}
+	p := poolName(name)
+	for _, c := range pools {
+		if c == p {
+			return true
+		}
+	}
+	return false
+}
+
+// isManagedPool returns if p is configured to hold any of the ROOT, BOOT or USERDATA containers.
+// Every pool is managed when no pool is configured.
+func (ms *Machines) isManagedPool(p string) bool {
+	pools := ms.conf.Pools
+	if len(pools.Root) == 0 && len(pools.Boot) == 0 && len(pools.UserData) == 0 {
+		return true
+	}
+	return inPools(p, pools.Root) || inPools(p, pools.Boot) || inPools(p, pools.UserData)
+}
+
+// isSystemDataset returns if d is a system root dataset or is in a ROOT container.
+func isSystemDataset(d zfs.Dataset) bool {
+	return (d.Mountpoint == "/" && d.CanMount != "off") || strings.Contains(strings.ToLower(d.Name), rootdatasetsContainerName)
+}
+
+// isBootDataset returns if the dataset path is in a BOOT container.
+func isBootDataset(path string) bool {
+	return strings.Contains(strings.ToLower(path), bootdatasetsContainerName)
+}
+
+// Pools returns the pools the state and its users datasets are on, sorted by name, with the roles they hold.
+func (s State) Pools() []PoolMembership {
+	roles := make(map[string]map[string]bool)
+	add := func(name, role string) {
+		p := poolName(name)
+		if roles[p] == nil {
+			roles[p] = make(map[string]bool)
+		}
+		roles[p][role] = true
+	}
+
+	for _, ds := range s.Datasets {
+		for _, d := range ds {
+			switch {
+			case isUserDataset(d.Name):
+				add(d.Name, PoolRoleUserData)
+			case isBootDataset(d.Name):
+				add(d.Name, PoolRoleBoot)
+			default:
+				add(d.Name, PoolRoleSystem)
+			}
+		}
+	}
+	for _, us := range s.Users {
+		for _, ds := range us.Datasets {
+			for _, d := range ds {
+				add(d.Name, PoolRoleUserData)
+			}
+		}
+	}
+
+	var r []PoolMembership
+	for p, rs := range roles {
+		m := PoolMembership{Name: p}
+		// Keep roles in a stable, meaningful order.
+		for _, role := range []string{PoolRoleSystem, PoolRoleBoot, PoolRoleUserData} {
+			if rs[role] {
+				m.Roles = append(m.Roles, role)
+			}
+		}
+		r = append(r, m)
+	}
+	sort.Slice(r, func(i, j int) bool { return r[i].Name < r[j].Name })
+	return r
+}
+
+// formatPools returns a human readable pool membership list, like "bpool (boot), rpool (system, userdata)".
+func formatPools(pools []PoolMembership) string {
+	var r []string
+	for _, p := range pools {
+		r = append(r, fmt.Sprintf("%s (%s)", p.Name, strings.Join(p.Roles, ", ")))
+	}
+	return strings.Join(r, ", ")
+}
@@ -0,0 +1,98 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// SetUserQuota sets the maximum space, in bytes, the current user dataset of user can reference and the space
+// reserved for it. 0 removes the quota or reservation.
+// Those are kept when the user is reverted to another state.
+func (ms *Machines) SetUserQuota(ctx context.Context, user string, quota, reservation uint64) error {
+	if !ms.current.isZsys() {
+		return errors.New(i18n.G("Current machine isn't Zsys, nothing to modify"))
+	}
+	if user == "" {
+		return errors.New(i18n.G("Needs a valid user name, got nothing"))
+	}
+	us, ok := ms.current.Users[user]
+	if !ok {
+		return fmt.Errorf(i18n.G("user %q not found on current state"), user)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	log.Infof(ctx, i18n.G("Setting quota of user %q to %s and reservation to %s"), user, formatQuota(quota), formatQuota(reservation))
+	if err := setQuota(t, us.ID, quota, reservation); err != nil {
+		cancel()
+		return err
+	}
+
+	return ms.Refresh(ctx)
+}
+
+// setQuota sets the quota and reservation, in bytes, on the dataset name. 0 removes them.
+func setQuota(t *zfs.Transaction, name string, quota, reservation uint64) error {
+	for _, p := range []struct {
+		name string
+		size uint64
+	}{{libzfs.RefquotaProp, quota}, {libzfs.ReservationProp, reservation}} {
+		value := "none"
+		if p.size > 0 {
+			value = strconv.FormatUint(p.size, 10)
+		}
+		if err := t.SetProperty(p.name, value, name, false); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set %s to %s on %q: ")+config.ErrorFormat, p.name, formatQuota(p.size), name, err)
+		}
+	}
+	return nil
+}
+
+// inheritQuota copies the quota, if any, of the user dataset from to the dataset name replacing it and moves its
+// reservation, so that the pool doesn't have to hold it twice.
+func inheritQuota(t *zfs.Transaction, from *zfs.Dataset, name string) error {
+	if from.Quota == 0 && from.Reservation == 0 {
+		return nil
+	}
+	log.Debugf(t.Context(), i18n.G("Keeping quota of %q on %q"), from.Name, name)
+	quota, reservation := from.Quota, from.Reservation
+	if reservation > 0 {
+		if err := t.SetProperty(libzfs.ReservationProp, "none", from.Name, false); err != nil {
+			return fmt.Errorf(i18n.G("couldn't set %s to %s on %q: ")+config.ErrorFormat, libzfs.ReservationProp, formatQuota(0), from.Name, err)
+		}
+	}
+	return setQuota(t, name, quota, reservation)
+}
+
+// formatQuota returns a human readable quota or reservation size.
+func formatQuota(size uint64) string {
+	if size == 0 {
+		return i18n.G("none")
+	}
+	return humanSize(size)
+}
+
+// formatUserUsage returns the space referenced by the user dataset d versus its quota and reservation.
+// It's empty if none is set.
+func formatUserUsage(d *zfs.Dataset) string {
+	if d.Quota == 0 && d.Reservation == 0 {
+		return ""
+	}
+	r := fmt.Sprintf(i18n.G("%s used"), humanSize(d.Referenced))
+	if d.Quota > 0 {
+		r = fmt.Sprintf(i18n.G("%s of %s quota (%d%%)"), r, humanSize(d.Quota), d.Referenced*100/d.Quota)
+	}
+	if d.Reservation > 0 {
+		r = fmt.Sprintf(i18n.G("%s, %s reserved"), r, humanSize(d.Reservation))
+	}
+	return r
+}
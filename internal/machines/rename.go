@@ -0,0 +1,119 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// RenameState renames the saved system or user state matching name to newName.
+// Every snapshot of a system state is renamed along with its linked user snapshots, in a single transaction.
+// Any revert pending on the state follows the new name.
+// It returns the new ID of the state.
+func (ms *Machines) RenameState(ctx context.Context, name, user, newName string) (string, error) {
+	s, err := ms.IDToState(ctx, name, user)
+	if err != nil {
+		return "", fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+	if !s.isSnapshot() {
+		return "", fmt.Errorf(i18n.G("%s isn't a saved state: only saved states can be renamed"), s.ID)
+	}
+	if err := validateStateName(newName); err != nil {
+		return "", err
+	}
+
+	base, oldName := splitSnapshotName(s.ID)
+	if oldName == newName {
+		return "", fmt.Errorf(i18n.G("%s is already named %q"), s.ID, newName)
+	}
+	newID := base + "@" + newName
+
+	// Only rename snapshots belonging to this state: linked user states may be clones.
+	var snapshots []*zfs.Dataset
+	for _, d := range append(s.getDatasets(), s.getUsersDatasets()...) {
+		if _, n := splitSnapshotName(d.Name); !d.IsSnapshot || n != oldName {
+			log.Debugf(ctx, i18n.G("%s isn't a snapshot of state %s, not renaming it"), d.Name, s.ID)
+			continue
+		}
+		snapshots = append(snapshots, d)
+	}
+
+	if err := ms.checkRenameCollisions(s, user, snapshots, newName); err != nil {
+		return "", err
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	log.Infof(ctx, i18n.G("Renaming state %s to %s"), s.ID, newID)
+	for _, d := range snapshots {
+		if err := t.RenameSnapshot(d.Name, newName); err != nil {
+			cancel()
+			return "", fmt.Errorf(i18n.G("couldn't rename state %s: ")+config.ErrorFormat, s.ID, err)
+		}
+	}
+
+	// Pending reverts reference the state by its ID.
+	renamedIDs := map[string]string{s.ID: newID}
+	for _, us := range s.Users {
+		if us.isSnapshot() {
+			b, _ := splitSnapshotName(us.ID)
+			renamedIDs[us.ID] = b + "@" + newName
+		}
+	}
+	for _, d := range append(ms.allSystemDatasets, ms.allUsersDatasets...) {
+		if d.IsSnapshot || d.PendingRevert == "" {
+			continue
+		}
+		id, revertUserData := parsePendingRevert(d.PendingRevert)
+		renamed, ok := renamedIDs[id]
+		if !ok {
+			continue
+		}
+		if revertUserData {
+			renamed += " " + zfsRevertUserDataTag
+		}
+		log.Infof(ctx, i18n.G("Updating pending revert on %q to %q"), d.Name, renamed)
+		if err := t.SetProperty(libzfs.PendingRevertProp, renamed, d.Name, false); err != nil {
+			cancel()
+			return "", fmt.Errorf(i18n.G("couldn't update pending revert on %q: ")+config.ErrorFormat, d.Name, err)
+		}
+	}
+
+	ms.refresh(ctx)
+	return newID, nil
+}
+
+// checkRenameCollisions returns an error if renaming snapshots of s to newName would clash with existing datasets
+// or states.
+func (ms *Machines) checkRenameCollisions(s *State, user string, snapshots []*zfs.Dataset, newName string) error {
+	existing := make(map[string]bool)
+	for _, d := range ms.z.Datasets() {
+		existing[d.Name] = true
+	}
+	for _, d := range snapshots {
+		base, _ := splitSnapshotName(d.Name)
+		if existing[base+"@"+newName] {
+			return fmt.Errorf(i18n.G("couldn't rename state %s: %s already exists"), s.ID, base+"@"+newName)
+		}
+	}
+
+	// As with a new user state, a user state named after a system state would be incoherent.
+	if user == "" {
+		return nil
+	}
+	for _, m := range ms.all {
+		for id := range m.History {
+			if strings.HasSuffix(id, "@"+newName) {
+				return fmt.Errorf(i18n.G("couldn't rename state %s: a system state %q already exists"), s.ID, id)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+package machines
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/hooks"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+	"github.com/ubuntu/zsys/internal/zfs"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// RevertSystemState schedules the current machine to boot on the system state matching name on next boot.
+// If revertUserData is true, user datasets attached to this state will be reverted as well, as with the
+// zsys-revert=userdata kernel command line tag.
+// The request is recorded on the current machine root dataset and is consumed on next boot commit.
+// selectNextBoot, if not nil, first selects the reverted state in the bootloader. The request isn't recorded if it fails.
+func (ms *Machines) RevertSystemState(ctx context.Context, name string, revertUserData bool,
+	selectNextBoot func(ctx context.Context, stateID string, revertUserData bool) error) (*State, error) {
+	m := ms.current
+	if !m.isZsys() {
+		return nil, errors.New(i18n.G("Current machine isn't Zsys, nothing to revert"))
+	}
+
+	s, err := ms.IDToState(ctx, name, "")
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+
+	if s == &m.State {
+		return nil, fmt.Errorf(i18n.G("%s is the current system state"), s.ID)
+	}
+	if m.History[s.ID] != s {
+		return nil, fmt.Errorf(i18n.G("%s isn't a state of current machine %s"), s.ID, m.ID)
+	}
+
+	pendingRevert := s.ID
+	if revertUserData {
+		pendingRevert += " " + zfsRevertUserDataTag
+	}
+
+	env := hooksEnv(s.ID, "", s.getDatasets())
+	if err := ms.hooksRunner().Run(ctx, hooks.PreRevert, env); err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't revert to state %s: ")+config.ErrorFormat, s.ID, err)
+	}
+
+	if selectNextBoot != nil {
+		if err := selectNextBoot(ctx, s.ID, revertUserData); err != nil {
+			return nil, fmt.Errorf(i18n.G("couldn't select %s for next boot: ")+config.ErrorFormat, s.ID, err)
+		}
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	log.Infof(ctx, i18n.G("Scheduling revert to %q on next boot"), pendingRevert)
+	if err := t.SetProperty(libzfs.PendingRevertProp, pendingRevert, m.ID, false); err != nil {
+		cancel()
+		if selectNextBoot != nil {
+			log.Warningf(ctx, i18n.G("%s stays selected for next boot in the bootloader"), s.ID)
+		}
+		return nil, fmt.Errorf(i18n.G("couldn't set pending revert to %q on %q: ")+config.ErrorFormat, pendingRevert, m.ID, err)
+	}
+
+	ms.hooksRunner().RunPost(ctx, hooks.PostRevert, env)
+	return s, nil
+}
+
+// RevertUserState schedules the user state matching name to replace the current user datasets on next boot.
+func (ms *Machines) RevertUserState(ctx context.Context, name, user string) (*State, error) {
+	current, s, err := ms.userStatesForRevert(ctx, name, user)
+	if err != nil {
+		return nil, err
+	}
+
+	env := hooksEnv(s.ID, user, s.getDatasets())
+	if err := ms.hooksRunner().Run(ctx, hooks.PreRevert, env); err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't revert to state %s: ")+config.ErrorFormat, s.ID, err)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	log.Infof(ctx, i18n.G("Scheduling revert of user %q to %q on next boot"), user, s.ID)
+	if err := t.SetProperty(libzfs.PendingRevertProp, s.ID, current.ID, false); err != nil {
+		cancel()
+		return nil, fmt.Errorf(i18n.G("couldn't set pending revert to %q on %q: ")+config.ErrorFormat, s.ID, current.ID, err)
+	}
+
+	ms.hooksRunner().RunPost(ctx, hooks.PostRevert, env)
+	return s, nil
+}
+
+// RestoreUserState replaces immediately the current user datasets by the user state matching name, without any reboot.
+// Snapshots are cloned to new user datasets, which are mounted in place of the current ones. Previous user datasets are
+// detached from the current system state and kept in user history.
+// It returns the new current user state.
+func (ms *Machines) RestoreUserState(ctx context.Context, name, user string) (*State, error) {
+	current, s, err := ms.userStatesForRevert(ctx, name, user)
+	if err != nil {
+		return nil, err
+	}
+	m := ms.current
+
+	env := hooksEnv(s.ID, user, s.getDatasets())
+	if err := ms.hooksRunner().Run(ctx, hooks.PreRevert, env); err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't restore state %s: ")+config.ErrorFormat, s.ID, err)
+	}
+
+	// Prevent snapshots from being destroyed until they are cloned and the new datasets are in place.
+	release, err := ms.holdSnapshots(ctx, s.getDatasets())
+	if err != nil {
+		return nil, fmt.Errorf(i18n.G("couldn't hold state %s: ")+config.ErrorFormat, s.ID, err)
+	}
+	defer release()
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
+	log.Infof(ctx, i18n.G("Restoring user %q to %q"), user, s.ID)
+	newID, err := switchUserState(t, current, s, m.ID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// A restore supersedes any revert scheduled on next boot.
+	if current.Datasets[current.ID][0].PendingRevert != "" {
+		if err := t.SetProperty(libzfs.PendingRevertProp, "", current.ID, false); err != nil {
+			cancel()
+			return nil, fmt.Errorf(i18n.G("couldn't reset pending revert on %q: ")+config.ErrorFormat, current.ID, err)
+		}
+	}
+
+	// Swap mountpoints: previous user datasets won't be mounted anymore, while new ones will be.
+	oldDatasets := current.getDatasets()
+	for _, d := range oldDatasets {
+		if err := t.SetProperty(libzfs.CanmountProp, "noauto", d.Name, false); err != nil {
+			cancel()
+			return nil, fmt.Errorf(i18n.G("couldn't set %q to canmount=noauto: ")+config.ErrorFormat, d.Name, err)
+		}
+	}
+	var newDatasets []*zfs.Dataset
+	for _, d := range t.Zfs.Datasets() {
+		if d.IsSnapshot || (d.Name != newID && !strings.HasPrefix(d.Name, newID+"/")) {
+			continue
+		}
+		if err := t.SetProperty(libzfs.CanmountProp, "on", d.Name, false); err != nil {
+			cancel()
+			return nil, fmt.Errorf(i18n.G("couldn't set %q to canmount=on: ")+config.ErrorFormat, d.Name, err)
+		}
+		newDatasets = append(newDatasets, d)
+	}
+
+	if err := ms.switchMounts(ctx, oldDatasets, newDatasets); err != nil {
+		cancel()
+		return nil, fmt.Errorf(i18n.G("couldn't switch user %q to %q: ")+config.ErrorFormat, user, newID, err)
+	}
+	t.Done()
+
+	if err := ms.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	ms.hooksRunner().RunPost(ctx, hooks.PostRevert, env)
+	us, ok := ms.current.Users[user]
+	if !ok {
+		return nil, fmt.Errorf(i18n.G("couldn't find restored state for user %q"), user)
+	}
+	return us, nil
+}
+
+// userStatesForRevert returns the current user state and the user state matching name that user can be reverted to.
+func (ms *Machines) userStatesForRevert(ctx context.Context, name, user string) (current, s *State, err error) {
+	if user == "" {
+		return nil, nil, errors.New(i18n.G("Needs a valid user name, got nothing"))
+	}
+
+	m := ms.current
+	if !m.isZsys() {
+		return nil, nil, errors.New(i18n.G("Current machine isn't Zsys, nothing to revert"))
+	}
+
+	current, ok := m.State.Users[user]
+	if !ok {
+		return nil, nil, fmt.Errorf(i18n.G("user %q doesn't exist"), user)
+	}
+
+	s, err = ms.IDToState(ctx, name, user)
+	if err != nil {
+		return nil, nil, fmt.Errorf(i18n.G("Couldn't find state: %v"), err)
+	}
+
+	if s == current {
+		return nil, nil, fmt.Errorf(i18n.G("%s is the current state for user %q"), s.ID, user)
+	}
+	for _, us := range m.AllUsersStates[user] {
+		if us == s {
+			return current, s, nil
+		}
+	}
+	return nil, nil, fmt.Errorf(i18n.G("%s isn't a state of current machine %s"), s.ID, m.ID)
+}
+
+// parsePendingRevert returns the state ID and if user data should be reverted from a pending revert property value.
+func parsePendingRevert(value string) (id string, revertUserData bool) {
+	for i, entry := range strings.Fields(value) {
+		if i == 0 {
+			id = entry
+			continue
+		}
+		if entry == zfsRevertUserDataTag {
+			revertUserData = true
+		}
+	}
+	return id, revertUserData
+}
+
+// revertPendingUserStates replaces user datasets of a system state by the user states scheduled with RevertUserState.
+// Snapshots are cloned to new user datasets. The new datasets are associated to ownerID instead of the current ones.
+// It returns if any user datasets or pending revert requests were changed.
+func (s *State) revertPendingUserStates(t *zfs.Transaction, m *Machine, ownerID string) (changed bool, err error) {
+	for _, user := range sortedStateKeys(s.Users) {
+		us := s.Users[user]
+		current := us.Datasets[us.ID][0]
+		pendingID := current.PendingRevert
+		if pendingID == "" {
+			continue
+		}
+
+		var target *State
+		for _, candidate := range m.AllUsersStates[user] {
+			if candidate.ID == pendingID {
+				target = candidate
+				break
+			}
+		}
+
+		// Encrypted user datasets can only be cloned once unlocked: keep the request for then.
+		if target != nil && target.isLocked() {
+			log.Infof(t.Context(), i18n.G("User state %q is locked: delaying revert of user %q until it is unlocked"), target.ID, user)
+			continue
+		}
+
+		// Reset the one shot request, whatever happens next.
+		if err := t.SetProperty(libzfs.PendingRevertProp, "", current.Name, false); err != nil {
+			return false, fmt.Errorf(i18n.G("couldn't reset pending revert on %q: ")+config.ErrorFormat, current.Name, err)
+		}
+		changed = true
+		if target == nil {
+			log.Warningf(t.Context(), i18n.G("Couldn't find state %q to revert user %q to, ignoring"), pendingID, user)
+			continue
+		}
+
+		log.Infof(t.Context(), i18n.G("Reverting user %q to %q"), user, target.ID)
+		if _, err := switchUserState(t, us, target, ownerID); err != nil {
+			return false, err
+		}
+	}
+
+	return changed, nil
+}
+
+// switchUserState associates target user state to ownerID in place of current.
+// Snapshots are cloned to new user datasets. It returns the ID of the user state now associated to ownerID.
+func switchUserState(t *zfs.Transaction, current, target *State, ownerID string) (newID string, err error) {
+	if target.isSnapshot() {
+		suffix := t.Zfs.GenerateID(6)
+		if err := t.Clone(target.ID, suffix, false, true); err != nil {
+			return "", fmt.Errorf(i18n.G("couldn't create new user datasets from %q: %v"), target.ID, err)
+		}
+		// Reformat the name with the new suffix, as done by Clone().
+		base, _ := splitSnapshotName(target.ID)
+		newID = base[:strings.LastIndex(base, "_")] + "_" + suffix
+		if err := t.SetProperty(libzfs.BootfsDatasetsProp, ownerID, newID, false); err != nil {
+			return "", fmt.Errorf(i18n.G("couldn't add %q to BootfsDatasets property of %q: ")+config.ErrorFormat, ownerID, newID, err)
+		}
+	} else {
+		newID = target.ID
+		d := target.Datasets[target.ID][0]
+		if !nameInBootfsDatasets(ownerID, *d) {
+			newTag := ownerID
+			if d.BootfsDatasets != "" {
+				newTag = d.BootfsDatasets + bootfsdatasetsSeparator + ownerID
+			}
+			if err := t.SetProperty(libzfs.BootfsDatasetsProp, newTag, d.Name, false); err != nil {
+				return "", fmt.Errorf(i18n.G("couldn't add %q to BootfsDatasets property of %q: ")+config.ErrorFormat, ownerID, d.Name, err)
+			}
+		}
+	}
+
+	// Quotas are a guardrail on the user, not part of its history: keep them on the new user datasets.
+	if newID != current.ID {
+		if err := inheritQuota(t, current.Datasets[current.ID][0], newID); err != nil {
+			return "", err
+		}
+	}
+
+	// Detach previous user datasets from this state
+	for _, d := range current.getDatasets() {
+		var newTags []string
+		for _, n := range strings.Split(d.BootfsDatasets, bootfsdatasetsSeparator) {
+			if n != ownerID {
+				newTags = append(newTags, n)
+			}
+		}
+		newTag := strings.Join(newTags, bootfsdatasetsSeparator)
+		if newTag == d.BootfsDatasets {
+			continue
+		}
+		log.Infof(t.Context(), i18n.G("Untagging user dataset: %q"), d.Name)
+		if err := t.SetProperty(libzfs.BootfsDatasetsProp, newTag, d.Name, false); err != nil {
+			return "", fmt.Errorf(i18n.G("couldn't remove %q to BootfsDatasets property of %q: ")+config.ErrorFormat, ownerID, d.Name, err)
+		}
+	}
+
+	return newID, nil
+}
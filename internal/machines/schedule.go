@@ -0,0 +1,115 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+const (
+	// ScheduleScopeSystem saves the current system state with its users.
+	ScheduleScopeSystem = config.ScheduleScopeSystem
+	// ScheduleScopeUsers saves user states only.
+	ScheduleScopeUsers = config.ScheduleScopeUsers
+
+	// scheduleLabel is the label storing the name of the rule which saved a state.
+	scheduleLabel = "schedule"
+)
+
+// RunSchedule saves a new state for each schedule rule whose last saved state is older than its interval,
+// then garbage collects if any state was saved.
+// The last run of a rule is the newest state labelled with its name, so that nothing else needs to persist
+// between 2 daemon activations.
+// It returns true if a system state was saved.
+func (ms *Machines) RunSchedule(ctx context.Context) (systemSaved bool, err error) {
+	if !ms.current.isZsys() {
+		log.Info(ctx, i18n.G("Current machine isn't Zsys, no scheduled state to save"))
+		return false, nil
+	}
+
+	now := ms.time.Now()
+	var saved bool
+	var failedRules []string
+	for _, r := range ms.conf.Schedule {
+		if r.Every <= 0 {
+			log.Warningf(ctx, i18n.G("Schedule rule %q has no valid interval, skipping"), r.Name)
+			continue
+		}
+		labels := map[string]string{"origin": "schedule", scheduleLabel: r.Name}
+
+		switch r.Scope {
+		case ScheduleScopeSystem:
+			if !scheduleDue(ms.current.History, r, now) {
+				log.Debugf(ctx, i18n.G("Schedule rule %q: system state isn't due yet"), r.Name)
+				continue
+			}
+			log.Infof(ctx, i18n.G("Schedule rule %q: saving system state"), r.Name)
+			if _, err := ms.CreateSystemSnapshot(ctx, "", "", labels); err != nil {
+				log.Warningf(ctx, i18n.G("Schedule rule %q: couldn't save system state: ")+config.ErrorFormat, r.Name, err)
+				failedRules = append(failedRules, r.Name)
+				continue
+			}
+			saved, systemSaved = true, true
+
+		case ScheduleScopeUsers:
+			users := r.Users
+			if len(users) == 0 {
+				for u := range ms.current.AllUsersStates {
+					users = append(users, u)
+				}
+				sort.Strings(users)
+			}
+
+			var failed bool
+			for _, u := range users {
+				if !scheduleDue(ms.current.AllUsersStates[u], r, now) {
+					log.Debugf(ctx, i18n.G("Schedule rule %q: state of user %q isn't due yet"), r.Name, u)
+					continue
+				}
+				log.Infof(ctx, i18n.G("Schedule rule %q: saving state of user %q"), r.Name, u)
+				if _, err := ms.CreateUserSnapshot(ctx, u, "", "", labels); err != nil {
+					log.Warningf(ctx, i18n.G("Schedule rule %q: couldn't save state of user %q: ")+config.ErrorFormat, r.Name, u, err)
+					failed = true
+					continue
+				}
+				saved = true
+			}
+			if failed {
+				failedRules = append(failedRules, r.Name)
+			}
+
+		default:
+			log.Warningf(ctx, i18n.G("Schedule rule %q has an unknown scope %q, skipping"), r.Name, r.Scope)
+		}
+	}
+
+	if saved {
+		if err := ms.GC(ctx, false); err != nil {
+			return systemSaved, fmt.Errorf(i18n.G("couldn't garbage collect after saving scheduled states: ")+config.ErrorFormat, err)
+		}
+	}
+
+	if len(failedRules) > 0 {
+		return systemSaved, fmt.Errorf(i18n.G("couldn't save all states of schedule rules: %s"), strings.Join(failedRules, ", "))
+	}
+	return systemSaved, nil
+}
+
+// scheduleDue returns true if no state saved by rule r is within its interval, among states.
+func scheduleDue(states map[string]*State, r config.ScheduleRule, now time.Time) bool {
+	for _, s := range states {
+		if !s.isSnapshot() || s.Labels()[scheduleLabel] != r.Name {
+			continue
+		}
+		if now.Sub(s.LastUsed) < r.Every {
+			return false
+		}
+	}
+	return true
+}
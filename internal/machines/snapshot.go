@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/hooks"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/zfs"
 )
@@ -15,19 +17,21 @@ const automatedSnapshotPrefix = "autozsys_"
 // CreateSystemSnapshot creates a snapshot of a system and all users datasets.
 // If snapshotname is not empty, it is used as the id of the snapshot otherwise an id
 // is generated with a random string.
-func (ms *Machines) CreateSystemSnapshot(ctx context.Context, snapshotname string) (string, error) {
-	return ms.createSnapshot(ctx, snapshotname, "")
+// description and labels, if any, are stored on every snapshotted dataset.
+func (ms *Machines) CreateSystemSnapshot(ctx context.Context, snapshotname, description string, labels map[string]string) (string, error) {
+	return ms.createSnapshot(ctx, snapshotname, "", description, labels)
 }
 
 // CreateUserSnapshot creates a snapshot for the provided user.
 // If snapshotName is not empty, it is used as the id of the snapshot otherwise an id
 // is generated with a random string.
 // userName is the name of the user to snapshot the datasets from.
-func (ms *Machines) CreateUserSnapshot(ctx context.Context, userName, snapshotName string) (string, error) {
+// description and labels, if any, are stored on every snapshotted dataset.
+func (ms *Machines) CreateUserSnapshot(ctx context.Context, userName, snapshotName, description string, labels map[string]string) (string, error) {
 	if userName == "" {
 		return "", errors.New(i18n.G("Needs a valid user name, got nothing"))
 	}
-	return ms.createSnapshot(ctx, snapshotName, userName)
+	return ms.createSnapshot(ctx, snapshotName, userName, description, labels)
 }
 
 // createSnapshot creates a snapshot of a system and all users datasets.
@@ -35,7 +39,7 @@ func (ms *Machines) CreateUserSnapshot(ctx context.Context, userName, snapshotNa
 // is generated with a random string.
 // If onlyUser is empty a snapshot of all the system datasets is taken,
 // otherwise only a snapshot of the given username is done
-func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser string) (string, error) {
+func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser, description string, labels map[string]string) (string, error) {
 	m := ms.current
 	if !m.isZsys() {
 		return "", errors.New(i18n.G("Current machine isn't Zsys, nothing to create"))
@@ -47,11 +51,12 @@ func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser st
 	if err := validateStateName(name); err != nil {
 		return "", err
 	}
-
-	t, cancel := ms.z.NewTransaction(ctx)
-	defer t.Done()
+	if err := validateMetadata(description, labels); err != nil {
+		return "", err
+	}
 
 	var toSnapshot []*zfs.Dataset
+	id := m.ID + "@" + name
 	if onlyUser != "" {
 		userState, ok := m.State.Users[onlyUser]
 		if !ok {
@@ -64,6 +69,7 @@ func (ms *Machines) createSnapshot(ctx context.Context, name string, onlyUser st
 			}
 		}
 		toSnapshot = userState.getDatasets()
+		id = userState.ID + "@" + name
 	} else {
 		toSnapshot = append(m.State.getDatasets(), m.State.getUsersDatasets()...)
 	}
@@ -87,14 +93,27 @@ Please remove some states manually to free up space.`), ms.conf.General.MinFreeP
 		}
 	}
 
+	env := hooksEnv(id, onlyUser, toSnapshot)
+	if err := ms.hooksRunner().Run(ctx, hooks.PreSave, env); err != nil {
+		return "", fmt.Errorf(i18n.G("couldn't save state %s: ")+config.ErrorFormat, id, err)
+	}
+
+	t, cancel := ms.z.NewTransaction(ctx)
+	defer t.Done()
+
 	for _, d := range toSnapshot {
 		if err := t.Snapshot(name, d.Name, false); err != nil {
 			cancel()
 			return "", err
 		}
+		if err := setMetadata(t, d.Name+"@"+name, description, labels); err != nil {
+			cancel()
+			return "", err
+		}
 	}
 
 	ms.refresh(ctx)
+	ms.hooksRunner().RunPost(ctx, hooks.PostSave, env)
 	return name, nil
 }
 
@@ -0,0 +1,118 @@
+package machines
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/zfs"
+)
+
+const (
+	// SortByLastUsed orders states from the most recently used one.
+	SortByLastUsed = "last-used"
+	// SortByReclaimable orders states from the one freeing the most space on removal.
+	SortByReclaimable = "reclaimable"
+)
+
+// Space is the disk space accounting of a state, in bytes.
+type Space struct {
+	// Used is the space consumed by the state datasets, its linked user states and their snapshots.
+	Used uint64
+	// Referenced is the amount of data accessible by the state datasets and its linked user states.
+	Referenced uint64
+	// Unique is the space only consumed by the state datasets and its linked user states.
+	Unique uint64
+	// Written is the space written to the state datasets and its linked user states since their previous snapshot.
+	Written uint64
+	// Reclaimable is the space freed by removing the state with all states and datasets depending on it.
+	// As space shared between multiple removed snapshots isn't accounted by ZFS, this is a lower bound.
+	Reclaimable uint64
+}
+
+// StateSpace returns the space accounting of a state, taking its dependencies into account for the reclaimable space.
+func (ms *Machines) StateSpace(ctx context.Context, s *State) Space {
+	var space Space
+	for _, d := range append(s.getDatasets(), s.getUsersDatasets()...) {
+		u := d.UniqueSpace()
+		space.Unique += u
+		space.Used += u
+		if !d.IsSnapshot {
+			space.Used += d.UsedBySnapshots
+		}
+		space.Referenced += d.Referenced
+		space.Written += d.Written
+	}
+
+	states, datasets := s.getDependencies(ctx, ms)
+	space.Reclaimable = reclaimableSpace(states, datasets)
+
+	return space
+}
+
+// reclaimableSpace returns the space freed by destroying all datasets of states and datasets.
+// States only linked to another one are untagged and not destroyed: their datasets are not accounted.
+func reclaimableSpace(states []stateWithLinkedState, datasets []*zfs.Dataset) uint64 {
+	seen := make(map[string]bool)
+	var r uint64
+	add := func(d *zfs.Dataset) {
+		if seen[d.Name] {
+			return
+		}
+		seen[d.Name] = true
+		r += d.UniqueSpace()
+	}
+
+	for _, s := range states {
+		if s.linkedStateID != "" {
+			continue
+		}
+		for _, d := range s.getDatasets() {
+			add(d)
+		}
+	}
+	for _, d := range datasets {
+		add(d)
+	}
+
+	return r
+}
+
+// SortedHistoryIDs returns the IDs of the machine history states, ordered by sortBy.
+func (ms *Machines) SortedHistoryIDs(ctx context.Context, m *Machine, sortBy string) ([]string, error) {
+	ids := m.HistoryIDs()
+
+	switch sortBy {
+	case "", SortByLastUsed:
+	case SortByReclaimable:
+		reclaimable := make(map[string]uint64)
+		for _, id := range ids {
+			reclaimable[id] = ms.StateSpace(ctx, m.History[id]).Reclaimable
+		}
+		// Keep most recently used first for states freeing the same amount of space.
+		sort.SliceStable(ids, func(i, j int) bool { return reclaimable[ids[i]] > reclaimable[ids[j]] })
+	default:
+		return nil, fmt.Errorf(i18n.G("unknown sort key %q"), sortBy)
+	}
+
+	return ids, nil
+}
+
+// humanSize returns a human readable representation of size bytes, with binary prefixes, as zfs does.
+func humanSize(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit && exp < len("KMGTPE")-1; n /= unit {
+		div *= unit
+		exp++
+	}
+	v := fmt.Sprintf("%.2f", float64(size)/float64(div))
+	v = strings.TrimRight(strings.TrimRight(v, "0"), ".")
+	return v + string("KMGTPE"[exp])
+}
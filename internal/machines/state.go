@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/hooks"
 	"github.com/ubuntu/zsys/internal/i18n"
 	"github.com/ubuntu/zsys/internal/log"
 	"github.com/ubuntu/zsys/internal/zfs"
@@ -213,6 +214,26 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 		log.Debugf(ctx, "    - %s", d.Name)
 	}
 
+	// ZFS refuses to destroy held snapshots: don't start removing anything, even if forced.
+	var held string
+	for _, curr := range states {
+		// Linked states are only untagged
+		if curr.linkedStateID != "" {
+			continue
+		}
+		if tags := curr.holds(); len(tags) > 0 {
+			held += fmt.Sprintf(i18n.G("  - %s (held by %s)\n"), curr.ID, strings.Join(tags, ", "))
+		}
+	}
+	for _, d := range datasets {
+		if len(d.Holds) > 0 {
+			held += fmt.Sprintf(i18n.G("  - %s (held by %s)\n"), d.Name, strings.Join(d.Holds, ", "))
+		}
+	}
+	if held != "" {
+		return fmt.Errorf(i18n.G("%s would remove some held snapshots, release them first:\n%s"), s.ID, held)
+	}
+
 	if !force {
 		var errmsg string
 		// Check that current state is not linked to a system state.
@@ -256,6 +277,19 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 				errmsg += fmt.Sprintf(i18n.G("  - %s (%s)%s\n"), curr.ID, lu, additionalInfo)
 			}
 		}
+		var pinned []string
+		for _, curr := range states {
+			// Linked states are only untagged
+			if curr.linkedStateID == "" && curr.IsPinned() {
+				pinned = append(pinned, curr.ID)
+			}
+		}
+		if len(pinned) > 0 {
+			errmsg += fmt.Sprintf(i18n.G("%s would remove some pinned states:\n"), s.ID)
+			for _, id := range pinned {
+				errmsg += fmt.Sprintf(i18n.G("  - %s\n"), id)
+			}
+		}
 		if len(datasets) > 0 {
 			errmsg += fmt.Sprintf(i18n.G("%s has a dependency on some datasets:\n"), s.ID)
 			for i := len(datasets) - 1; i >= 0; i-- {
@@ -267,6 +301,13 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 		}
 	}
 
+	env := hooksEnv(s.ID, user, append(s.getDatasets(), datasets...))
+	if dryrun {
+		log.RemotePrintf(ctx, i18n.G("Removing %s would free at least %s\n"), s.ID, humanSize(reclaimableSpace(states, datasets)))
+	} else if err := ms.hooksRunner().Run(ctx, hooks.PreRemove, env); err != nil {
+		return fmt.Errorf(i18n.G("couldn't remove state %s: ")+config.ErrorFormat, s.ID, err)
+	}
+
 	// Remove datasets
 	nt := ms.z.NewNoTransaction(ctx)
 	for _, d := range datasets {
@@ -291,6 +332,9 @@ func (ms *Machines) RemoveState(ctx context.Context, name, user string, force, d
 	}
 
 	ms.refresh(ctx)
+	if !dryrun {
+		ms.hooksRunner().RunPost(ctx, hooks.PostRemove, env)
+	}
 	return nil
 }
 
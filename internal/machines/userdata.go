@@ -20,9 +20,41 @@ import (
 	"github.com/ubuntu/zsys/internal/zfs/libzfs"
 )
 
+// UserDataOption customizes the creation of a user dataset.
+type UserDataOption func(*userDataOptions)
+
+type userDataOptions struct {
+	encrypt    *bool
+	passphrase []byte
+}
+
+// WithEncryption overrides the configuration default on encrypting the user dataset.
+func WithEncryption(encrypt bool) UserDataOption {
+	return func(o *userDataOptions) {
+		o.encrypt = &encrypt
+	}
+}
+
+// WithPassphrase sets the passphrase unlocking the user dataset, if it is encrypted.
+func WithPassphrase(passphrase []byte) UserDataOption {
+	return func(o *userDataOptions) {
+		o.passphrase = passphrase
+	}
+}
+
 // CreateUserData creates a new dataset for homepath and attach to current system.
 // It creates intermediates user datasets if needed.
-func (ms *Machines) CreateUserData(ctx context.Context, user, homepath string) error {
+// The new dataset is encrypted if the configuration says so, unless options override it.
+func (ms *Machines) CreateUserData(ctx context.Context, user, homepath string, opts ...UserDataOption) error {
+	args := userDataOptions{}
+	for _, o := range opts {
+		o(&args)
+	}
+	encrypt := ms.conf.UserData.Encryption
+	if args.encrypt != nil {
+		encrypt = *args.encrypt
+	}
+
 	if !ms.current.isZsys() {
 		return errors.New(i18n.G("Current machine isn't Zsys, nothing to create"))
 	}
@@ -32,6 +64,9 @@ func (ms *Machines) CreateUserData(ctx context.Context, user, homepath string) e
 	if homepath == "" {
 		return errors.New(i18n.G("Needs a valid home path, got nothing"))
 	}
+	if encrypt && len(args.passphrase) == 0 {
+		return errors.New(i18n.G("Needs a passphrase to create an encrypted user dataset, got nothing"))
+	}
 
 	t, cancel := ms.z.NewTransaction(ctx)
 	defer t.Done()
@@ -61,22 +96,39 @@ selectUserDataset:
 		userdatasetRoot = getUserDatasetRoot(ms.allUsersDatasets[0].Name)
 	}
 
-	// If there is still none found, check if there is only USERDATA with no user under it as it won't shows up in machines
+	// If there is still none found, check if there is only USERDATA with no user under it as it won't shows up in machines.
+	// Configured pools are checked in order of preference.
+	userdataPools := ms.conf.Pools.UserData
 	if userdatasetRoot == "" {
+		containers := make(map[string]string)
 		for _, d := range ms.z.Datasets() {
-			if strings.HasSuffix(strings.ToLower(d.Name)+"/", userdatasetsContainerName) {
+			if d.Backup || !inPools(d.Name, userdataPools) {
+				continue
+			}
+			if !strings.HasSuffix(strings.ToLower(d.Name)+"/", userdatasetsContainerName) {
+				continue
+			}
+			if len(userdataPools) == 0 {
 				userdatasetRoot = d.Name
 				break
 			}
+			if _, exists := containers[poolName(d.Name)]; !exists {
+				containers[poolName(d.Name)] = d.Name
+			}
+		}
+		for _, p := range userdataPools {
+			if c, ok := containers[p]; ok {
+				userdatasetRoot = c
+				break
+			}
 		}
 	}
 
-	// If there is still none found, take the current system pool and create one
+	// If there is still none found, take the first configured user data pool, or the current system pool, and create one
 	if userdatasetRoot == "" {
-		p := ms.current.ID
-		i := strings.Index(p, "/")
-		if i != -1 {
-			p = p[:i]
+		p := poolName(ms.current.ID)
+		if len(userdataPools) > 0 {
+			p = userdataPools[0]
 		}
 		userdatasetRoot = filepath.Join(p, zfs.UserdataPrefix)
 
@@ -88,10 +140,22 @@ selectUserDataset:
 	}
 
 	userdataset := filepath.Join(userdatasetRoot, fmt.Sprintf("%s_%s", user, t.Zfs.GenerateID(6)))
-	if err := t.Create(userdataset, homepath, "on"); err != nil {
+	if encrypt {
+		log.Infof(ctx, i18n.G("Encrypt user dataset for %q"), homepath)
+		err = t.CreateEncrypted(userdataset, homepath, "on", args.passphrase)
+	} else {
+		err = t.Create(userdataset, homepath, "on")
+	}
+	if err != nil {
 		cancel()
 		return err
 	}
+	if quota, reservation := ms.conf.UserData.Quota, ms.conf.UserData.Reservation; quota > 0 || reservation > 0 {
+		if err := setQuota(t, userdataset, uint64(quota)*1024*1024, uint64(reservation)*1024*1024); err != nil {
+			cancel()
+			return err
+		}
+	}
 	// FIXME: mount the dataset here, we should have that in Create() but mitigate the impact for focal release
 	if err := syscall.Mount(userdataset, homepath, "zfs", 0, "zfsutil"); err != nil {
 		log.Warningf(ctx, i18n.G("Couldn't mount %s: %v"), homepath, err)
@@ -38,6 +38,7 @@ func StartLocalSystemBus(t *testing.T) func() {
   <listen>unix:tmpdir=/tmp</listen>
   <standard_system_servicedirs />
   <policy context="default">
+    <allow user="*"/>
     <allow send_destination="*" eavesdrop="true"/>
     <allow eavesdrop="true"/>
     <allow own="*"/>
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -38,11 +39,20 @@ type fakePool struct {
 		IsVolume         bool
 		Mountpoint       string
 		CanMount         string
-		ZsysBootfs       string    `yaml:"zsys_bootfs"`
-		LastUsed         time.Time `yaml:"last_used"`
-		LastBootedKernel string    `yaml:"last_booted_kernel"`
-		BootfsDatasets   string    `yaml:"bootfs_datasets"`
-		Origin           string    `yaml:"origin"`
+		ZsysBootfs       string            `yaml:"zsys_bootfs"`
+		LastUsed         time.Time         `yaml:"last_used"`
+		LastBootedKernel string            `yaml:"last_booted_kernel"`
+		BootfsDatasets   string            `yaml:"bootfs_datasets"`
+		PendingRevert    string            `yaml:"pending_revert"`
+		Pinned           string            `yaml:"pinned"`
+		Description      string            `yaml:"description"`
+		Labels           map[string]string `yaml:"labels"`
+		Backup           string            `yaml:"backup"`
+		Origin           string            `yaml:"origin"`
+		Key              string            `yaml:"key"` // Passphrase of a new encryption root, if not empty.
+		Refquota         string            `yaml:"refquota"`
+		Reservation      string            `yaml:"reservation"`
+		Space            space             `yaml:",inline"`
 		Snapshots        orderedSnapshots
 	}
 }
@@ -51,14 +61,53 @@ type orderedSnapshots []struct {
 	Name             string
 	Mountpoint       string
 	CanMount         string
-	ZsysBootfs       string     `yaml:"zsys_bootfs"`
-	LastBootedKernel string     `yaml:"last_booted_kernel"`
-	BootfsDatasets   string     `yaml:"bootfs_datasets"`
-	CreationTime     *time.Time `yaml:"creation_time"` // Snapshot creation time, only work for mock usage.
+	ZsysBootfs       string            `yaml:"zsys_bootfs"`
+	LastBootedKernel string            `yaml:"last_booted_kernel"`
+	BootfsDatasets   string            `yaml:"bootfs_datasets"`
+	Pinned           string            `yaml:"pinned"`
+	Description      string            `yaml:"description"`
+	Labels           map[string]string `yaml:"labels"`        // Values are in "value:source" format, like other snapshot user properties.
+	CreationTime     *time.Time        `yaml:"creation_time"` // Snapshot creation time, only work for mock usage.
+	Holds            []string          `yaml:"holds"`         // Hold tags placed on the snapshot.
+	Space            space             `yaml:",inline"`
 	//TODO: one libzfs support bookmarks
 	//BookMarks        []string
 }
 
+// space is the dataset space accounting, in bytes. Only work for mock usage.
+type space struct {
+	Used            string `yaml:"used"`
+	Referenced      string `yaml:"referenced"`
+	UsedBySnapshots string `yaml:"used_by_snapshots"`
+	Written         string `yaml:"written"`
+}
+
+// props returns space accounting as read only properties.
+func (s space) props() map[libzfs.Prop]string {
+	props := make(map[libzfs.Prop]string)
+	for p, v := range map[libzfs.Prop]string{
+		libzfs.DatasetPropUsed:            s.Used,
+		libzfs.DatasetPropReferenced:      s.Referenced,
+		libzfs.DatasetPropUsedbysnapshots: s.UsedBySnapshots,
+		libzfs.DatasetPropWritten:         s.Written,
+	} {
+		if v != "" {
+			props[p] = v
+		}
+	}
+	return props
+}
+
+// labelsIndex returns the sorted list of label keys, as stored in the labels index user property.
+func labelsIndex(labels map[string]string) string {
+	var keys []string
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
 func (s orderedSnapshots) Len() int           { return len(s) }
 func (s orderedSnapshots) Less(i, j int) bool { return s[i].Name < s[j].Name }
 func (s orderedSnapshots) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
@@ -219,7 +268,15 @@ func (fpools FakePools) Create(path string) func() {
 						props[libzfs.DatasetPropVolsize] = libzfs.Property{Value: strSize}
 					}
 
-					d, err = fpools.libzfs.DatasetCreate(datasetName, dType, props)
+					var key []byte
+					if dataset.Key != "" {
+						props[libzfs.DatasetPropEncryption] = libzfs.Property{Value: libzfs.EncryptionOn}
+						props[libzfs.DatasetPropKeyFormat] = libzfs.Property{Value: libzfs.KeyFormatPassphrase}
+						props[libzfs.DatasetPropKeyLocation] = libzfs.Property{Value: libzfs.KeyLocationPrompt}
+						key = []byte(dataset.Key)
+					}
+
+					d, err = fpools.libzfs.DatasetCreate(datasetName, dType, props, key)
 					if err != nil {
 						fpools.Fatalf("couldn't create dataset %q: %v", datasetName, err)
 					}
@@ -251,12 +308,44 @@ func (fpools FakePools) Create(path string) func() {
 				if dataset.BootfsDatasets != "" {
 					d.SetUserProperty(libzfs.BootfsDatasetsProp, dataset.BootfsDatasets)
 				}
+				if dataset.PendingRevert != "" {
+					d.SetUserProperty(libzfs.PendingRevertProp, dataset.PendingRevert)
+				}
+				if dataset.Pinned != "" {
+					d.SetUserProperty(libzfs.PinnedProp, dataset.Pinned)
+				}
+				if dataset.Description != "" {
+					d.SetUserProperty(libzfs.DescriptionProp, dataset.Description)
+				}
+				if dataset.Backup != "" {
+					d.SetUserProperty(libzfs.BackupProp, dataset.Backup)
+				}
+				if len(dataset.Labels) > 0 {
+					for k, v := range dataset.Labels {
+						d.SetUserProperty(libzfs.LabelPropPrefix+k, v)
+					}
+					d.SetUserProperty(libzfs.LabelsProp, labelsIndex(dataset.Labels))
+				}
 				if dataset.Origin != "" {
 					if _, ok := fpools.libzfs.(*mock.LibZFS); !ok {
 						fpools.Fatalf("trying to set origin on clone for %q on real ZFS run. This is not possible", datasetName)
 					}
 					d.SetProperty(libzfs.DatasetPropOrigin, dataset.Origin)
 				}
+				if dataset.Refquota != "" {
+					d.SetProperty(libzfs.DatasetPropRefquota, dataset.Refquota)
+				}
+				if dataset.Reservation != "" {
+					d.SetProperty(libzfs.DatasetPropReservation, dataset.Reservation)
+				}
+				if spaceProps := dataset.Space.props(); len(spaceProps) > 0 {
+					if _, ok := fpools.libzfs.(*mock.LibZFS); !ok {
+						fpools.Fatalf("trying to set space accounting for %q on real ZFS run. This is not possible", datasetName)
+					}
+					for p, v := range spaceProps {
+						d.SetProperty(p, v)
+					}
+				}
 				d.Close()
 
 				snapshotWG.Add(1)
@@ -276,6 +365,14 @@ func (fpools FakePools) Create(path string) func() {
 							}
 							props[libzfs.DatasetPropCreation] = libzfs.Property{Value: strconv.FormatInt(s.CreationTime.Unix(), 10)}
 						}
+						if spaceProps := s.Space.props(); len(spaceProps) > 0 {
+							if _, ok := fpools.libzfs.(*mock.LibZFS); !ok {
+								fpools.Fatalf("trying to set space accounting for %q on real ZFS run. This is not possible", datasetName)
+							}
+							for p, v := range spaceProps {
+								props[p] = libzfs.Property{Value: v, Source: "-"}
+							}
+						}
 						userProps := make(map[string]string)
 						if s.Mountpoint != "" {
 							userProps[libzfs.SnapshotMountpointProp] = s.Mountpoint
@@ -292,11 +389,29 @@ func (fpools FakePools) Create(path string) func() {
 						if s.BootfsDatasets != "" {
 							userProps[libzfs.BootfsDatasetsProp] = s.BootfsDatasets
 						}
+						if s.Pinned != "" {
+							userProps[libzfs.PinnedProp] = s.Pinned
+						}
+						if s.Description != "" {
+							userProps[libzfs.DescriptionProp] = s.Description
+						}
+						if len(s.Labels) > 0 {
+							for k, v := range s.Labels {
+								userProps[libzfs.LabelPropPrefix+k] = v
+							}
+							userProps[libzfs.LabelsProp] = labelsIndex(s.Labels) + ":local"
+						}
 						d, err := fpools.libzfs.DatasetSnapshot(datasetName+"@"+s.Name, false, props, userProps)
 						if err != nil {
 							fmt.Fprintf(os.Stderr, "Couldn't create snapshot %q: %v\n", datasetName+"@"+s.Name, err)
 							os.Exit(1)
 						}
+						for _, tag := range s.Holds {
+							if err := d.Hold(tag); err != nil {
+								fmt.Fprintf(os.Stderr, "Couldn't hold snapshot %q with %q: %v\n", datasetName+"@"+s.Name, tag, err)
+								os.Exit(1)
+							}
+						}
 						d.Close()
 					}
 				}(dataset.Snapshots)
@@ -0,0 +1,27 @@
+package zfs
+
+import (
+	"fmt"
+
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/zfs/libzfs"
+)
+
+// Diff calls change on each path change between from snapshot and to, which is a later snapshot or filesystem dataset
+func (z Zfs) Diff(from, to string, change func(libzfs.DiffEntry) error) error {
+	d, err := z.findDatasetByName(from)
+	if err != nil {
+		return fmt.Errorf(i18n.G("cannot find %q: %v"), from, err)
+	}
+	if !d.IsSnapshot {
+		return fmt.Errorf(i18n.G("%q isn't a snapshot"), from)
+	}
+	if _, err := z.findDatasetByName(to); err != nil {
+		return fmt.Errorf(i18n.G("cannot find %q: %v"), to, err)
+	}
+
+	if err := z.libzfs.DatasetDiff(from, to, change); err != nil {
+		return fmt.Errorf(i18n.G("couldn't compare %q to %q: %v"), from, to, err)
+	}
+	return nil
+}
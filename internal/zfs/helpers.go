@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -84,6 +85,29 @@ func (d *Dataset) refreshProperties(ctx context.Context) error {
 
 	origin := dZFSprops[libzfs.DatasetPropOrigin].Value
 
+	// Not encrypted datasets report "-" or nothing, depending on the ZFS version
+	encryptionRoot := dZFSprops[libzfs.DatasetPropEncryptionRoot].Value
+	if encryptionRoot == "-" {
+		encryptionRoot = ""
+	}
+	keyLoaded := encryptionRoot != "" && dZFSprops[libzfs.DatasetPropKeyStatus].Value == libzfs.KeyStatusAvailable
+
+	used := spaceProperty(ctx, name, dZFSprops[libzfs.DatasetPropUsed])
+	referenced := spaceProperty(ctx, name, dZFSprops[libzfs.DatasetPropReferenced])
+	usedBySnapshots := spaceProperty(ctx, name, dZFSprops[libzfs.DatasetPropUsedbysnapshots])
+	written := spaceProperty(ctx, name, dZFSprops[libzfs.DatasetPropWritten])
+
+	// Quotas aren't inherited: only keep track of locally set ones
+	var quota, reservation uint64
+	if p := dZFSprops[libzfs.DatasetPropRefquota]; p.Source == "local" {
+		quota = spaceProperty(ctx, name, p)
+		sources.Quota = "local"
+	}
+	if p := dZFSprops[libzfs.DatasetPropReservation]; p.Source == "local" {
+		reservation = spaceProperty(ctx, name, p)
+		sources.Reservation = "local"
+	}
+
 	bfs, srcBootFS, err := getUserPropertyFromSys(ctx, libzfs.BootfsProp, d.dZFS)
 	if err != nil {
 		log.Warningf(ctx, i18n.G("can't read bootfs property, ignoring: ")+config.ErrorFormat, err)
@@ -139,6 +163,53 @@ func (d *Dataset) refreshProperties(ctx context.Context) error {
 	}
 	sources.BootfsDatasets = srcBootfsDatasets
 
+	var pendingRevert, srcPendingRevert string
+	if !d.IsSnapshot {
+		if pendingRevert, srcPendingRevert, err = getUserPropertyFromSys(ctx, libzfs.PendingRevertProp, d.dZFS); err != nil {
+			log.Warningf(ctx, i18n.G("can't read pending revert property, ignoring: ")+config.ErrorFormat, err)
+		}
+		// A pending revert only applies to the root dataset it's set on: ignore it on inheriting children.
+		if srcPendingRevert != "local" {
+			pendingRevert, srcPendingRevert = "", ""
+		}
+	}
+	sources.PendingRevert = srcPendingRevert
+
+	pinned, srcPinned, err := getUserPropertyFromSys(ctx, libzfs.PinnedProp, d.dZFS)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("can't read pinned property, ignoring: ")+config.ErrorFormat, err)
+	}
+	sources.Pinned = srcPinned
+
+	description, srcDescription, err := getUserPropertyFromSys(ctx, libzfs.DescriptionProp, d.dZFS)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("can't read description property, ignoring: ")+config.ErrorFormat, err)
+	}
+	sources.Description = srcDescription
+
+	labels, srcLabels := getLabelsFromSys(ctx, d.dZFS)
+	sources.Labels = srcLabels
+
+	var backup, srcBackup string
+	if !d.IsSnapshot {
+		if backup, srcBackup, err = getUserPropertyFromSys(ctx, libzfs.BackupProp, d.dZFS); err != nil {
+			log.Warningf(ctx, i18n.G("can't read backup property, ignoring: ")+config.ErrorFormat, err)
+		}
+	}
+	sources.Backup = srcBackup
+
+	var holds []string
+	if d.IsSnapshot {
+		tags, err := d.dZFS.Holds()
+		if err != nil {
+			log.Warningf(ctx, i18n.G("can't read holds of %q, ignoring: ")+config.ErrorFormat, name, err)
+		}
+		for _, t := range tags {
+			holds = append(holds, t.Name)
+		}
+		sort.Strings(holds)
+	}
+
 	d.DatasetProp = DatasetProp{
 		Mountpoint:       mountpoint,
 		CanMount:         canMount,
@@ -147,12 +218,40 @@ func (d *Dataset) refreshProperties(ctx context.Context) error {
 		LastUsed:         lastUsed,
 		LastBootedKernel: lastBootedKernel,
 		BootfsDatasets:   bootfsDatasets,
+		PendingRevert:    pendingRevert,
+		Pinned:           pinned == "yes",
+		Description:      description,
+		Labels:           labels,
+		Backup:           backup == "yes",
 		Origin:           origin,
+		Used:             used,
+		Referenced:       referenced,
+		UsedBySnapshots:  usedBySnapshots,
+		Written:          written,
+		Quota:            quota,
+		Reservation:      reservation,
+		EncryptionRoot:   encryptionRoot,
+		KeyLoaded:        keyLoaded,
+		Holds:            holds,
 		sources:          sources,
 	}
 	return nil
 }
 
+// spaceProperty returns the number of bytes of a space accounting property.
+// Unset or not applicable properties (like usedbysnapshots on snapshots) are 0, as are unset quotas.
+func spaceProperty(ctx context.Context, name string, p libzfs.Property) uint64 {
+	if p.Value == "" || p.Value == "-" || p.Value == "none" {
+		return 0
+	}
+	v, err := strconv.ParseUint(p.Value, 10, 64)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("%q has an invalid space property value %q, ignoring: ")+config.ErrorFormat, name, p.Value, err)
+		return 0
+	}
+	return v
+}
+
 // getUserPropertyFromSys returns the value of a user property and its source from the underlying
 // ZFS system dataset state.
 // It also sanitize the sources to only return "local" or "inherited".
@@ -196,6 +295,30 @@ func getUserPropertyFromSys(ctx context.Context, prop string, dZFS libzfs.DZFSIn
 	return value, source, nil
 }
 
+// getLabelsFromSys returns the labels listed in the labels index user property and the source of this index
+// from the underlying ZFS system dataset state.
+func getLabelsFromSys(ctx context.Context, dZFS libzfs.DZFSInterface) (map[string]string, string) {
+	keys, source, err := getUserPropertyFromSys(ctx, libzfs.LabelsProp, dZFS)
+	if err != nil {
+		log.Warningf(ctx, i18n.G("can't read labels property, ignoring: ")+config.ErrorFormat, err)
+		return nil, ""
+	}
+	if keys == "" {
+		return nil, source
+	}
+
+	labels := make(map[string]string)
+	for _, k := range strings.Split(keys, labelsSeparator) {
+		v, _, err := getUserPropertyFromSys(ctx, libzfs.LabelPropPrefix+k, dZFS)
+		if err != nil {
+			log.Warningf(ctx, i18n.G("can't read label %q, ignoring: ")+config.ErrorFormat, k, err)
+			continue
+		}
+		labels[k] = v
+	}
+	return labels, source
+}
+
 // newDatasetTree returns a Dataset and a populated tree of all its children
 // It returns a nil Dataset with a nil error for unsupported dataset type (DatasetTypeVolume or DatasetTypeBookmark)
 func newDatasetTree(ctx context.Context, dZFS libzfs.DZFSInterface, allDatasets *map[string]*Dataset) (*Dataset, error) {
@@ -302,6 +425,26 @@ func (d Dataset) HasSnapshotInHierarchy() bool {
 	return false
 }
 
+// UniqueSpace returns the space, in bytes, only consumed by this dataset: its descendent filesystems and snapshots are excluded.
+// This is what destroying this dataset alone would free.
+func (d Dataset) UniqueSpace() uint64 {
+	if d.IsSnapshot {
+		return d.Used
+	}
+
+	excluded := d.UsedBySnapshots
+	for _, c := range d.children {
+		if c.IsSnapshot {
+			continue
+		}
+		excluded += c.Used
+	}
+	if excluded > d.Used {
+		return 0
+	}
+	return d.Used - excluded
+}
+
 // IsUserDataset returns if this filesystem dataset is or has been a userdataset, even if unlinked to any filesystem dataset
 // Note that it doesn’t take into account if the dataset is a clone of a userdataset.
 // Snapshots will always return an error, check the filesystem dataset first.
@@ -380,6 +523,17 @@ func (d *Dataset) setProperty(name, value, source string) (err error) {
 			return err
 		}
 
+		// Keep the labels index in sync, as user properties can't be enumerated
+		if key := strings.TrimPrefix(name, libzfs.LabelPropPrefix); key != name {
+			index := d.labelsIndex(key, value)
+			if d.IsSnapshot && source != "" {
+				index = fmt.Sprintf("%s:%s", index, source)
+			}
+			if err := d.dZFS.SetUserProperty(libzfs.LabelsProp, index); err != nil {
+				return err
+			}
+		}
+
 		// TODO: remove once we mock rather time.Now() for mock tests
 		// Reload last used property from backend (as we can have set it to magic time)
 		if name == libzfs.LastUsedProp {
@@ -415,20 +569,37 @@ func (d *Dataset) setProperty(name, value, source string) (err error) {
 			bootFS = true
 		}
 		d.BootFS = bootFS
+	case libzfs.PinnedProp:
+		d.Pinned = value == "yes"
+	case libzfs.BackupProp:
+		d.Backup = value == "yes"
 	case libzfs.LastUsedProp:
 		lastUsed, err := strconv.Atoi(value)
 		if err != nil {
 			panic(fmt.Sprintf("%q property isn't an int: %v, while it has already been checked for main dataset and passed", libzfs.LastUsedProp, err))
 		}
 		d.LastUsed = lastUsed
+	case libzfs.RefquotaProp:
+		d.Quota = spaceProperty(context.Background(), d.Name, libzfs.Property{Value: value})
+	case libzfs.ReservationProp:
+		d.Reservation = spaceProperty(context.Background(), d.Name, libzfs.Property{Value: value})
 	case libzfs.MountPointProp:
 		oldMountPoint = *destV
 		fallthrough
 	default:
+		if key := strings.TrimPrefix(name, libzfs.LabelPropPrefix); key != name {
+			d.setLabel(key, value)
+			break
+		}
 		*destV = value
 	}
 	*destS = source
 
+	// Pending revert isn't inherited: children ignore it on refresh.
+	if name == libzfs.PendingRevertProp {
+		return nil
+	}
+
 	// Refresh all children that inherits from this property.
 	children := make(chan *Dataset)
 	var getInheritedChildren func(d *Dataset)
@@ -475,6 +646,10 @@ func (d *Dataset) setProperty(name, value, source string) (err error) {
 				bootFS = true
 			}
 			c.BootFS = bootFS
+		case libzfs.PinnedProp:
+			c.Pinned = value == "yes"
+		case libzfs.BackupProp:
+			c.Backup = value == "yes"
 		case libzfs.LastUsedProp:
 			lastUsed, err := strconv.Atoi(value)
 			if err != nil {
@@ -485,6 +660,10 @@ func (d *Dataset) setProperty(name, value, source string) (err error) {
 		case libzfs.MountPointProp:
 			*destV = filepath.Join(value, strings.TrimPrefix(*destV, oldMountPoint))
 		default:
+			if key := strings.TrimPrefix(name, libzfs.LabelPropPrefix); key != name {
+				c.setLabel(key, value)
+				break
+			}
 			*destV = value
 		}
 		*destS = "inherited"
@@ -539,12 +718,77 @@ func (d *Dataset) stringToProp(name string) (nativeProp libzfs.Prop, userProp st
 	case libzfs.LastBootedKernelProp:
 		value = &d.LastBootedKernel
 		simplifiedSource = &d.sources.LastBootedKernel
+	case libzfs.PendingRevertProp:
+		value = &d.PendingRevert
+		simplifiedSource = &d.sources.PendingRevert
+	case libzfs.PinnedProp:
+		pinned := "yes"
+		if !d.Pinned {
+			pinned = "no"
+		}
+		value = &pinned
+		simplifiedSource = &d.sources.Pinned
+	case libzfs.BackupProp:
+		backup := "yes"
+		if !d.Backup {
+			backup = "no"
+		}
+		value = &backup
+		simplifiedSource = &d.sources.Backup
+	// Quotas are non string. Return a local string
+	case libzfs.RefquotaProp:
+		nativeProp = libzfs.DatasetPropRefquota
+		quota := strconv.FormatUint(d.Quota, 10)
+		value = &quota
+		simplifiedSource = &d.sources.Quota
+	case libzfs.ReservationProp:
+		nativeProp = libzfs.DatasetPropReservation
+		reservation := strconv.FormatUint(d.Reservation, 10)
+		value = &reservation
+		simplifiedSource = &d.sources.Reservation
+	case libzfs.DescriptionProp:
+		value = &d.Description
+		simplifiedSource = &d.sources.Description
 	default:
+		// Labels are stored in a map. Return a local string
+		if key := strings.TrimPrefix(name, libzfs.LabelPropPrefix); key != name {
+			label := d.Labels[key]
+			value = &label
+			simplifiedSource = &d.sources.Labels
+			break
+		}
 		panic(fmt.Sprintf("unsupported property %q", name))
 	}
 	return nativeProp, userProp, value, simplifiedSource
 }
 
+// setLabel sets or, if value is empty, removes the label key on the dataset object.
+func (d *Dataset) setLabel(key, value string) {
+	if value == "" {
+		delete(d.Labels, key)
+		return
+	}
+	if d.Labels == nil {
+		d.Labels = make(map[string]string)
+	}
+	d.Labels[key] = value
+}
+
+// labelsIndex returns the labels index property value once label key is set to value.
+func (d Dataset) labelsIndex(key, value string) string {
+	var keys []string
+	for k := range d.Labels {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	if value != "" {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, labelsSeparator)
+}
+
 // inverseOrigin inverses on the Dataset object themselves the dependence hierarchy.
 // It refreshes the global hierarchy as well, as snapshots are migrating.
 func (t *nestedTransaction) inverseOrigin(oldOrigDataset, newOrigDataset *Dataset) error {
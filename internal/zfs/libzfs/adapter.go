@@ -1,6 +1,8 @@
 package libzfs
 
 import (
+	"os"
+
 	golibzfs "github.com/bicomsystems/go-libzfs"
 )
 
@@ -22,6 +24,8 @@ type (
 	DatasetType = golibzfs.DatasetType
 	// DatasetProperties type is map of dataset or volume properties prop -> value
 	DatasetProperties = golibzfs.DatasetProperties
+	// HoldTag is a user hold on a snapshot, with its tag name and when it was placed
+	HoldTag = golibzfs.HoldTag
 )
 
 const (
@@ -29,6 +33,8 @@ const (
 	PoolPropAltroot = golibzfs.PoolPropAltroot
 	// PoolPropCapacity ZFS Pool property
 	PoolPropCapacity = golibzfs.PoolPropCapacity
+	// PoolPropSize ZFS Pool property
+	PoolPropSize = golibzfs.PoolPropSize
 	// PoolNumProps is the end pool number property
 	PoolNumProps = golibzfs.PoolNumProps
 	// VDevTypeFile is the vdevtype on file
@@ -55,6 +61,41 @@ const (
 	DatasetPropCreation = golibzfs.DatasetPropCreation
 	// DatasetPropVolsize is the volume size property for the dataset
 	DatasetPropVolsize = golibzfs.DatasetPropVolsize
+	// DatasetPropUsed is the space consumed by the dataset and all its descendents
+	DatasetPropUsed = golibzfs.DatasetPropUsed
+	// DatasetPropReferenced is the amount of data accessible by the dataset
+	DatasetPropReferenced = golibzfs.DatasetPropReferenced
+	// DatasetPropUsedbysnapshots is the space consumed by snapshots of the dataset
+	DatasetPropUsedbysnapshots = golibzfs.DatasetPropUsedsnap
+	// DatasetPropWritten is the space written to the dataset since the previous snapshot
+	DatasetPropWritten = golibzfs.DatasetPropWritten
+	// DatasetPropRefquota is the maximum space the dataset can reference, excluding descendents and snapshots
+	DatasetPropRefquota = golibzfs.DatasetPropRefquota
+	// DatasetPropReservation is the space guaranteed to the dataset and its descendents
+	DatasetPropReservation = golibzfs.DatasetPropReservation
+	// DatasetPropEncryption is the encryption suite of the dataset, "off" if not encrypted
+	DatasetPropEncryption = golibzfs.DatasetPropEncryption
+	// DatasetPropKeyFormat is the format of the key of an encryption root
+	DatasetPropKeyFormat = golibzfs.DatasetPropKeyFormat
+	// DatasetPropKeyLocation is where the key of an encryption root is loaded from
+	DatasetPropKeyLocation = golibzfs.DatasetPropKeyLocation
+	// DatasetPropEncryptionRoot is the dataset holding the key of an encrypted dataset
+	DatasetPropEncryptionRoot = golibzfs.DatasetPropEncryptionRoot
+	// DatasetPropKeyStatus reports if the key of an encrypted dataset is loaded
+	DatasetPropKeyStatus = golibzfs.DatasetPropKeyStatus
+)
+
+const (
+	// EncryptionOn enables the default encryption suite
+	EncryptionOn = "on"
+	// KeyFormatPassphrase derives the wrapping key from a passphrase
+	KeyFormatPassphrase = "passphrase"
+	// KeyLocationPrompt requests the key when it's loaded
+	KeyLocationPrompt = "prompt"
+	// KeyStatusAvailable is the key status of an encrypted dataset with its key loaded
+	KeyStatusAvailable = "available"
+	// KeyStatusUnavailable is the key status of an encrypted dataset with its key not loaded
+	KeyStatusUnavailable = "unavailable"
 )
 
 const (
@@ -67,12 +108,28 @@ const (
 	BootfsDatasetsProp = zsysPrefix + "bootfs-datasets"
 	// LastBootedKernelProp string value
 	LastBootedKernelProp = zsysPrefix + "last-booted-kernel"
+	// PendingRevertProp string value
+	PendingRevertProp = zsysPrefix + "pending-revert"
+	// PinnedProp string value
+	PinnedProp = zsysPrefix + "pinned"
+	// DescriptionProp string value
+	DescriptionProp = zsysPrefix + "description"
+	// LabelPropPrefix is the prefix of each label user property, followed by the label key
+	LabelPropPrefix = zsysPrefix + "label:"
+	// LabelsProp lists label keys set on a dataset, as user properties can't be enumerated
+	LabelsProp = zsysPrefix + "labels"
+	// BackupProp marks a dataset and its children as holding exported states, which aren't zsys managed
+	BackupProp = zsysPrefix + "backup"
 	// CanmountProp string value
 	CanmountProp = "canmount"
 	// SnapshotCanmountProp is the equivalent to CanmountProp, but as a user property to store on zsys snapshot
 	SnapshotCanmountProp = zsysPrefix + CanmountProp
 	// MountPointProp string value
 	MountPointProp = "mountpoint"
+	// RefquotaProp string value, in bytes
+	RefquotaProp = "refquota"
+	// ReservationProp string value, in bytes
+	ReservationProp = "reservation"
 	// SnapshotMountpointProp is the equivalent to MountPointProp, but as a user property to store on zsys snapshot
 	SnapshotMountpointProp = zsysPrefix + MountPointProp
 )
@@ -82,11 +139,36 @@ type Interface interface {
 	PoolOpen(name string) (pool Pool, err error)
 	DatasetOpenAll() (datasets []DZFSInterface, err error)
 	DatasetOpen(name string) (d DZFSInterface, err error)
-	DatasetCreate(path string, dtype DatasetType, props map[Prop]Property) (d DZFSInterface, err error)
+	DatasetCreate(path string, dtype DatasetType, props map[Prop]Property, key []byte) (d DZFSInterface, err error)
+	DatasetLoadKey(name string, key []byte) (err error)
 	DatasetSnapshot(path string, recur bool, props map[Prop]Property, userProps map[string]string) (rd DZFSInterface, err error)
+	DatasetDiff(from, to string, change func(DiffEntry) error) (err error)
+	DatasetSend(name, from string, outf *os.File) (err error)
+	DatasetReceive(parent string, inf *os.File) (err error)
 	GenerateID(length int) string
 }
 
+// DiffEntry is a path change between a snapshot and a later snapshot or filesystem, as reported by zfs diff.
+type DiffEntry struct {
+	// Change is the type of change: DiffAdded, DiffRemoved, DiffModified or DiffRenamed.
+	Change string
+	// Path is the changed path.
+	Path string
+	// NewPath is the destination path of a renamed path.
+	NewPath string `json:",omitempty"`
+}
+
+const (
+	// DiffAdded is the change type of a created path
+	DiffAdded = "+"
+	// DiffRemoved is the change type of a removed path
+	DiffRemoved = "-"
+	// DiffModified is the change type of a modified path
+	DiffModified = "M"
+	// DiffRenamed is the change type of a renamed path
+	DiffRenamed = "R"
+)
+
 // DZFSInterface is the interface to use real libzfs Dataset object or in memory mock.
 type DZFSInterface interface {
 	DZFSChildren() *[]Dataset
@@ -96,11 +178,15 @@ type DZFSInterface interface {
 	Close()
 	Destroy(Defer bool) (err error)
 	GetUserProperty(p string) (prop Property, err error)
+	Hold(tag string) (err error)
+	Holds() (tags []HoldTag, err error)
 	IsSnapshot() (ok bool)
 	Pool() (p Pool, err error)
 	Promote() (err error)
 	Properties() *map[Prop]Property
 	ReloadProperties() (err error)
+	Release(tag string) (err error)
+	Rename(newName string, recur, forceUnmount bool) (err error)
 	SetUserProperty(prop, value string) error
 	SetProperty(p Prop, value string) error
 	Type() DatasetType
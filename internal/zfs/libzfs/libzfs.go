@@ -1,7 +1,15 @@
 package libzfs
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
 	"math/rand"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,13 +52,48 @@ func (Adapter) DatasetOpen(name string) (DZFSInterface, error) {
 	return dZFSAdapter{&d}, nil
 }
 
-// DatasetCreate creates a dataset
-func (*Adapter) DatasetCreate(path string, dtype DatasetType, props map[Prop]Property) (DZFSInterface, error) {
-	d, err := golibzfs.DatasetCreate(path, dtype, props)
-	if err != nil {
+// DatasetCreate creates a dataset.
+// A new encryption root is created with key, prompted by libzfs on its standard input, if key isn't nil:
+// we rely on the zfs command to pass it.
+func (a *Adapter) DatasetCreate(path string, dtype DatasetType, props map[Prop]Property, key []byte) (DZFSInterface, error) {
+	if key == nil {
+		d, err := golibzfs.DatasetCreate(path, dtype, props)
+		if err != nil {
+			return dZFSAdapter{}, err
+		}
+		return dZFSAdapter{&d}, nil
+	}
+
+	if dtype != DatasetTypeFilesystem {
+		return dZFSAdapter{}, fmt.Errorf("only filesystems can be created with a key, %q isn't", path)
+	}
+	args := []string{"create"}
+	for p, v := range props {
+		args = append(args, "-o", fmt.Sprintf("%s=%s", golibzfs.DatasetPropertyToName(p), v.Value))
+	}
+	args = append(args, path)
+	if err := runWithKey(key, "zfs", args...); err != nil {
 		return dZFSAdapter{}, err
 	}
-	return dZFSAdapter{&d}, nil
+	return a.DatasetOpen(path)
+}
+
+// DatasetLoadKey loads the key of the encryption root name.
+// libzfs prompts for keys on its standard input, so we rely on the zfs command to pass it.
+func (*Adapter) DatasetLoadKey(name string, key []byte) error {
+	return runWithKey(key, "zfs", "load-key", "-L", KeyLocationPrompt, name)
+}
+
+// runWithKey runs the command, passing key on its standard input.
+func runWithKey(key []byte, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(key)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s failed: %v: %s", name, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
 }
 
 // DatasetSnapshot creates a snapshot
@@ -62,6 +105,94 @@ func (*Adapter) DatasetSnapshot(path string, recur bool, props map[Prop]Property
 	return dZFSAdapter{&d}, nil
 }
 
+// DatasetDiff calls change on each path change between from snapshot and to snapshot or filesystem, as they are
+// listed. libzfs doesn't expose diff, so we rely on the zfs command.
+func (*Adapter) DatasetDiff(from, to string, change func(DiffEntry) error) (err error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("zfs", "diff", "-H", from, to)
+	cmd.Stderr = &stderr
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("zfs diff %s %s failed: %v", from, to, err)
+	}
+	defer func() {
+		if err != nil {
+			cmd.Process.Kill()
+		}
+		if errWait := cmd.Wait(); errWait != nil && err == nil {
+			err = fmt.Errorf("zfs diff %s %s failed: %v: %s", from, to, errWait, strings.TrimSpace(stderr.String()))
+		}
+	}()
+
+	return parseDiff(out, change)
+}
+
+// parseDiff parses zfs diff -H output: change type, path and new path for renames, separated by tabs.
+func parseDiff(r io.Reader, change func(DiffEntry) error) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		c := DiffEntry{Change: fields[0], Path: unescapeDiffPath(fields[1])}
+		if c.Change == DiffRenamed && len(fields) > 2 {
+			c.NewPath = unescapeDiffPath(fields[2])
+		}
+		if err := change(c); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// unescapeDiffPath decodes non printable characters, which zfs diff escapes as \0ooo octal sequences.
+func unescapeDiffPath(p string) string {
+	var r strings.Builder
+	for i := 0; i < len(p); i++ {
+		if p[i] == '\\' && i+5 <= len(p) && p[i+1] == '0' {
+			if v, err := strconv.ParseUint(p[i+2:i+5], 8, 8); err == nil {
+				r.WriteByte(byte(v))
+				i += 4
+				continue
+			}
+		}
+		r.WriteByte(p[i])
+	}
+	return r.String()
+}
+
+// DatasetSend writes a stream of snapshot name, with its properties, to outf.
+// The stream is incremental from the earlier snapshot from of the same filesystem if from isn't empty.
+func (*Adapter) DatasetSend(name, from string, outf *os.File) error {
+	d, err := golibzfs.DatasetOpenSingle(name)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	flags := golibzfs.SendFlags{Props: true}
+	if from == "" {
+		return d.Send(outf, flags)
+	}
+	return d.SendFrom(from, outf, flags)
+}
+
+// DatasetReceive reads a stream from inf and receives it under the existing filesystem parent.
+// The received filesystem keeps the last element of its sent name and isn't mounted.
+func (*Adapter) DatasetReceive(parent string, inf *os.File) error {
+	d, err := golibzfs.DatasetOpenSingle(parent)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Receive(inf, golibzfs.RecvFlags{IsTail: true, NoMount: true})
+}
+
 var seedOnce = sync.Once{}
 
 // GenerateID with n ascii or digits, lowercase, characters
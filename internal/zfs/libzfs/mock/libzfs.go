@@ -1,9 +1,14 @@
 package mock
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -19,11 +24,17 @@ type LibZFS struct {
 	mu       sync.RWMutex
 	datasets map[string]*dZFS
 	pools    map[string]libzfs.Pool
+	diffs    map[string][]libzfs.DiffEntry
+	// keys are the keys of each encryption root
+	keys map[string][]byte
+	// holds are the hold tags of each snapshot, with the time they were placed
+	holds map[string]map[string]time.Time
 
 	errOnCreate       bool
 	errOnClone        bool
 	errOnDestroyDS    []string
 	errOnPromote      bool
+	errOnRenameDS     []string
 	errOnScan         bool
 	errOnSetProperty  bool
 	forceLastUsedTime bool
@@ -55,7 +66,7 @@ func (l *LibZFS) PoolCreate(name string, vdev libzfs.VDevTree, features map[stri
 	for i, prop := range fsprops {
 		datasetProps[i] = libzfs.Property{Value: prop}
 	}
-	l.DatasetCreate(name, libzfs.DatasetTypeFilesystem, datasetProps)
+	l.DatasetCreate(name, libzfs.DatasetTypeFilesystem, datasetProps, nil)
 
 	return p, nil
 }
@@ -125,7 +136,7 @@ func (l *LibZFS) openChildrenFor(dm *dZFS) {
 }
 
 // DatasetCreate creates a dataset
-func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[libzfs.Prop]libzfs.Property) (libzfs.DZFSInterface, error) {
+func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[libzfs.Prop]libzfs.Property, key []byte) (libzfs.DZFSInterface, error) {
 	if l.errOnCreate {
 		return nil, errors.New("Error on Create requested")
 	}
@@ -157,6 +168,18 @@ func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[
 	props[libzfs.DatasetPropName] = libzfs.Property{Value: path}
 	userProperties := make(map[string]libzfs.Property)
 
+	// New encryption roots need a key and have it loaded at creation
+	if e, ok := props[libzfs.DatasetPropEncryption]; ok && e.Value != "off" && props[libzfs.DatasetPropEncryptionRoot].Value == "" {
+		if key == nil {
+			return nil, fmt.Errorf("encrypted dataset %q needs a key", path)
+		}
+		props[libzfs.DatasetPropEncryptionRoot] = libzfs.Property{Value: path, Source: "-"}
+		props[libzfs.DatasetPropKeyStatus] = libzfs.Property{Value: libzfs.KeyStatusAvailable, Source: "-"}
+		l.mu.Lock()
+		l.keys[path] = key
+		l.mu.Unlock()
+	}
+
 	if mp, ok := props[libzfs.DatasetPropMountpoint]; ok {
 		mp.Source = "local"
 		props[libzfs.DatasetPropMountpoint] = mp
@@ -216,7 +239,8 @@ func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[
 		}
 
 		// User properties (can only be from parent at creation time)
-		for _, k := range []string{libzfs.BootfsProp, libzfs.LastUsedProp, libzfs.BootfsDatasetsProp, libzfs.LastBootedKernelProp,
+		for _, k := range []string{libzfs.BootfsProp, libzfs.LastUsedProp, libzfs.BootfsDatasetsProp, libzfs.LastBootedKernelProp, libzfs.PendingRevertProp, libzfs.PinnedProp,
+			libzfs.DescriptionProp, libzfs.LabelsProp, libzfs.BackupProp,
 			libzfs.CanmountProp, libzfs.SnapshotCanmountProp, libzfs.MountPointProp, libzfs.SnapshotMountpointProp} {
 			if _, ok := parent.userProperties[k]; ok {
 				p := parent.userProperties[k]
@@ -226,6 +250,21 @@ func (l *LibZFS) DatasetCreate(path string, dtype libzfs.DatasetType, props map[
 				userProperties[k] = p
 			}
 		}
+		for k, p := range parent.userProperties {
+			if !strings.HasPrefix(k, libzfs.LabelPropPrefix) {
+				continue
+			}
+			if p.Source == "local" {
+				p.Source = "inherited"
+			}
+			userProperties[k] = p
+		}
+
+		// Children and snapshots are in the encryption root of their parent
+		if pr := pprops[libzfs.DatasetPropEncryptionRoot]; pr.Value != "" && props[libzfs.DatasetPropEncryptionRoot].Value == "" {
+			props[libzfs.DatasetPropEncryptionRoot] = pr
+			props[libzfs.DatasetPropKeyStatus] = pprops[libzfs.DatasetPropKeyStatus]
+		}
 	} else {
 		if _, ok := props[libzfs.DatasetPropMountpoint]; !ok {
 			props[libzfs.DatasetPropMountpoint] = libzfs.Property{
@@ -280,7 +319,7 @@ func (l *LibZFS) createSnapshot(path string, recur bool, props map[libzfs.Prop]l
 		props[libzfs.DatasetPropCreation] = libzfs.Property{Value: currentMagicTime}
 	}
 
-	dinterface, err := l.DatasetCreate(path, libzfs.DatasetTypeSnapshot, props)
+	dinterface, err := l.DatasetCreate(path, libzfs.DatasetTypeSnapshot, props, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -310,6 +349,42 @@ func (l *LibZFS) createSnapshot(path string, recur bool, props map[libzfs.Prop]l
 	return d, nil
 }
 
+// DatasetLoadKey loads the key of the encryption root name, making all its datasets available.
+func (l *LibZFS) DatasetLoadKey(name string, key []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	d, ok := l.datasets[name]
+	if !ok {
+		return fmt.Errorf("No dataset found with name %q", name)
+	}
+	if d.Dataset.Properties[libzfs.DatasetPropEncryptionRoot].Value != name {
+		return fmt.Errorf("%q isn't an encryption root", name)
+	}
+	if !bytes.Equal(l.keys[name], key) {
+		return fmt.Errorf("incorrect key provided for %q", name)
+	}
+	for _, ds := range l.datasets {
+		if ds.Dataset.Properties[libzfs.DatasetPropEncryptionRoot].Value != name {
+			continue
+		}
+		ds.Dataset.Properties[libzfs.DatasetPropKeyStatus] = libzfs.Property{Value: libzfs.KeyStatusAvailable, Source: "-"}
+	}
+	return nil
+}
+
+// UnloadKeys is a test-only helper unloading the key of every encryption root, like after a reboot.
+func (l *LibZFS) UnloadKeys() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, ds := range l.datasets {
+		if ds.Dataset.Properties[libzfs.DatasetPropEncryptionRoot].Value == "" {
+			continue
+		}
+		ds.Dataset.Properties[libzfs.DatasetPropKeyStatus] = libzfs.Property{Value: libzfs.KeyStatusUnavailable, Source: "-"}
+	}
+}
+
 // SetDatasetAsMounted is a test-only property allowing forcing one dataset to be mounted
 func (l *LibZFS) SetDatasetAsMounted(name string, mounted bool) {
 	l.mu.Lock()
@@ -322,6 +397,220 @@ func (l *LibZFS) SetDatasetAsMounted(name string, mounted bool) {
 	d.setPropertyWithSource(libzfs.DatasetPropMounted, m, "")
 }
 
+// DatasetDiff calls change on each change set with SetDatasetDiff between from and to, which should both exist.
+func (l *LibZFS) DatasetDiff(from, to string, change func(libzfs.DiffEntry) error) error {
+	l.mu.RLock()
+	if !strings.Contains(from, "@") {
+		l.mu.RUnlock()
+		return fmt.Errorf("%q is not a snapshot", from)
+	}
+	for _, n := range []string{from, to} {
+		if _, ok := l.datasets[n]; !ok {
+			l.mu.RUnlock()
+			return fmt.Errorf("No dataset found with name %q", n)
+		}
+	}
+	changes := l.diffs[from+" "+to]
+	l.mu.RUnlock()
+
+	for _, c := range changes {
+		if err := change(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetDatasetDiff is a test-only helper defining changes reported between from and to
+func (l *LibZFS) SetDatasetDiff(from, to string, changes []libzfs.DiffEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.diffs[from+" "+to] = changes
+}
+
+// sendStream is the content of a mock send stream, written as a single json line.
+type sendStream struct {
+	// Name is the sent snapshot.
+	Name string
+	// From is the snapshot the stream is incremental from, empty for full streams.
+	From string `json:",omitempty"`
+	// Creation is the creation time of the snapshot.
+	Creation string
+	// Props and UserProps are the locally set properties of the snapshot filesystem.
+	Props     map[libzfs.Prop]string `json:",omitempty"`
+	UserProps map[string]string      `json:",omitempty"`
+	// SnapshotUserProps are the locally set user properties of the snapshot.
+	SnapshotUserProps map[string]string `json:",omitempty"`
+}
+
+// DatasetSend writes a stream of snapshot name and its local properties to outf, incremental from from if not empty.
+func (l *LibZFS) DatasetSend(name, from string, outf *os.File) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	snap, ok := l.datasets[name]
+	if !ok {
+		return fmt.Errorf("No dataset found with name %q", name)
+	}
+	if !snap.IsSnapshot() {
+		return fmt.Errorf("%q is not a snapshot", name)
+	}
+	fsName := strings.Split(name, "@")[0]
+	if from != "" {
+		if strings.Split(from, "@")[0] != fsName {
+			return fmt.Errorf("incremental source %q must be in the same filesystem as %q", from, name)
+		}
+		if _, ok := l.datasets[from]; !ok {
+			return fmt.Errorf("No dataset found with name %q", from)
+		}
+	}
+
+	fs := l.datasets[fsName]
+	stream := sendStream{
+		Name:              name,
+		From:              from,
+		Creation:          snap.Dataset.Properties[libzfs.DatasetPropCreation].Value,
+		Props:             make(map[libzfs.Prop]string),
+		UserProps:         make(map[string]string),
+		SnapshotUserProps: make(map[string]string),
+	}
+	for k, p := range fs.Dataset.Properties {
+		// The name is the only property not following the received dataset
+		if p.Source == "local" && k != libzfs.DatasetPropName {
+			stream.Props[k] = p.Value
+		}
+	}
+	for k, p := range fs.userProperties {
+		if p.Source == "local" {
+			stream.UserProps[k] = p.Value
+		}
+	}
+	for k, p := range snap.userProperties {
+		if p.Source == "local" {
+			stream.SnapshotUserProps[k] = p.Value
+		}
+	}
+
+	data, err := json.Marshal(stream)
+	if err != nil {
+		return err
+	}
+	_, err = outf.Write(append(data, '\n'))
+	return err
+}
+
+// DatasetReceive reads one stream from inf and creates its snapshot, and its filesystem for full streams, under parent.
+func (l *LibZFS) DatasetReceive(parent string, inf *os.File) error {
+	// Read byte by byte to not consume following streams.
+	var data []byte
+	b := make([]byte, 1)
+	for {
+		n, err := inf.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			data = append(data, b[0])
+			continue
+		}
+		if err == io.EOF {
+			return errors.New("no stream to receive")
+		} else if err != nil {
+			return err
+		}
+	}
+	var stream sendStream
+	if err := json.Unmarshal(data, &stream); err != nil {
+		return fmt.Errorf("invalid stream: %v", err)
+	}
+
+	l.mu.RLock()
+	p, ok := l.datasets[parent]
+	l.mu.RUnlock()
+	if !ok || p.IsSnapshot() {
+		return fmt.Errorf("No filesystem found with name %q", parent)
+	}
+
+	fsName := filepath.Join(parent, filepath.Base(strings.Split(stream.Name, "@")[0]))
+	snapName := fsName + "@" + strings.Split(stream.Name, "@")[1]
+	l.mu.RLock()
+	fs, fsExists := l.datasets[fsName]
+	_, snapExists := l.datasets[snapName]
+	var fromExists bool
+	var newest string
+	if stream.From != "" {
+		_, fromExists = l.datasets[fsName+"@"+strings.Split(stream.From, "@")[1]]
+		newest = l.newestSnapshot(fsName)
+	}
+	l.mu.RUnlock()
+	if snapExists {
+		return fmt.Errorf("destination %q already exists", snapName)
+	}
+
+	if stream.From == "" {
+		if fsExists {
+			return fmt.Errorf("destination %q exists and stream isn't incremental", fsName)
+		}
+		props := make(map[libzfs.Prop]libzfs.Property)
+		for k, v := range stream.Props {
+			props[k] = libzfs.Property{Value: v}
+		}
+		d, err := l.DatasetCreate(fsName, libzfs.DatasetTypeFilesystem, props, nil)
+		if err != nil {
+			return err
+		}
+		fs = d.(*dZFS)
+	} else {
+		if !fromExists {
+			return fmt.Errorf("incremental source of %q doesn't exist on destination %q", stream.Name, fsName)
+		}
+		if newest != fsName+"@"+strings.Split(stream.From, "@")[1] {
+			return fmt.Errorf("destination %q has been modified since most recent snapshot %q", fsName, newest)
+		}
+		if w := fs.Dataset.Properties[libzfs.DatasetPropWritten].Value; w != "" && w != "0" {
+			return fmt.Errorf("destination %q has been modified since most recent snapshot", fsName)
+		}
+		for k, v := range stream.Props {
+			if err := fs.setPropertyWithSource(k, v, "local"); err != nil {
+				return err
+			}
+		}
+	}
+	for k, v := range stream.UserProps {
+		if err := fs.setUserPropertyWithSource(k, v, "local"); err != nil {
+			return err
+		}
+	}
+
+	d, err := l.DatasetCreate(snapName, libzfs.DatasetTypeSnapshot, map[libzfs.Prop]libzfs.Property{
+		libzfs.DatasetPropCreation: {Value: stream.Creation}}, nil)
+	if err != nil {
+		return err
+	}
+	snap := d.(*dZFS)
+	for k, v := range stream.SnapshotUserProps {
+		if err := snap.setUserPropertyWithSource(k, v, "local"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newestSnapshot returns the most recently created snapshot of filesystem fs.
+func (l *LibZFS) newestSnapshot(fs string) (newest string) {
+	var newestCreation int64 = -1
+	for n, d := range l.datasets {
+		if !strings.HasPrefix(n, fs+"@") {
+			continue
+		}
+		creation, _ := strconv.ParseInt(d.Dataset.Properties[libzfs.DatasetPropCreation].Value, 10, 64)
+		if creation > newestCreation || (creation == newestCreation && n > newest) {
+			newest, newestCreation = n, creation
+		}
+	}
+	return newest
+}
+
 // SetPoolCapacity allows forcing a capabity value on a pool
 func (l *LibZFS) SetPoolCapacity(name, cap string) {
 	l.mu.Lock()
@@ -329,11 +618,23 @@ func (l *LibZFS) SetPoolCapacity(name, cap string) {
 	l.pools[name].Properties[libzfs.PoolPropCapacity] = libzfs.Property{Value: cap}
 }
 
+// SetPoolSize allows forcing a size value, in bytes, on a pool
+func (l *LibZFS) SetPoolSize(name, size string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pools[name].Properties[libzfs.PoolPropSize] = libzfs.Property{Value: size}
+}
+
 // ErrOnPromote forces a failure of the mock on clone operation
 func (l *LibZFS) ErrOnPromote(shouldErr bool) {
 	l.errOnPromote = shouldErr
 }
 
+// ErrOnRenameDS forces a failure of the mock on rename operation for the datasets passed in dsErr
+func (l *LibZFS) ErrOnRenameDS(dsErr []string) {
+	l.errOnRenameDS = dsErr
+}
+
 // ErrOnClone forces a failure of the mock on clone operation
 func (l *LibZFS) ErrOnClone(shouldErr bool) {
 	l.errOnClone = shouldErr
@@ -415,8 +716,17 @@ func (d dZFS) Clone(target string, props map[libzfs.Prop]libzfs.Property) (libzf
 		Value:  d.Dataset.Properties[libzfs.DatasetPropName].Value,
 		Source: "-",
 	}
+	// Clones stay in the encryption root of their origin, which key is needed to create them
+	if root := d.Dataset.Properties[libzfs.DatasetPropEncryptionRoot]; root.Value != "" {
+		if d.Dataset.Properties[libzfs.DatasetPropKeyStatus].Value != libzfs.KeyStatusAvailable {
+			return nil, fmt.Errorf("key for encryption root %q isn't loaded", root.Value)
+		}
+		props[libzfs.DatasetPropEncryption] = libzfs.Property{Value: d.Dataset.Properties[libzfs.DatasetPropEncryption].Value, Source: "inherited"}
+		props[libzfs.DatasetPropEncryptionRoot] = root
+		props[libzfs.DatasetPropKeyStatus] = d.Dataset.Properties[libzfs.DatasetPropKeyStatus]
+	}
 
-	dinterface, err := d.libZFSMock.DatasetCreate(target, libzfs.DatasetTypeFilesystem, props)
+	dinterface, err := d.libZFSMock.DatasetCreate(target, libzfs.DatasetTypeFilesystem, props, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -446,6 +756,54 @@ func (d dZFS) GetUserProperty(p string) (prop libzfs.Property, err error) {
 	return prop, nil
 }
 
+func (d *dZFS) Hold(tag string) error {
+	d.assertDatasetOpened()
+	n := d.Dataset.Properties[libzfs.DatasetPropName].Value
+	if !d.IsSnapshot() {
+		return fmt.Errorf("can't hold %s: only snapshots can be held", n)
+	}
+
+	d.libZFSMock.mu.Lock()
+	defer d.libZFSMock.mu.Unlock()
+	if _, exists := d.libZFSMock.holds[n][tag]; exists {
+		return fmt.Errorf("can't hold %s: tag %s already exists", n, tag)
+	}
+	if d.libZFSMock.holds[n] == nil {
+		d.libZFSMock.holds[n] = make(map[string]time.Time)
+	}
+	d.libZFSMock.holds[n][tag] = time.Now()
+	return nil
+}
+
+func (d *dZFS) Release(tag string) error {
+	d.assertDatasetOpened()
+	n := d.Dataset.Properties[libzfs.DatasetPropName].Value
+
+	d.libZFSMock.mu.Lock()
+	defer d.libZFSMock.mu.Unlock()
+	if _, exists := d.libZFSMock.holds[n][tag]; !exists {
+		return fmt.Errorf("can't release %s: no such tag %s", n, tag)
+	}
+	delete(d.libZFSMock.holds[n], tag)
+	if len(d.libZFSMock.holds[n]) == 0 {
+		delete(d.libZFSMock.holds, n)
+	}
+	return nil
+}
+
+func (d dZFS) Holds() (tags []libzfs.HoldTag, err error) {
+	d.assertDatasetOpened()
+	n := d.Dataset.Properties[libzfs.DatasetPropName].Value
+
+	d.libZFSMock.mu.RLock()
+	defer d.libZFSMock.mu.RUnlock()
+	for tag, t := range d.libZFSMock.holds[n] {
+		tags = append(tags, libzfs.HoldTag{Name: tag, Timestamp: t})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
 func (d *dZFS) SetUserProperty(prop, value string) error {
 	if d.libZFSMock.errOnSetProperty {
 		return errors.New("Error on SetProperty requested")
@@ -478,12 +836,65 @@ func (d *dZFS) SetProperty(p libzfs.Prop, value string) error {
 		return errors.New("Error on SetProperty requested")
 	}
 	d.assertDatasetOpened()
+	if p == libzfs.DatasetPropReservation {
+		if err := d.libZFSMock.checkReservation(d.Dataset.Properties[libzfs.DatasetPropName].Value, value); err != nil {
+			return err
+		}
+	}
 	return d.setPropertyWithSource(p, value, "local")
 }
 
+// checkReservation returns an error if the pool of dataset name, when its size was set, hasn't enough free space
+// for all reservations once the reservation of name is set to value.
+func (l *LibZFS) checkReservation(name, value string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	pool := strings.SplitN(name, "/", 2)[0]
+	p, ok := l.pools[pool]
+	if !ok {
+		return nil
+	}
+	size, err := strconv.ParseUint(p.Properties[libzfs.PoolPropSize].Value, 10, 64)
+	if err != nil || size == 0 {
+		return nil
+	}
+	capacity, err := strconv.ParseUint(p.Properties[libzfs.PoolPropCapacity].Value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	free := size - capacity*size/100
+
+	for n, d := range l.datasets {
+		// Snapshots don't hold any reservation.
+		if n == name || !strings.HasPrefix(n, pool+"/") || strings.Contains(n, "@") {
+			continue
+		}
+		prop := d.Dataset.Properties[libzfs.DatasetPropReservation]
+		if prop.Source != "local" {
+			continue
+		}
+		other, err := strconv.ParseUint(prop.Value, 10, 64)
+		if err != nil {
+			continue
+		}
+		r += other
+	}
+	if r > free {
+		return fmt.Errorf("cannot set reservation on %s: size is greater than available space", name)
+	}
+	return nil
+}
+
 func (d *dZFS) setPropertyWithSource(p libzfs.Prop, value, source string) error {
 	// Those properties don't propagate to children
-	if p == libzfs.DatasetPropMounted || p == libzfs.DatasetPropOrigin {
+	switch p {
+	case libzfs.DatasetPropMounted, libzfs.DatasetPropOrigin,
+		libzfs.DatasetPropUsed, libzfs.DatasetPropReferenced, libzfs.DatasetPropUsedbysnapshots, libzfs.DatasetPropWritten:
 		source = "-"
 	}
 
@@ -492,6 +903,10 @@ func (d *dZFS) setPropertyWithSource(p libzfs.Prop, value, source string) error
 	if source == "-" {
 		return nil
 	}
+	// Quotas are set on the dataset only
+	if p == libzfs.DatasetPropRefquota || p == libzfs.DatasetPropReservation {
+		return nil
+	}
 
 	// refresh children
 	for i := range d.children {
@@ -532,6 +947,9 @@ func (d *dZFS) Destroy(Defer bool) (err error) {
 
 	d.libZFSMock.mu.Lock()
 	defer d.libZFSMock.mu.Unlock()
+	if len(d.libZFSMock.holds[n]) > 0 {
+		return fmt.Errorf("can't remove %s: dataset is busy", n)
+	}
 	for name, dataset := range d.libZFSMock.datasets {
 		if n == name {
 			continue
@@ -544,9 +962,37 @@ func (d *dZFS) Destroy(Defer bool) (err error) {
 		}
 	}
 	delete(d.libZFSMock.datasets, n)
+	d.libZFSMock.releasePoolSpace(strings.SplitN(n, "/", 2)[0], d.Dataset.Properties[libzfs.DatasetPropUsed].Value)
 	return nil
 }
 
+// releasePoolSpace lowers the capacity of pool, if its size was set, by the space used by a destroyed dataset.
+func (l *LibZFS) releasePoolSpace(pool, used string) {
+	p, ok := l.pools[strings.SplitN(pool, "@", 2)[0]]
+	if !ok {
+		return
+	}
+	size, err := strconv.ParseUint(p.Properties[libzfs.PoolPropSize].Value, 10, 64)
+	if err != nil || size == 0 {
+		return
+	}
+	u, err := strconv.ParseUint(used, 10, 64)
+	if err != nil {
+		return
+	}
+	capacity, err := strconv.ParseUint(p.Properties[libzfs.PoolPropCapacity].Value, 10, 64)
+	if err != nil {
+		return
+	}
+	allocated := capacity * size / 100
+	if u > allocated {
+		u = allocated
+	}
+	// Round up, as zfs does.
+	capacity = ((allocated-u)*100 + size - 1) / size
+	p.Properties[libzfs.PoolPropCapacity] = libzfs.Property{Value: strconv.FormatUint(capacity, 10)}
+}
+
 func (d *dZFS) Clones() (clones []string, err error) {
 	d.assertDatasetOpened()
 	d.libZFSMock.mu.Lock()
@@ -617,7 +1063,7 @@ func (d *dZFS) Promote() (err error) {
 		for k, v := range snap.Dataset.Properties {
 			newDProps[k] = v
 		}
-		newD, err := d.libZFSMock.DatasetCreate(newName, libzfs.DatasetTypeSnapshot, newDProps)
+		newD, err := d.libZFSMock.DatasetCreate(newName, libzfs.DatasetTypeSnapshot, newDProps, nil)
 		if err != nil {
 			return err
 		}
@@ -660,6 +1106,53 @@ func (d *dZFS) Promote() (err error) {
 	return nil
 }
 
+// Rename renames the dataset, with its children and snapshots, and points any of its clones to the new name.
+func (d *dZFS) Rename(newName string, recur, forceUnmount bool) (err error) {
+	d.assertDatasetOpened()
+	oldName := d.Dataset.Properties[libzfs.DatasetPropName].Value
+
+	if d.libZFSMock.errOnRenameDS != nil {
+		if len(d.libZFSMock.errOnRenameDS) == 0 {
+			return errors.New("Error on Rename requested on all datasets")
+		}
+		for _, errd := range d.libZFSMock.errOnRenameDS {
+			if errd == oldName {
+				return fmt.Errorf("Error on Rename requested on %s", oldName)
+			}
+		}
+	}
+
+	d.libZFSMock.mu.Lock()
+	defer d.libZFSMock.mu.Unlock()
+
+	if _, ok := d.libZFSMock.datasets[newName]; ok {
+		return fmt.Errorf("dataset %q already exists", newName)
+	}
+
+	renamed := make(map[string]string)
+	for name := range d.libZFSMock.datasets {
+		if name == oldName || strings.HasPrefix(name, oldName+"/") || strings.HasPrefix(name, oldName+"@") {
+			renamed[name] = newName + strings.TrimPrefix(name, oldName)
+		}
+	}
+	for from, to := range renamed {
+		ds := d.libZFSMock.datasets[from]
+		delete(d.libZFSMock.datasets, from)
+		ds.Dataset.Properties[libzfs.DatasetPropName] = libzfs.Property{Value: to, Source: "-"}
+		d.libZFSMock.datasets[to] = ds
+		if h, ok := d.libZFSMock.holds[from]; ok {
+			delete(d.libZFSMock.holds, from)
+			d.libZFSMock.holds[to] = h
+		}
+	}
+	for _, ds := range d.libZFSMock.datasets {
+		if to, ok := renamed[ds.Dataset.Properties[libzfs.DatasetPropOrigin].Value]; ok {
+			ds.Dataset.Properties[libzfs.DatasetPropOrigin] = libzfs.Property{Value: to, Source: "-"}
+		}
+	}
+	return nil
+}
+
 // ReloadProperties: set orig to new thing
 // This is to mock libZFS only reloading the orig property at this time
 func (d *dZFS) ReloadProperties() (err error) {
@@ -677,5 +1170,8 @@ func New() LibZFS {
 	return LibZFS{
 		datasets: make(map[string]*dZFS),
 		pools:    make(map[string]libzfs.Pool),
+		diffs:    make(map[string][]libzfs.DiffEntry),
+		keys:     make(map[string][]byte),
+		holds:    make(map[string]map[string]time.Time),
 	}
 }
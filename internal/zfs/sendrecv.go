@@ -0,0 +1,59 @@
+package zfs
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ubuntu/zsys/internal/config"
+	"github.com/ubuntu/zsys/internal/i18n"
+	"github.com/ubuntu/zsys/internal/log"
+)
+
+// Send writes a stream of snapshot name, with its properties, to w.
+// The stream is incremental from the earlier snapshot from of the same filesystem if from isn't empty.
+func (z Zfs) Send(name, from string, w *os.File) error {
+	d, err := z.findDatasetByName(name)
+	if err != nil {
+		return fmt.Errorf(i18n.G("cannot find %q: %v"), name, err)
+	}
+	if !d.IsSnapshot {
+		return fmt.Errorf(i18n.G("%q isn't a snapshot"), name)
+	}
+	if from != "" {
+		if _, err := z.findDatasetByName(from); err != nil {
+			return fmt.Errorf(i18n.G("cannot find %q: %v"), from, err)
+		}
+	}
+
+	if err := z.libzfs.DatasetSend(name, from, w); err != nil {
+		return fmt.Errorf(i18n.G("couldn't send %q: %v"), name, err)
+	}
+	return nil
+}
+
+// Receive reads a stream from r and creates its snapshot under the filesystem parent. The received filesystem keeps
+// the last element of its sent name: it is created for full streams, and must hold the incremental source otherwise.
+// Received datasets aren't mounted.
+// Note that received data can't be recreated, so we don't accept them in a transactional Zfs element.
+func (nt *NoTransaction) Receive(parent string, r *os.File) error {
+	log.Debugf(nt.ctx, i18n.G("ZFS: trying to receive a stream under %q"), parent)
+
+	d, err := nt.Zfs.findDatasetByName(parent)
+	if err != nil {
+		return fmt.Errorf(i18n.G("cannot find %q: %v"), parent, err)
+	}
+	if d.IsSnapshot {
+		return fmt.Errorf(i18n.G("can't receive under %q: it's a snapshot"), parent)
+	}
+
+	errReceive := nt.Zfs.libzfs.DatasetReceive(parent, r)
+
+	// The stream can be partially received: rescan in all cases.
+	if err := nt.Zfs.Refresh(nt.ctx); err != nil {
+		return fmt.Errorf(i18n.G("couldn't refresh datasets after receiving under %q: ")+config.ErrorFormat, parent, err)
+	}
+	if errReceive != nil {
+		return fmt.Errorf(i18n.G("couldn't receive under %q: %v"), parent, errReceive)
+	}
+	return nil
+}
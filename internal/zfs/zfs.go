@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/ubuntu/zsys/internal/config"
@@ -15,6 +16,8 @@ import (
 const (
 	// UserdataPrefix is the part of the path of the dataset that contains user data
 	UserdataPrefix = "USERDATA"
+
+	labelsSeparator = ","
 )
 
 // Dataset is the abstraction of a physical dataset and exposes only properties that must are accessible by the user.
@@ -44,8 +47,36 @@ type DatasetProp struct {
 	LastBootedKernel string `json:",omitempty"`
 	// BootfsDatasets is a user property for user datasets, linking them to relevant system bootfs datasets.
 	BootfsDatasets string `json:",omitempty"`
+	// PendingRevert is a user property on root datasets storing the state to revert to on next boot.
+	PendingRevert string `json:",omitempty"`
+	// Pinned is a user property protecting the state owning the dataset from garbage collection and removal.
+	Pinned bool `json:",omitempty"`
+	// Description is a user property describing the state owning the dataset.
+	Description string `json:",omitempty"`
+	// Labels are user properties attaching key/value metadata to the state owning the dataset.
+	Labels map[string]string `json:",omitempty"`
+	// Backup is a user property marking filesystems holding exported states, which aren't managed by zsys.
+	Backup bool `json:",omitempty"`
 	// Origin points to the dataset snapshot this one was clone from.
 	Origin string `json:",omitempty"`
+	// Used is the space, in bytes, consumed by the dataset and all its descendents.
+	Used uint64 `json:",omitempty"`
+	// Referenced is the amount of data, in bytes, accessible by the dataset.
+	Referenced uint64 `json:",omitempty"`
+	// UsedBySnapshots is the space, in bytes, consumed by snapshots of the dataset.
+	UsedBySnapshots uint64 `json:",omitempty"`
+	// Written is the space, in bytes, written to the dataset since the previous snapshot.
+	Written uint64 `json:",omitempty"`
+	// Quota is the maximum space, in bytes, the dataset can reference. 0 means no quota.
+	Quota uint64 `json:",omitempty"`
+	// Reservation is the space, in bytes, guaranteed to the dataset and its descendents.
+	Reservation uint64 `json:",omitempty"`
+	// EncryptionRoot is the dataset holding the key of an encrypted dataset. It's empty if not encrypted.
+	EncryptionRoot string `json:",omitempty"`
+	// KeyLoaded reports if the key of an encrypted dataset is loaded, making its content available.
+	KeyLoaded bool `json:",omitempty"`
+	// Holds are the tags of user holds on a snapshot, preventing its destruction.
+	Holds []string `json:",omitempty"`
 
 	// Here are the sources (not exposed to the public API) for each property
 	// Used mostly for tests
@@ -60,6 +91,13 @@ type datasetSources struct {
 	LastUsed         string `json:",omitempty"`
 	LastBootedKernel string `json:",omitempty"`
 	BootfsDatasets   string `json:",omitempty"`
+	PendingRevert    string `json:",omitempty"`
+	Pinned           string `json:",omitempty"`
+	Description      string `json:",omitempty"`
+	Labels           string `json:",omitempty"`
+	Backup           string `json:",omitempty"`
+	Quota            string `json:",omitempty"`
+	Reservation      string `json:",omitempty"`
 }
 
 // Zfs is a system handler talking to zfs linux module.
@@ -320,14 +358,35 @@ func (t *Transaction) Create(path, mountpoint, canmount string) error {
 	t.checkValid()
 
 	log.Debugf(t.ctx, i18n.G("ZFS: trying to Create %q with mountpoint %q"), path, mountpoint)
+	return t.create(path, mountpoint, canmount, nil)
+}
 
+// CreateEncrypted creates a dataset for that path, as a new encryption root unlocked by passphrase.
+// The key is loaded once created.
+func (t *Transaction) CreateEncrypted(path, mountpoint, canmount string, passphrase []byte) error {
+	t.checkValid()
+
+	log.Debugf(t.ctx, i18n.G("ZFS: trying to Create encrypted %q with mountpoint %q"), path, mountpoint)
+	if len(passphrase) == 0 {
+		return fmt.Errorf(i18n.G("can't create encrypted %q: a passphrase is required"), path)
+	}
+	return t.create(path, mountpoint, canmount, passphrase)
+}
+
+// create creates a dataset for that path, as a new encryption root if key isn't nil.
+func (t *Transaction) create(path, mountpoint, canmount string, key []byte) error {
 	props := make(map[libzfs.Prop]libzfs.Property)
 	if mountpoint != "" {
 		props[libzfs.DatasetPropMountpoint] = libzfs.Property{Value: mountpoint}
 	}
 	props[libzfs.DatasetPropCanmount] = libzfs.Property{Value: canmount}
+	if key != nil {
+		props[libzfs.DatasetPropEncryption] = libzfs.Property{Value: libzfs.EncryptionOn}
+		props[libzfs.DatasetPropKeyFormat] = libzfs.Property{Value: libzfs.KeyFormatPassphrase}
+		props[libzfs.DatasetPropKeyLocation] = libzfs.Property{Value: libzfs.KeyLocationPrompt}
+	}
 
-	dZFS, err := t.Zfs.libzfs.DatasetCreate(path, libzfs.DatasetTypeFilesystem, props)
+	dZFS, err := t.Zfs.libzfs.DatasetCreate(path, libzfs.DatasetTypeFilesystem, props, key)
 	if err != nil {
 		return fmt.Errorf(i18n.G("can't create %q: %v"), path, err)
 	}
@@ -461,6 +520,9 @@ func (t *Transaction) Clone(name, suffix string, ignoreErrorOnExists, recursive
 	if !d.IsSnapshot {
 		return fmt.Errorf(i18n.G("%q isn't a snapshot"), name)
 	}
+	if d.EncryptionRoot != "" && !d.KeyLoaded {
+		return fmt.Errorf(i18n.G("can't clone %q: key of encryption root %q isn't loaded"), name, d.EncryptionRoot)
+	}
 
 	nestedT := t.newNestedTransaction()
 	defer nestedT.Done(&errClone)
@@ -707,6 +769,84 @@ func (t *nestedTransaction) promoteRecursive(d *Dataset) error {
 	return nil
 }
 
+// LoadKey loads the key of the encryption root name from passphrase, making all datasets sharing this
+// encryption root available.
+// Loading a key can't be reverted while keeping data available, so we don't accept it in a transactional Zfs element.
+func (nt *NoTransaction) LoadKey(name string, passphrase []byte) error {
+	log.Debugf(nt.ctx, i18n.G("ZFS: trying to load key of %q"), name)
+	d, err := nt.Zfs.findDatasetByName(name)
+	if err != nil {
+		return fmt.Errorf(i18n.G("can't get dataset to load key of %q: ")+config.ErrorFormat, name, err)
+	}
+	if d.EncryptionRoot != d.Name {
+		return fmt.Errorf(i18n.G("%q isn't an encryption root"), name)
+	}
+	if d.KeyLoaded {
+		log.Debugf(nt.ctx, i18n.G("Key of %q is already loaded"), name)
+		return nil
+	}
+
+	if err := nt.Zfs.libzfs.DatasetLoadKey(name, passphrase); err != nil {
+		return fmt.Errorf(i18n.G("couldn't load key of %q: ")+config.ErrorFormat, name, err)
+	}
+
+	for _, ds := range nt.Zfs.allDatasets {
+		if ds.EncryptionRoot != name {
+			continue
+		}
+		if err := ds.dZFS.ReloadProperties(); err != nil {
+			return fmt.Errorf(i18n.G("couldn't reload properties of %q: ")+config.ErrorFormat, ds.Name, err)
+		}
+		if err := ds.refreshProperties(nt.ctx); err != nil {
+			return fmt.Errorf(i18n.G("couldn't refresh properties of %q: ")+config.ErrorFormat, ds.Name, err)
+		}
+	}
+	return nil
+}
+
+// Hold places a user hold named tag on the snapshot name, preventing its destruction until the tag is released.
+// Holds are only a protection and have no content to revert, so we don't accept them in a transactional Zfs element.
+func (nt *NoTransaction) Hold(name, tag string) error {
+	log.Debugf(nt.ctx, i18n.G("ZFS: trying to hold %q with %q"), name, tag)
+	d, err := nt.Zfs.findDatasetByName(name)
+	if err != nil {
+		return fmt.Errorf(i18n.G("can't get dataset to hold %q: ")+config.ErrorFormat, name, err)
+	}
+	if !d.IsSnapshot {
+		return fmt.Errorf(i18n.G("%q isn't a snapshot: only snapshots can be held"), name)
+	}
+
+	if err := d.dZFS.Hold(tag); err != nil {
+		return fmt.Errorf(i18n.G("couldn't hold %q with %q: ")+config.ErrorFormat, name, tag, err)
+	}
+	d.Holds = append(d.Holds, tag)
+	sort.Strings(d.Holds)
+	return nil
+}
+
+// Release removes the user hold named tag from the snapshot name.
+func (nt *NoTransaction) Release(name, tag string) error {
+	log.Debugf(nt.ctx, i18n.G("ZFS: trying to release %q from %q"), tag, name)
+	d, err := nt.Zfs.findDatasetByName(name)
+	if err != nil {
+		return fmt.Errorf(i18n.G("can't get dataset to release %q: ")+config.ErrorFormat, name, err)
+	}
+
+	if err := d.dZFS.Release(tag); err != nil {
+		return fmt.Errorf(i18n.G("couldn't release %q from %q: ")+config.ErrorFormat, tag, name, err)
+	}
+	for i, h := range d.Holds {
+		if h == tag {
+			d.Holds = append(d.Holds[:i], d.Holds[i+1:]...)
+			break
+		}
+	}
+	if len(d.Holds) == 0 {
+		d.Holds = nil
+	}
+	return nil
+}
+
 // Destroy recursively all children, including dataset named "name".
 // If the dataset is a filesystem dataset, only remove it and children if there is no snapshots in the descendants.
 // If the dataset is a snapshot, navigate through the hierarchy to delete all dataset with the same snapshot name.
@@ -833,6 +973,52 @@ func (t *Transaction) SetProperty(name, value, datasetName string, force bool) e
 	return nil
 }
 
+// RenameSnapshot renames the snapshot name to newSnapName, keeping it on the same dataset.
+// Clones of the snapshot follow the new name.
+func (t *Transaction) RenameSnapshot(name, newSnapName string) error {
+	t.checkValid()
+
+	log.Debugf(t.ctx, i18n.G("ZFS: trying to rename %q to %q"), name, newSnapName)
+	d, err := t.Zfs.findDatasetByName(name)
+	if err != nil {
+		return fmt.Errorf(i18n.G("can't get snapshot to rename %q: ")+config.ErrorFormat, name, err)
+	}
+	if !d.IsSnapshot {
+		return fmt.Errorf(i18n.G("%q isn't a snapshot"), name)
+	}
+
+	base, _ := splitSnapshotName(name)
+	newName := base + "@" + newSnapName
+	if t.Zfs.datasetExists(newName) {
+		return fmt.Errorf(i18n.G("can't rename %q: %q already exists"), name, newName)
+	}
+
+	if err := t.Zfs.renameSnapshot(d, newName); err != nil {
+		return err
+	}
+	t.registerRevert(func() error { return t.Zfs.renameSnapshot(d, name) })
+
+	return nil
+}
+
+// renameSnapshot renames d to newName and updates our cache accordingly.
+func (z *Zfs) renameSnapshot(d *Dataset, newName string) error {
+	oldName := d.Name
+	if err := d.dZFS.Rename(newName, false, false); err != nil {
+		return fmt.Errorf(i18n.G("couldn't rename %q to %q: ")+config.ErrorFormat, oldName, newName, err)
+	}
+
+	delete(z.allDatasets, oldName)
+	d.Name = newName
+	z.allDatasets[newName] = d
+	for _, c := range z.allDatasets {
+		if c.Origin == oldName {
+			c.Origin = newName
+		}
+	}
+	return nil
+}
+
 // Dependencies returns the list of dataset dependencies in reverse order (deepest first)
 // A dataset has dependencies if:
 //   - it has a subdataset (has child)
@@ -55,6 +55,7 @@ func TestNew(t *testing.T) {
 		"One pool, one dataset, one snapshot":                                      {def: "one_pool_one_dataset_one_snapshot.yaml"},
 		"One pool, one dataset, canmount=noauto":                                   {def: "one_pool_one_dataset_canmount_noauto.yaml"},
 		"One pool, N datasets, one snapshot":                                       {def: "one_pool_n_datasets_one_snapshot.yaml"},
+		"One pool, N datasets, one held snapshot":                                  {def: "one_pool_n_datasets_one_snapshot_held.yaml"},
 		"One pool non-root mpoint, N datasets no mountpoint":                       {def: "one_pool_with_nonroot_mountpoint_n_datasets_no_mountpoint.yaml"},
 		"Two pools, N datasets":                                                    {def: "two_pools_n_datasets.yaml"},
 		"Two pools, N datasets, N snapshots":                                       {def: "two_pools_n_datasets_n_snapshots.yaml"},
@@ -163,6 +164,8 @@ func TestCreate(t *testing.T) {
 		path       string
 		mountpoint string
 		canmount   string
+		passphrase string
+		encrypted  bool
 
 		wantErr bool
 	}{
@@ -170,11 +173,13 @@ func TestCreate(t *testing.T) {
 		"Without mountpoint":                 {def: "one_pool_one_dataset.yaml", path: "rpool/dataset", canmount: "on"},
 		"With canmount false":                {def: "one_pool_one_dataset.yaml", path: "rpool/dataset", mountpoint: "/home/foo", canmount: "off"},
 		"With mountpoint and canmount false": {def: "one_pool_one_dataset.yaml", path: "rpool/dataset", canmount: "off"},
+		"Encrypted creation":                 {def: "one_pool_one_dataset.yaml", path: "rpool/dataset", mountpoint: "/home/foo", canmount: "on", encrypted: true, passphrase: "secret"},
 
 		"Failing on dataset already exists":        {def: "one_pool_n_datasets.yaml", path: "rpool/ROOT/ubuntu", wantErr: true},
 		"Failing on pool directly":                 {def: "one_pool_one_dataset.yaml", path: "rpool", wantErr: true},
 		"Failing on unexisting pool":               {def: "one_pool_one_dataset.yaml", path: "rpool2", wantErr: true},
 		"Failing on missing intermediate datasets": {def: "one_pool_one_dataset.yaml", path: "rpool/intermediate/doesnt/exist", wantErr: true},
+		"Failing on encrypted without passphrase":  {def: "one_pool_one_dataset.yaml", path: "rpool/dataset", canmount: "on", encrypted: true, wantErr: true},
 	}
 
 	for name, tc := range tests {
@@ -194,7 +199,11 @@ func TestCreate(t *testing.T) {
 			trans, _ := z.NewTransaction(context.Background())
 			defer trans.Done()
 
-			err = trans.Create(tc.path, tc.mountpoint, tc.canmount)
+			if tc.encrypted {
+				err = trans.CreateEncrypted(tc.path, tc.mountpoint, tc.canmount, []byte(tc.passphrase))
+			} else {
+				err = trans.Create(tc.path, tc.mountpoint, tc.canmount)
+			}
 
 			if err != nil && !tc.wantErr {
 				t.Fatalf("expected no error but got: %v", err)
@@ -559,6 +568,7 @@ func TestDestroy(t *testing.T) {
 		"Hierarchy with unpromoted clones":            {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_1234", cloneFrom: "rpool/ROOT/ubuntu_1234@snap_r1", wantErr: true, isNoOp: true},
 		"Hierarchy with unpromoted clones non root":   {def: "layout1__one_pool_n_datasets_n_snapshots_with_started_clone.yaml", dataset: "rpool/ROOT/ubuntu_1234", cloneFrom: "rpool/ROOT/ubuntu_1234/var@snap_r1", wantErr: true, isNoOp: true},
 		"Hierarchy with snapshots can’t be destroyed": {def: "layout1__one_pool_n_datasets_n_snapshots.yaml", dataset: "rpool/ROOT/ubuntu_1234", wantErr: true, isNoOp: true},
+		"Held snapshot can’t be destroyed":            {def: "one_pool_n_datasets_one_snapshot_held.yaml", dataset: "rpool/ROOT/ubuntu@snap1", wantErr: true, isNoOp: true},
 	}
 
 	for name, tc := range tests {
@@ -656,11 +666,20 @@ func TestSetProperty(t *testing.T) {
 		"Let set on BootfsDatasetsProp but don't load it (local)": {def: "one_pool_one_dataset_one_snapshot_with_bootfsdatasets.yaml", propertyName: libzfs.BootfsDatasetsProp, propertyValue: "SetProperty Value", dataset: "rpool@snap1"},
 		"Let set on BootfsDatasetsProp but don't load it (none)":  {def: "one_pool_one_dataset_one_snapshot_without_user_properties.yaml", propertyName: libzfs.BootfsDatasetsProp, propertyValue: "SetProperty Value", dataset: "rpool@snap1"},
 
+		"Description property":                 {def: "one_pool_one_dataset_with_bootfsdatasets.yaml", propertyName: libzfs.DescriptionProp, propertyValue: "SetProperty Value", dataset: "rpool"},
+		"Description property on snapshot":     {def: "one_pool_one_dataset_one_snapshot_without_user_properties.yaml", propertyName: libzfs.DescriptionProp, propertyValue: "SetProperty Value", dataset: "rpool@snap1"},
+		"Label property (inherit on children)": {def: "layout1__one_pool_n_datasets_one_main_snapshots_inherited.yaml", propertyName: libzfs.LabelPropPrefix + "key", propertyValue: "SetProperty Value", dataset: "rpool/ROOT/ubuntu_1234"},
+		"Label property on snapshot":           {def: "one_pool_one_dataset_one_snapshot_without_user_properties.yaml", propertyName: libzfs.LabelPropPrefix + "key", propertyValue: "SetProperty Value", dataset: "rpool@snap1"},
+
 		"LastUsed with children":            {def: "one_pool_one_dataset_one_snapshot_with_user_properties.yaml", propertyName: libzfs.LastUsedProp, propertyValue: "42", dataset: "rpool"},
 		"LastUsed is not a number":          {def: "one_pool_one_dataset_one_snapshot_with_user_properties.yaml", propertyName: libzfs.LastUsedProp, propertyValue: "not a number", dataset: "rpool", wantErr: true, isNoOp: true},
 		"LastUsed is inherited by children": {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.LastUsedProp, propertyValue: "42", dataset: "rpool/ROOT/ubuntu"},
 		"LastUsed set empty":                {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.LastUsedProp, propertyValue: "", dataset: "rpool/ROOT/ubuntu"},
 
+		"Quota property isn't inherited by children": {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.RefquotaProp, propertyValue: "1073741824", dataset: "rpool/ROOT/ubuntu"},
+		"Reservation property":                       {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.ReservationProp, propertyValue: "536870912", dataset: "rpool/ROOT/ubuntu"},
+		"Quota property set to none":                 {def: "one_pool_n_datasets_n_children.yaml", propertyName: libzfs.RefquotaProp, propertyValue: "none", dataset: "rpool/ROOT/ubuntu"},
+
 		"Unauthorized property":  {def: "one_pool_one_dataset.yaml", propertyName: "snapdir", propertyValue: "/setproperty/value", dataset: "rpool", wantPanic: true},
 		"Dataset doesn't exists": {def: "one_pool_one_dataset.yaml", propertyName: libzfs.BootfsDatasetsProp, propertyValue: "SetProperty Value", dataset: "rpool10", wantErr: true, isNoOp: true},
 	}
@@ -710,6 +729,127 @@ func TestSetProperty(t *testing.T) {
 	}
 }
 
+func TestRenameSnapshot(t *testing.T) {
+	failOnZFSPermissionDenied(t)
+
+	tests := map[string]struct {
+		def         string
+		snapshot    string
+		newSnapName string
+		cancel      bool
+
+		wantErr bool
+		isNoOp  bool
+	}{
+		"Rename snapshot":                {def: "one_pool_one_dataset_one_snapshot.yaml", snapshot: "rpool@snap1", newSnapName: "renamed"},
+		"Rename snapshot on subdataset":  {def: "one_pool_n_datasets_one_snapshot.yaml", snapshot: "rpool/ROOT/ubuntu@snap1", newSnapName: "renamed"},
+		"Rename is reverted on cancel":   {def: "one_pool_one_dataset_one_snapshot.yaml", snapshot: "rpool@snap1", newSnapName: "renamed", cancel: true, isNoOp: true},
+		"Error on filesystem dataset":    {def: "one_pool_one_dataset_one_snapshot.yaml", snapshot: "rpool", newSnapName: "renamed", wantErr: true, isNoOp: true},
+		"Error on missing snapshot":      {def: "one_pool_one_dataset_one_snapshot.yaml", snapshot: "rpool@snap2", newSnapName: "renamed", wantErr: true, isNoOp: true},
+		"Error on existing new snapshot": {def: "layout1_with_bootfs_already_cloned.yaml", snapshot: "rpool/ROOT/ubuntu_1234@snap_r1", newSnapName: "snap_r2", wantErr: true, isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			ta := timeAsserter(time.Now())
+			adapter := testutils.GetLibZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(adapter))
+			defer fPools.Create(dir)()
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			initState := copyState(z)
+			trans, cancel := z.NewTransaction(context.Background())
+
+			err = trans.RenameSnapshot(tc.snapshot, tc.newSnapName)
+			if tc.cancel {
+				cancel()
+			}
+			trans.Done()
+
+			if err != nil && !tc.wantErr {
+				t.Fatalf("expected no error but got: %v", err)
+			} else if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			if tc.isNoOp {
+				assertDatasetsEquals(t, ta, initState, z.Datasets())
+			} else {
+				assertDatasetsToGolden(t, ta, z.Datasets())
+			}
+
+			zfs.AssertNoZFSChildren(t, z)
+			assertIdempotentWithNew(t, ta, z.Datasets(), adapter)
+		})
+	}
+}
+
+func TestHold(t *testing.T) {
+	failOnZFSPermissionDenied(t)
+
+	tests := map[string]struct {
+		def     string
+		dataset string
+		tag     string
+		release bool
+
+		wantErr bool
+		isNoOp  bool
+	}{
+		"Hold snapshot":                {def: "one_pool_n_datasets_one_snapshot.yaml", dataset: "rpool/ROOT/ubuntu@snap1", tag: "zsys-test"},
+		"Hold already held snapshot":   {def: "one_pool_n_datasets_one_snapshot_held.yaml", dataset: "rpool/ROOT/ubuntu@snap1", tag: "zsys-test"},
+		"Release hold":                 {def: "one_pool_n_datasets_one_snapshot_held.yaml", dataset: "rpool/ROOT/ubuntu@snap1", tag: "backup-tool", release: true},
+		"Error on filesystem dataset":  {def: "one_pool_n_datasets_one_snapshot.yaml", dataset: "rpool/ROOT/ubuntu", tag: "zsys-test", wantErr: true, isNoOp: true},
+		"Error on missing snapshot":    {def: "one_pool_n_datasets_one_snapshot.yaml", dataset: "rpool/ROOT/ubuntu@snap2", tag: "zsys-test", wantErr: true, isNoOp: true},
+		"Error on existing tag":        {def: "one_pool_n_datasets_one_snapshot_held.yaml", dataset: "rpool/ROOT/ubuntu@snap1", tag: "backup-tool", wantErr: true, isNoOp: true},
+		"Error on releasing unset tag": {def: "one_pool_n_datasets_one_snapshot_held.yaml", dataset: "rpool/ROOT/ubuntu@snap1", tag: "zsys-test", release: true, wantErr: true, isNoOp: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir, cleanup := testutils.TempDir(t)
+			defer cleanup()
+
+			ta := timeAsserter(time.Now())
+			adapter := testutils.GetLibZFS(t)
+			fPools := testutils.NewFakePools(t, filepath.Join("testdata", tc.def), testutils.WithLibZFS(adapter))
+			defer fPools.Create(dir)()
+			z, err := zfs.New(context.Background(), zfs.WithLibZFS(adapter))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			initState := copyState(z)
+			nt := z.NewNoTransaction(context.Background())
+
+			if tc.release {
+				err = nt.Release(tc.dataset, tc.tag)
+			} else {
+				err = nt.Hold(tc.dataset, tc.tag)
+			}
+
+			if err != nil && !tc.wantErr {
+				t.Fatalf("expected no error but got: %v", err)
+			} else if err == nil && tc.wantErr {
+				t.Fatal("expected an error but got none")
+			}
+
+			if tc.isNoOp {
+				assertDatasetsEquals(t, ta, initState, z.Datasets())
+			} else {
+				assertDatasetsToGolden(t, ta, z.Datasets())
+			}
+
+			zfs.AssertNoZFSChildren(t, z)
+			assertIdempotentWithNew(t, ta, z.Datasets(), adapter)
+		})
+	}
+}
+
 func TestDependencies(t *testing.T) {
 	failOnZFSPermissionDenied(t)
 
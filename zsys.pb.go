@@ -29,6 +29,122 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type CreateUserDataRequest_Encryption int32
+
+const (
+	CreateUserDataRequest_DEFAULT       CreateUserDataRequest_Encryption = 0
+	CreateUserDataRequest_ENCRYPTED     CreateUserDataRequest_Encryption = 1
+	CreateUserDataRequest_NOT_ENCRYPTED CreateUserDataRequest_Encryption = 2
+)
+
+// Enum value maps for CreateUserDataRequest_Encryption.
+var (
+	CreateUserDataRequest_Encryption_name = map[int32]string{
+		0: "DEFAULT",
+		1: "ENCRYPTED",
+		2: "NOT_ENCRYPTED",
+	}
+	CreateUserDataRequest_Encryption_value = map[string]int32{
+		"DEFAULT":       0,
+		"ENCRYPTED":     1,
+		"NOT_ENCRYPTED": 2,
+	}
+)
+
+func (x CreateUserDataRequest_Encryption) Enum() *CreateUserDataRequest_Encryption {
+	p := new(CreateUserDataRequest_Encryption)
+	*p = x
+	return p
+}
+
+func (x CreateUserDataRequest_Encryption) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CreateUserDataRequest_Encryption) Descriptor() protoreflect.EnumDescriptor {
+	return file_zsys_proto_enumTypes[0].Descriptor()
+}
+
+func (CreateUserDataRequest_Encryption) Type() protoreflect.EnumType {
+	return &file_zsys_proto_enumTypes[0]
+}
+
+func (x CreateUserDataRequest_Encryption) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CreateUserDataRequest_Encryption.Descriptor instead.
+func (CreateUserDataRequest_Encryption) EnumDescriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type Event_Type int32
+
+const (
+	Event_UNKNOWN          Event_Type = 0
+	Event_STATE_CREATED    Event_Type = 1
+	Event_STATE_REMOVED    Event_Type = 2
+	Event_GC_FINISHED      Event_Type = 3
+	Event_BOOT_PREPARED    Event_Type = 4
+	Event_BOOT_COMMITTED   Event_Type = 5
+	Event_USERDATA_CREATED Event_Type = 6
+	Event_USER_DISSOCIATED Event_Type = 7
+	Event_CONFIG_RELOADED  Event_Type = 8
+)
+
+// Enum value maps for Event_Type.
+var (
+	Event_Type_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "STATE_CREATED",
+		2: "STATE_REMOVED",
+		3: "GC_FINISHED",
+		4: "BOOT_PREPARED",
+		5: "BOOT_COMMITTED",
+		6: "USERDATA_CREATED",
+		7: "USER_DISSOCIATED",
+		8: "CONFIG_RELOADED",
+	}
+	Event_Type_value = map[string]int32{
+		"UNKNOWN":          0,
+		"STATE_CREATED":    1,
+		"STATE_REMOVED":    2,
+		"GC_FINISHED":      3,
+		"BOOT_PREPARED":    4,
+		"BOOT_COMMITTED":   5,
+		"USERDATA_CREATED": 6,
+		"USER_DISSOCIATED": 7,
+		"CONFIG_RELOADED":  8,
+	}
+)
+
+func (x Event_Type) Enum() *Event_Type {
+	p := new(Event_Type)
+	*p = x
+	return p
+}
+
+func (x Event_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Event_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_zsys_proto_enumTypes[1].Descriptor()
+}
+
+func (Event_Type) Type() protoreflect.EnumType {
+	return &file_zsys_proto_enumTypes[1]
+}
+
+func (x Event_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Event_Type.Descriptor instead.
+func (Event_Type) EnumDescriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{50, 0}
+}
+
 type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -199,8 +315,10 @@ type CreateUserDataRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	User     string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	Homepath string `protobuf:"bytes,2,opt,name=homepath,proto3" json:"homepath,omitempty"`
+	User       string                           `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Homepath   string                           `protobuf:"bytes,2,opt,name=homepath,proto3" json:"homepath,omitempty"`
+	Encryption CreateUserDataRequest_Encryption `protobuf:"varint,3,opt,name=encryption,proto3,enum=zsys.CreateUserDataRequest_Encryption" json:"encryption,omitempty"`
+	Passphrase []byte                           `protobuf:"bytes,4,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
 }
 
 func (x *CreateUserDataRequest) Reset() {
@@ -249,6 +367,138 @@ func (x *CreateUserDataRequest) GetHomepath() string {
 	return ""
 }
 
+func (x *CreateUserDataRequest) GetEncryption() CreateUserDataRequest_Encryption {
+	if x != nil {
+		return x.Encryption
+	}
+	return CreateUserDataRequest_DEFAULT
+}
+
+func (x *CreateUserDataRequest) GetPassphrase() []byte {
+	if x != nil {
+		return x.Passphrase
+	}
+	return nil
+}
+
+type UnlockUserDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User       string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Passphrase []byte `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+}
+
+func (x *UnlockUserDataRequest) Reset() {
+	*x = UnlockUserDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnlockUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserDataRequest) ProtoMessage() {}
+
+func (x *UnlockUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserDataRequest.ProtoReflect.Descriptor instead.
+func (*UnlockUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UnlockUserDataRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *UnlockUserDataRequest) GetPassphrase() []byte {
+	if x != nil {
+		return x.Passphrase
+	}
+	return nil
+}
+
+type SetUserQuotaRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User        string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Quota       uint64 `protobuf:"varint,2,opt,name=quota,proto3" json:"quota,omitempty"`
+	Reservation uint64 `protobuf:"varint,3,opt,name=reservation,proto3" json:"reservation,omitempty"`
+}
+
+func (x *SetUserQuotaRequest) Reset() {
+	*x = SetUserQuotaRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetUserQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserQuotaRequest) ProtoMessage() {}
+
+func (x *SetUserQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserQuotaRequest.ProtoReflect.Descriptor instead.
+func (*SetUserQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetUserQuotaRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *SetUserQuotaRequest) GetQuota() uint64 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+func (x *SetUserQuotaRequest) GetReservation() uint64 {
+	if x != nil {
+		return x.Reservation
+	}
+	return 0
+}
+
 type ChangeHomeOnUserDataRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -261,7 +511,7 @@ type ChangeHomeOnUserDataRequest struct {
 func (x *ChangeHomeOnUserDataRequest) Reset() {
 	*x = ChangeHomeOnUserDataRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[4]
+		mi := &file_zsys_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -274,7 +524,7 @@ func (x *ChangeHomeOnUserDataRequest) String() string {
 func (*ChangeHomeOnUserDataRequest) ProtoMessage() {}
 
 func (x *ChangeHomeOnUserDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[4]
+	mi := &file_zsys_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -287,7 +537,7 @@ func (x *ChangeHomeOnUserDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangeHomeOnUserDataRequest.ProtoReflect.Descriptor instead.
 func (*ChangeHomeOnUserDataRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{4}
+	return file_zsys_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ChangeHomeOnUserDataRequest) GetHome() string {
@@ -316,7 +566,7 @@ type DissociateUserRequest struct {
 func (x *DissociateUserRequest) Reset() {
 	*x = DissociateUserRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[5]
+		mi := &file_zsys_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -329,7 +579,7 @@ func (x *DissociateUserRequest) String() string {
 func (*DissociateUserRequest) ProtoMessage() {}
 
 func (x *DissociateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[5]
+	mi := &file_zsys_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -342,7 +592,7 @@ func (x *DissociateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DissociateUserRequest.ProtoReflect.Descriptor instead.
 func (*DissociateUserRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{5}
+	return file_zsys_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DissociateUserRequest) GetUser() string {
@@ -373,7 +623,7 @@ type PrepareBootResponse struct {
 func (x *PrepareBootResponse) Reset() {
 	*x = PrepareBootResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[6]
+		mi := &file_zsys_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -386,7 +636,7 @@ func (x *PrepareBootResponse) String() string {
 func (*PrepareBootResponse) ProtoMessage() {}
 
 func (x *PrepareBootResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[6]
+	mi := &file_zsys_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +649,7 @@ func (x *PrepareBootResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrepareBootResponse.ProtoReflect.Descriptor instead.
 func (*PrepareBootResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{6}
+	return file_zsys_proto_rawDescGZIP(), []int{8}
 }
 
 func (m *PrepareBootResponse) GetReply() isPrepareBootResponse_Reply {
@@ -453,7 +703,7 @@ type CommitBootResponse struct {
 func (x *CommitBootResponse) Reset() {
 	*x = CommitBootResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[7]
+		mi := &file_zsys_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -466,7 +716,7 @@ func (x *CommitBootResponse) String() string {
 func (*CommitBootResponse) ProtoMessage() {}
 
 func (x *CommitBootResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[7]
+	mi := &file_zsys_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -479,7 +729,7 @@ func (x *CommitBootResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommitBootResponse.ProtoReflect.Descriptor instead.
 func (*CommitBootResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{7}
+	return file_zsys_proto_rawDescGZIP(), []int{9}
 }
 
 func (m *CommitBootResponse) GetReply() isCommitBootResponse_Reply {
@@ -530,7 +780,7 @@ type UpdateBootMenuRequest struct {
 func (x *UpdateBootMenuRequest) Reset() {
 	*x = UpdateBootMenuRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[8]
+		mi := &file_zsys_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -543,7 +793,7 @@ func (x *UpdateBootMenuRequest) String() string {
 func (*UpdateBootMenuRequest) ProtoMessage() {}
 
 func (x *UpdateBootMenuRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[8]
+	mi := &file_zsys_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +806,7 @@ func (x *UpdateBootMenuRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBootMenuRequest.ProtoReflect.Descriptor instead.
 func (*UpdateBootMenuRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{8}
+	return file_zsys_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateBootMenuRequest) GetAuto() bool {
@@ -571,15 +821,17 @@ type SaveSystemStateRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StateName      string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
-	UpdateBootMenu bool   `protobuf:"varint,2,opt,name=updateBootMenu,proto3" json:"updateBootMenu,omitempty"`
-	Autosave       bool   `protobuf:"varint,3,opt,name=autosave,proto3" json:"autosave,omitempty"`
+	StateName      string            `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	UpdateBootMenu bool              `protobuf:"varint,2,opt,name=updateBootMenu,proto3" json:"updateBootMenu,omitempty"`
+	Autosave       bool              `protobuf:"varint,3,opt,name=autosave,proto3" json:"autosave,omitempty"`
+	Description    string            `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Labels         map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *SaveSystemStateRequest) Reset() {
 	*x = SaveSystemStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[9]
+		mi := &file_zsys_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -592,7 +844,7 @@ func (x *SaveSystemStateRequest) String() string {
 func (*SaveSystemStateRequest) ProtoMessage() {}
 
 func (x *SaveSystemStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[9]
+	mi := &file_zsys_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,7 +857,7 @@ func (x *SaveSystemStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SaveSystemStateRequest.ProtoReflect.Descriptor instead.
 func (*SaveSystemStateRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{9}
+	return file_zsys_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SaveSystemStateRequest) GetStateName() string {
@@ -629,19 +881,35 @@ func (x *SaveSystemStateRequest) GetAutosave() bool {
 	return false
 }
 
+func (x *SaveSystemStateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SaveSystemStateRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type SaveUserStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserName  string `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
-	StateName string `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	UserName    string            `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	StateName   string            `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	Description string            `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *SaveUserStateRequest) Reset() {
 	*x = SaveUserStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[10]
+		mi := &file_zsys_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -654,7 +922,7 @@ func (x *SaveUserStateRequest) String() string {
 func (*SaveUserStateRequest) ProtoMessage() {}
 
 func (x *SaveUserStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[10]
+	mi := &file_zsys_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,7 +935,7 @@ func (x *SaveUserStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SaveUserStateRequest.ProtoReflect.Descriptor instead.
 func (*SaveUserStateRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{10}
+	return file_zsys_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SaveUserStateRequest) GetUserName() string {
@@ -684,6 +952,20 @@ func (x *SaveUserStateRequest) GetStateName() string {
 	return ""
 }
 
+func (x *SaveUserStateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SaveUserStateRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type CreateSaveStateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -698,7 +980,7 @@ type CreateSaveStateResponse struct {
 func (x *CreateSaveStateResponse) Reset() {
 	*x = CreateSaveStateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[11]
+		mi := &file_zsys_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -711,7 +993,7 @@ func (x *CreateSaveStateResponse) String() string {
 func (*CreateSaveStateResponse) ProtoMessage() {}
 
 func (x *CreateSaveStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[11]
+	mi := &file_zsys_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,7 +1006,7 @@ func (x *CreateSaveStateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSaveStateResponse.ProtoReflect.Descriptor instead.
 func (*CreateSaveStateResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{11}
+	return file_zsys_proto_rawDescGZIP(), []int{13}
 }
 
 func (m *CreateSaveStateResponse) GetReply() isCreateSaveStateResponse_Reply {
@@ -777,7 +1059,7 @@ type RemoveSystemStateRequest struct {
 func (x *RemoveSystemStateRequest) Reset() {
 	*x = RemoveSystemStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[12]
+		mi := &file_zsys_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -790,7 +1072,7 @@ func (x *RemoveSystemStateRequest) String() string {
 func (*RemoveSystemStateRequest) ProtoMessage() {}
 
 func (x *RemoveSystemStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[12]
+	mi := &file_zsys_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -803,7 +1085,7 @@ func (x *RemoveSystemStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveSystemStateRequest.ProtoReflect.Descriptor instead.
 func (*RemoveSystemStateRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{12}
+	return file_zsys_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RemoveSystemStateRequest) GetStateName() string {
@@ -841,7 +1123,7 @@ type RemoveUserStateRequest struct {
 func (x *RemoveUserStateRequest) Reset() {
 	*x = RemoveUserStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[13]
+		mi := &file_zsys_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -854,7 +1136,7 @@ func (x *RemoveUserStateRequest) String() string {
 func (*RemoveUserStateRequest) ProtoMessage() {}
 
 func (x *RemoveUserStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[13]
+	mi := &file_zsys_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,7 +1149,7 @@ func (x *RemoveUserStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveUserStateRequest.ProtoReflect.Descriptor instead.
 func (*RemoveUserStateRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{13}
+	return file_zsys_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *RemoveUserStateRequest) GetUserName() string {
@@ -898,34 +1180,32 @@ func (x *RemoveUserStateRequest) GetDryrun() bool {
 	return false
 }
 
-type DumpStatesResponse struct {
+type RevertSystemStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Reply:
-	//	*DumpStatesResponse_Log
-	//	*DumpStatesResponse_States
-	Reply isDumpStatesResponse_Reply `protobuf_oneof:"reply"`
+	StateName      string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	RevertUserData bool   `protobuf:"varint,2,opt,name=revertUserData,proto3" json:"revertUserData,omitempty"`
 }
 
-func (x *DumpStatesResponse) Reset() {
-	*x = DumpStatesResponse{}
+func (x *RevertSystemStateRequest) Reset() {
+	*x = RevertSystemStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[14]
+		mi := &file_zsys_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DumpStatesResponse) String() string {
+func (x *RevertSystemStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpStatesResponse) ProtoMessage() {}
+func (*RevertSystemStateRequest) ProtoMessage() {}
 
-func (x *DumpStatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[14]
+func (x *RevertSystemStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -936,73 +1216,107 @@ func (x *DumpStatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpStatesResponse.ProtoReflect.Descriptor instead.
-func (*DumpStatesResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use RevertSystemStateRequest.ProtoReflect.Descriptor instead.
+func (*RevertSystemStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{16}
 }
 
-func (m *DumpStatesResponse) GetReply() isDumpStatesResponse_Reply {
-	if m != nil {
-		return m.Reply
+func (x *RevertSystemStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
 	}
-	return nil
+	return ""
 }
 
-func (x *DumpStatesResponse) GetLog() string {
-	if x, ok := x.GetReply().(*DumpStatesResponse_Log); ok {
-		return x.Log
+func (x *RevertSystemStateRequest) GetRevertUserData() bool {
+	if x != nil {
+		return x.RevertUserData
 	}
-	return ""
+	return false
 }
 
-func (x *DumpStatesResponse) GetStates() string {
-	if x, ok := x.GetReply().(*DumpStatesResponse_States); ok {
-		return x.States
+type RevertUserStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserName  string `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	StateName string `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+}
+
+func (x *RevertUserStateRequest) Reset() {
+	*x = RevertUserStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-type isDumpStatesResponse_Reply interface {
-	isDumpStatesResponse_Reply()
+func (x *RevertUserStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type DumpStatesResponse_Log struct {
-	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+func (*RevertUserStateRequest) ProtoMessage() {}
+
+func (x *RevertUserStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type DumpStatesResponse_States struct {
-	States string `protobuf:"bytes,2,opt,name=states,proto3,oneof"`
+// Deprecated: Use RevertUserStateRequest.ProtoReflect.Descriptor instead.
+func (*RevertUserStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{17}
 }
 
-func (*DumpStatesResponse_Log) isDumpStatesResponse_Reply() {}
+func (x *RevertUserStateRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
 
-func (*DumpStatesResponse_States) isDumpStatesResponse_Reply() {}
+func (x *RevertUserStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
 
-type LoggingLevelRequest struct {
+type RestoreUserStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Logginglevel int32 `protobuf:"varint,1,opt,name=logginglevel,proto3" json:"logginglevel,omitempty"`
+	UserName  string `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	StateName string `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	Force     bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
 }
 
-func (x *LoggingLevelRequest) Reset() {
-	*x = LoggingLevelRequest{}
+func (x *RestoreUserStateRequest) Reset() {
+	*x = RestoreUserStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[15]
+		mi := &file_zsys_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoggingLevelRequest) String() string {
+func (x *RestoreUserStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoggingLevelRequest) ProtoMessage() {}
+func (*RestoreUserStateRequest) ProtoMessage() {}
 
-func (x *LoggingLevelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[15]
+func (x *RestoreUserStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1013,44 +1327,61 @@ func (x *LoggingLevelRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoggingLevelRequest.ProtoReflect.Descriptor instead.
-func (*LoggingLevelRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use RestoreUserStateRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *LoggingLevelRequest) GetLogginglevel() int32 {
+func (x *RestoreUserStateRequest) GetUserName() string {
 	if x != nil {
-		return x.Logginglevel
+		return x.UserName
 	}
-	return 0
+	return ""
 }
 
-type TraceRequest struct {
+func (x *RestoreUserStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *RestoreUserStateRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type StateDiffRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Duration int32  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	From      string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To        string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	UserName  string `protobuf:"bytes,3,opt,name=userName,proto3" json:"userName,omitempty"`
+	WithUsers bool   `protobuf:"varint,4,opt,name=withUsers,proto3" json:"withUsers,omitempty"`
+	Path      string `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
 }
 
-func (x *TraceRequest) Reset() {
-	*x = TraceRequest{}
+func (x *StateDiffRequest) Reset() {
+	*x = StateDiffRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[16]
+		mi := &file_zsys_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TraceRequest) String() string {
+func (x *StateDiffRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TraceRequest) ProtoMessage() {}
+func (*StateDiffRequest) ProtoMessage() {}
 
-func (x *TraceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[16]
+func (x *StateDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1061,53 +1392,74 @@ func (x *TraceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TraceRequest.ProtoReflect.Descriptor instead.
-func (*TraceRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use StateDiffRequest.ProtoReflect.Descriptor instead.
+func (*StateDiffRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *TraceRequest) GetType() string {
+func (x *StateDiffRequest) GetFrom() string {
 	if x != nil {
-		return x.Type
+		return x.From
 	}
 	return ""
 }
 
-func (x *TraceRequest) GetDuration() int32 {
+func (x *StateDiffRequest) GetTo() string {
 	if x != nil {
-		return x.Duration
+		return x.To
 	}
-	return 0
+	return ""
 }
 
-type TraceResponse struct {
+func (x *StateDiffRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *StateDiffRequest) GetWithUsers() bool {
+	if x != nil {
+		return x.WithUsers
+	}
+	return false
+}
+
+func (x *StateDiffRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type StateDiffResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Reply:
-	//	*TraceResponse_Log
-	//	*TraceResponse_Trace
-	Reply isTraceResponse_Reply `protobuf_oneof:"reply"`
+	//	*StateDiffResponse_Log
+	//	*StateDiffResponse_Diff
+	Reply isStateDiffResponse_Reply `protobuf_oneof:"reply"`
 }
 
-func (x *TraceResponse) Reset() {
-	*x = TraceResponse{}
+func (x *StateDiffResponse) Reset() {
+	*x = StateDiffResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[17]
+		mi := &file_zsys_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TraceResponse) String() string {
+func (x *StateDiffResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TraceResponse) ProtoMessage() {}
+func (*StateDiffResponse) ProtoMessage() {}
 
-func (x *TraceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[17]
+func (x *StateDiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1118,73 +1470,74 @@ func (x *TraceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TraceResponse.ProtoReflect.Descriptor instead.
-func (*TraceResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use StateDiffResponse.ProtoReflect.Descriptor instead.
+func (*StateDiffResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{20}
 }
 
-func (m *TraceResponse) GetReply() isTraceResponse_Reply {
+func (m *StateDiffResponse) GetReply() isStateDiffResponse_Reply {
 	if m != nil {
 		return m.Reply
 	}
 	return nil
 }
 
-func (x *TraceResponse) GetLog() string {
-	if x, ok := x.GetReply().(*TraceResponse_Log); ok {
+func (x *StateDiffResponse) GetLog() string {
+	if x, ok := x.GetReply().(*StateDiffResponse_Log); ok {
 		return x.Log
 	}
 	return ""
 }
 
-func (x *TraceResponse) GetTrace() []byte {
-	if x, ok := x.GetReply().(*TraceResponse_Trace); ok {
-		return x.Trace
+func (x *StateDiffResponse) GetDiff() *DatasetDiff {
+	if x, ok := x.GetReply().(*StateDiffResponse_Diff); ok {
+		return x.Diff
 	}
 	return nil
 }
 
-type isTraceResponse_Reply interface {
-	isTraceResponse_Reply()
+type isStateDiffResponse_Reply interface {
+	isStateDiffResponse_Reply()
 }
 
-type TraceResponse_Log struct {
+type StateDiffResponse_Log struct {
 	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
 }
 
-type TraceResponse_Trace struct {
-	Trace []byte `protobuf:"bytes,2,opt,name=trace,proto3,oneof"`
+type StateDiffResponse_Diff struct {
+	Diff *DatasetDiff `protobuf:"bytes,2,opt,name=diff,proto3,oneof"`
 }
 
-func (*TraceResponse_Log) isTraceResponse_Reply() {}
+func (*StateDiffResponse_Log) isStateDiffResponse_Reply() {}
 
-func (*TraceResponse_Trace) isTraceResponse_Reply() {}
+func (*StateDiffResponse_Diff) isStateDiffResponse_Reply() {}
 
-type GCRequest struct {
+type PinStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	All bool `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	StateName string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	UserName  string `protobuf:"bytes,2,opt,name=userName,proto3" json:"userName,omitempty"`
 }
 
-func (x *GCRequest) Reset() {
-	*x = GCRequest{}
+func (x *PinStateRequest) Reset() {
+	*x = PinStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[18]
+		mi := &file_zsys_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GCRequest) String() string {
+func (x *PinStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GCRequest) ProtoMessage() {}
+func (*PinStateRequest) ProtoMessage() {}
 
-func (x *GCRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[18]
+func (x *PinStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1195,44 +1548,51 @@ func (x *GCRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GCRequest.ProtoReflect.Descriptor instead.
-func (*GCRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use PinStateRequest.ProtoReflect.Descriptor instead.
+func (*PinStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *GCRequest) GetAll() bool {
+func (x *PinStateRequest) GetStateName() string {
 	if x != nil {
-		return x.All
+		return x.StateName
 	}
-	return false
+	return ""
 }
 
-type MachineShowRequest struct {
+func (x *PinStateRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+type UnpinStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MachineId string `protobuf:"bytes,1,opt,name=machineId,proto3" json:"machineId,omitempty"`
-	Full      bool   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	StateName string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	UserName  string `protobuf:"bytes,2,opt,name=userName,proto3" json:"userName,omitempty"`
 }
 
-func (x *MachineShowRequest) Reset() {
-	*x = MachineShowRequest{}
+func (x *UnpinStateRequest) Reset() {
+	*x = UnpinStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[19]
+		mi := &file_zsys_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MachineShowRequest) String() string {
+func (x *UnpinStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachineShowRequest) ProtoMessage() {}
+func (*UnpinStateRequest) ProtoMessage() {}
 
-func (x *MachineShowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[19]
+func (x *UnpinStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1243,53 +1603,52 @@ func (x *MachineShowRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MachineShowRequest.ProtoReflect.Descriptor instead.
-func (*MachineShowRequest) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use UnpinStateRequest.ProtoReflect.Descriptor instead.
+func (*UnpinStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *MachineShowRequest) GetMachineId() string {
+func (x *UnpinStateRequest) GetStateName() string {
 	if x != nil {
-		return x.MachineId
+		return x.StateName
 	}
 	return ""
 }
 
-func (x *MachineShowRequest) GetFull() bool {
+func (x *UnpinStateRequest) GetUserName() string {
 	if x != nil {
-		return x.Full
+		return x.UserName
 	}
-	return false
+	return ""
 }
 
-type MachineShowResponse struct {
+type RenameStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Reply:
-	//	*MachineShowResponse_Log
-	//	*MachineShowResponse_MachineInfo
-	Reply isMachineShowResponse_Reply `protobuf_oneof:"reply"`
+	StateName string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	UserName  string `protobuf:"bytes,2,opt,name=userName,proto3" json:"userName,omitempty"`
+	NewName   string `protobuf:"bytes,3,opt,name=newName,proto3" json:"newName,omitempty"`
 }
 
-func (x *MachineShowResponse) Reset() {
-	*x = MachineShowResponse{}
+func (x *RenameStateRequest) Reset() {
+	*x = RenameStateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[20]
+		mi := &file_zsys_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MachineShowResponse) String() string {
+func (x *RenameStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachineShowResponse) ProtoMessage() {}
+func (*RenameStateRequest) ProtoMessage() {}
 
-func (x *MachineShowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[20]
+func (x *RenameStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1300,76 +1659,59 @@ func (x *MachineShowResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MachineShowResponse.ProtoReflect.Descriptor instead.
-func (*MachineShowResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use RenameStateRequest.ProtoReflect.Descriptor instead.
+func (*RenameStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{23}
 }
 
-func (m *MachineShowResponse) GetReply() isMachineShowResponse_Reply {
-	if m != nil {
-		return m.Reply
+func (x *RenameStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
 	}
-	return nil
+	return ""
 }
 
-func (x *MachineShowResponse) GetLog() string {
-	if x, ok := x.GetReply().(*MachineShowResponse_Log); ok {
-		return x.Log
+func (x *RenameStateRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
 	}
 	return ""
 }
 
-func (x *MachineShowResponse) GetMachineInfo() string {
-	if x, ok := x.GetReply().(*MachineShowResponse_MachineInfo); ok {
-		return x.MachineInfo
+func (x *RenameStateRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
 	}
 	return ""
 }
 
-type isMachineShowResponse_Reply interface {
-	isMachineShowResponse_Reply()
-}
-
-type MachineShowResponse_Log struct {
-	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
-}
-
-type MachineShowResponse_MachineInfo struct {
-	MachineInfo string `protobuf:"bytes,2,opt,name=machineInfo,proto3,oneof"`
-}
-
-func (*MachineShowResponse_Log) isMachineShowResponse_Reply() {}
-
-func (*MachineShowResponse_MachineInfo) isMachineShowResponse_Reply() {}
-
-type MachineListResponse struct {
+type ListStatesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Reply:
-	//	*MachineListResponse_Log
-	//	*MachineListResponse_MachineList
-	Reply isMachineListResponse_Reply `protobuf_oneof:"reply"`
+	UserName    string            `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	Description string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *MachineListResponse) Reset() {
-	*x = MachineListResponse{}
+func (x *ListStatesRequest) Reset() {
+	*x = ListStatesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_zsys_proto_msgTypes[21]
+		mi := &file_zsys_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MachineListResponse) String() string {
+func (x *ListStatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MachineListResponse) ProtoMessage() {}
+func (*ListStatesRequest) ProtoMessage() {}
 
-func (x *MachineListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_zsys_proto_msgTypes[21]
+func (x *ListStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,397 +1722,4292 @@ func (x *MachineListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MachineListResponse.ProtoReflect.Descriptor instead.
-func (*MachineListResponse) Descriptor() ([]byte, []int) {
-	return file_zsys_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ListStatesRequest.ProtoReflect.Descriptor instead.
+func (*ListStatesRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{24}
 }
 
-func (m *MachineListResponse) GetReply() isMachineListResponse_Reply {
+func (x *ListStatesRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *ListStatesRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ListStatesRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type ListStatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*ListStatesResponse_Log
+	//	*ListStatesResponse_States
+	Reply isListStatesResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *ListStatesResponse) Reset() {
+	*x = ListStatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatesResponse) ProtoMessage() {}
+
+func (x *ListStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatesResponse.ProtoReflect.Descriptor instead.
+func (*ListStatesResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{25}
+}
+
+func (m *ListStatesResponse) GetReply() isListStatesResponse_Reply {
 	if m != nil {
 		return m.Reply
 	}
 	return nil
 }
 
-func (x *MachineListResponse) GetLog() string {
-	if x, ok := x.GetReply().(*MachineListResponse_Log); ok {
+func (x *ListStatesResponse) GetLog() string {
+	if x, ok := x.GetReply().(*ListStatesResponse_Log); ok {
 		return x.Log
 	}
 	return ""
 }
 
-func (x *MachineListResponse) GetMachineList() string {
-	if x, ok := x.GetReply().(*MachineListResponse_MachineList); ok {
-		return x.MachineList
+func (x *ListStatesResponse) GetStates() *States {
+	if x, ok := x.GetReply().(*ListStatesResponse_States); ok {
+		return x.States
 	}
-	return ""
+	return nil
 }
 
-type isMachineListResponse_Reply interface {
-	isMachineListResponse_Reply()
+type isListStatesResponse_Reply interface {
+	isListStatesResponse_Reply()
 }
 
-type MachineListResponse_Log struct {
+type ListStatesResponse_Log struct {
 	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
 }
 
-type MachineListResponse_MachineList struct {
-	MachineList string `protobuf:"bytes,2,opt,name=machineList,proto3,oneof"`
+type ListStatesResponse_States struct {
+	States *States `protobuf:"bytes,2,opt,name=states,proto3,oneof"`
 }
 
-func (*MachineListResponse_Log) isMachineListResponse_Reply() {}
+func (*ListStatesResponse_Log) isListStatesResponse_Reply() {}
 
-func (*MachineListResponse_MachineList) isMachineListResponse_Reply() {}
+func (*ListStatesResponse_States) isListStatesResponse_Reply() {}
 
-var File_zsys_proto protoreflect.FileDescriptor
+type States struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_zsys_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x7a, 0x73,
-	0x79, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0b, 0x4c,
-	0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f,
-	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x22, 0x4a, 0x0a, 0x0f,
-	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
-	0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42,
-	0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x47, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x74,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61, 0x74,
-	0x68, 0x22, 0x4b, 0x0a, 0x1b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f,
-	0x6e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x68, 0x6f, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x6f, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x48, 0x6f, 0x6d, 0x65, 0x22, 0x4b,
-	0x0a, 0x15, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x22, 0x4e, 0x0a, 0x13, 0x50,
-	0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x4d, 0x0a, 0x12, 0x43,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x2b, 0x0a, 0x15, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x6f, 0x22, 0x7a, 0x0a, 0x16, 0x53, 0x61, 0x76, 0x65, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x26, 0x0a, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e,
-	0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42,
-	0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73,
-	0x61, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73,
-	0x61, 0x76, 0x65, 0x22, 0x50, 0x0a, 0x14, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x56, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
-	0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1e, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x66, 0x0a,
-	0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64,
-	0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f,
-	0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x4b, 0x0a, 0x12, 0x44, 0x75, 0x6d, 0x70,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
-	0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c,
-	0x6f, 0x67, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05,
-	0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x39, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67,
-	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
-	0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x76, 0x65, 0x6c,
-	0x22, 0x3e, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x44, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x42, 0x07, 0x0a,
-	0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1d, 0x0a, 0x09, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x03, 0x61, 0x6c, 0x6c, 0x22, 0x46, 0x0a, 0x12, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d,
-	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c,
-	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x22, 0x56, 0x0a,
-	0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x22, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68,
-	0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x07, 0x0a, 0x05,
-	0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x56, 0x0a, 0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03,
-	0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67,
-	0x12, 0x22, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x32, 0xb2, 0x0a,
-	0x0a, 0x04, 0x5a, 0x73, 0x79, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x14, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44,
-	0x61, 0x74, 0x61, 0x12, 0x21, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x44,
-	0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1b, 0x2e,
-	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55,
-	0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
-	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
-	0x37, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x0b,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73,
-	0x79, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
-	0x42, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e,
-	0x75, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42,
-	0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x73,
-	0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a, 0x73, 0x79,
-	0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x0d, 0x53, 0x61, 0x76,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x7a, 0x73, 0x79,
-	0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
-	0x01, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
-	0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0a, 0x44, 0x75, 0x6d, 0x70, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2e,
-	0x0a, 0x0a, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x0b, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e,
-	0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76,
-	0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2b,
-	0x0a, 0x07, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x05, 0x54,
-	0x72, 0x61, 0x63, 0x65, 0x12, 0x12, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
-	0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
-	0x2a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x06, 0x52,
-	0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x02, 0x47, 0x43, 0x12, 0x0f, 0x2e,
-	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
-	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68,
-	0x6f, 0x77, 0x12, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e,
-	0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x7a,
-	0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x0b, 0x4d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63,
-	0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x30, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	States []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
 }
 
-var (
-	file_zsys_proto_rawDescOnce sync.Once
-	file_zsys_proto_rawDescData = file_zsys_proto_rawDesc
-)
+func (x *States) Reset() {
+	*x = States{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_zsys_proto_rawDescGZIP() []byte {
-	file_zsys_proto_rawDescOnce.Do(func() {
-		file_zsys_proto_rawDescData = protoimpl.X.CompressGZIP(file_zsys_proto_rawDescData)
-	})
-	return file_zsys_proto_rawDescData
+func (x *States) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_zsys_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
-var file_zsys_proto_goTypes = []interface{}{
-	(*Empty)(nil),                       // 0: zsys.Empty
-	(*LogResponse)(nil),                 // 1: zsys.LogResponse
-	(*VersionResponse)(nil),             // 2: zsys.VersionResponse
-	(*CreateUserDataRequest)(nil),       // 3: zsys.CreateUserDataRequest
-	(*ChangeHomeOnUserDataRequest)(nil), // 4: zsys.ChangeHomeOnUserDataRequest
-	(*DissociateUserRequest)(nil),       // 5: zsys.DissociateUserRequest
-	(*PrepareBootResponse)(nil),         // 6: zsys.PrepareBootResponse
-	(*CommitBootResponse)(nil),          // 7: zsys.CommitBootResponse
-	(*UpdateBootMenuRequest)(nil),       // 8: zsys.UpdateBootMenuRequest
-	(*SaveSystemStateRequest)(nil),      // 9: zsys.SaveSystemStateRequest
-	(*SaveUserStateRequest)(nil),        // 10: zsys.SaveUserStateRequest
-	(*CreateSaveStateResponse)(nil),     // 11: zsys.CreateSaveStateResponse
-	(*RemoveSystemStateRequest)(nil),    // 12: zsys.RemoveSystemStateRequest
-	(*RemoveUserStateRequest)(nil),      // 13: zsys.RemoveUserStateRequest
-	(*DumpStatesResponse)(nil),          // 14: zsys.DumpStatesResponse
-	(*LoggingLevelRequest)(nil),         // 15: zsys.LoggingLevelRequest
-	(*TraceRequest)(nil),                // 16: zsys.TraceRequest
-	(*TraceResponse)(nil),               // 17: zsys.TraceResponse
-	(*GCRequest)(nil),                   // 18: zsys.GCRequest
-	(*MachineShowRequest)(nil),          // 19: zsys.MachineShowRequest
-	(*MachineShowResponse)(nil),         // 20: zsys.MachineShowResponse
-	(*MachineListResponse)(nil),         // 21: zsys.MachineListResponse
+func (*States) ProtoMessage() {}
+
+func (x *States) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_zsys_proto_depIdxs = []int32{
-	0,  // 0: zsys.Zsys.Version:input_type -> zsys.Empty
-	3,  // 1: zsys.Zsys.CreateUserData:input_type -> zsys.CreateUserDataRequest
-	4,  // 2: zsys.Zsys.ChangeHomeOnUserData:input_type -> zsys.ChangeHomeOnUserDataRequest
-	5,  // 3: zsys.Zsys.DissociateUser:input_type -> zsys.DissociateUserRequest
-	0,  // 4: zsys.Zsys.PrepareBoot:input_type -> zsys.Empty
-	0,  // 5: zsys.Zsys.CommitBoot:input_type -> zsys.Empty
-	8,  // 6: zsys.Zsys.UpdateBootMenu:input_type -> zsys.UpdateBootMenuRequest
-	0,  // 7: zsys.Zsys.UpdateLastUsed:input_type -> zsys.Empty
-	9,  // 8: zsys.Zsys.SaveSystemState:input_type -> zsys.SaveSystemStateRequest
-	10, // 9: zsys.Zsys.SaveUserState:input_type -> zsys.SaveUserStateRequest
-	12, // 10: zsys.Zsys.RemoveSystemState:input_type -> zsys.RemoveSystemStateRequest
-	13, // 11: zsys.Zsys.RemoveUserState:input_type -> zsys.RemoveUserStateRequest
-	0,  // 12: zsys.Zsys.DumpStates:input_type -> zsys.Empty
-	0,  // 13: zsys.Zsys.DaemonStop:input_type -> zsys.Empty
-	15, // 14: zsys.Zsys.LoggingLevel:input_type -> zsys.LoggingLevelRequest
-	0,  // 15: zsys.Zsys.Refresh:input_type -> zsys.Empty
-	16, // 16: zsys.Zsys.Trace:input_type -> zsys.TraceRequest
-	0,  // 17: zsys.Zsys.Status:input_type -> zsys.Empty
-	0,  // 18: zsys.Zsys.Reload:input_type -> zsys.Empty
-	18, // 19: zsys.Zsys.GC:input_type -> zsys.GCRequest
-	19, // 20: zsys.Zsys.MachineShow:input_type -> zsys.MachineShowRequest
-	0,  // 21: zsys.Zsys.MachineList:input_type -> zsys.Empty
-	2,  // 22: zsys.Zsys.Version:output_type -> zsys.VersionResponse
-	1,  // 23: zsys.Zsys.CreateUserData:output_type -> zsys.LogResponse
-	1,  // 24: zsys.Zsys.ChangeHomeOnUserData:output_type -> zsys.LogResponse
-	1,  // 25: zsys.Zsys.DissociateUser:output_type -> zsys.LogResponse
-	6,  // 26: zsys.Zsys.PrepareBoot:output_type -> zsys.PrepareBootResponse
-	7,  // 27: zsys.Zsys.CommitBoot:output_type -> zsys.CommitBootResponse
-	1,  // 28: zsys.Zsys.UpdateBootMenu:output_type -> zsys.LogResponse
-	1,  // 29: zsys.Zsys.UpdateLastUsed:output_type -> zsys.LogResponse
-	11, // 30: zsys.Zsys.SaveSystemState:output_type -> zsys.CreateSaveStateResponse
-	11, // 31: zsys.Zsys.SaveUserState:output_type -> zsys.CreateSaveStateResponse
-	1,  // 32: zsys.Zsys.RemoveSystemState:output_type -> zsys.LogResponse
-	1,  // 33: zsys.Zsys.RemoveUserState:output_type -> zsys.LogResponse
-	14, // 34: zsys.Zsys.DumpStates:output_type -> zsys.DumpStatesResponse
-	1,  // 35: zsys.Zsys.DaemonStop:output_type -> zsys.LogResponse
-	1,  // 36: zsys.Zsys.LoggingLevel:output_type -> zsys.LogResponse
-	1,  // 37: zsys.Zsys.Refresh:output_type -> zsys.LogResponse
-	17, // 38: zsys.Zsys.Trace:output_type -> zsys.TraceResponse
-	1,  // 39: zsys.Zsys.Status:output_type -> zsys.LogResponse
-	1,  // 40: zsys.Zsys.Reload:output_type -> zsys.LogResponse
-	1,  // 41: zsys.Zsys.GC:output_type -> zsys.LogResponse
-	20, // 42: zsys.Zsys.MachineShow:output_type -> zsys.MachineShowResponse
-	21, // 43: zsys.Zsys.MachineList:output_type -> zsys.MachineListResponse
-	22, // [22:44] is the sub-list for method output_type
-	0,  // [0:22] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use States.ProtoReflect.Descriptor instead.
+func (*States) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{26}
 }
 
-func init() { file_zsys_proto_init() }
-func file_zsys_proto_init() {
-	if File_zsys_proto != nil {
-		return
+func (x *States) GetStates() []*State {
+	if x != nil {
+		return x.States
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_zsys_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_zsys_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_zsys_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+type ExportStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StateName string `protobuf:"bytes,1,opt,name=stateName,proto3" json:"stateName,omitempty"`
+	UserName  string `protobuf:"bytes,2,opt,name=userName,proto3" json:"userName,omitempty"`
+	Target    string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *ExportStateRequest) Reset() {
+	*x = ExportStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStateRequest) ProtoMessage() {}
+
+func (x *ExportStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_zsys_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateUserDataRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStateRequest.ProtoReflect.Descriptor instead.
+func (*ExportStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ExportStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+func (x *ExportStateRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *ExportStateRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type ImportStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source    string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	StateName string `protobuf:"bytes,2,opt,name=stateName,proto3" json:"stateName,omitempty"`
+}
+
+func (x *ImportStateRequest) Reset() {
+	*x = ImportStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStateRequest) ProtoMessage() {}
+
+func (x *ImportStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_zsys_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangeHomeOnUserDataRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStateRequest.ProtoReflect.Descriptor instead.
+func (*ImportStateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportStateRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ImportStateRequest) GetStateName() string {
+	if x != nil {
+		return x.StateName
+	}
+	return ""
+}
+
+type DatasetDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From    string        `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To      string        `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Changes []*PathChange `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *DatasetDiff) Reset() {
+	*x = DatasetDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatasetDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatasetDiff) ProtoMessage() {}
+
+func (x *DatasetDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatasetDiff.ProtoReflect.Descriptor instead.
+func (*DatasetDiff) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DatasetDiff) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *DatasetDiff) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *DatasetDiff) GetChanges() []*PathChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type PathChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Change  string `protobuf:"bytes,1,opt,name=change,proto3" json:"change,omitempty"`
+	Path    string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	NewPath string `protobuf:"bytes,3,opt,name=newPath,proto3" json:"newPath,omitempty"`
+}
+
+func (x *PathChange) Reset() {
+	*x = PathChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathChange) ProtoMessage() {}
+
+func (x *PathChange) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathChange.ProtoReflect.Descriptor instead.
+func (*PathChange) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PathChange) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *PathChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PathChange) GetNewPath() string {
+	if x != nil {
+		return x.NewPath
+	}
+	return ""
+}
+
+type DumpStatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*DumpStatesResponse_Log
+	//	*DumpStatesResponse_States
+	Reply isDumpStatesResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *DumpStatesResponse) Reset() {
+	*x = DumpStatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpStatesResponse) ProtoMessage() {}
+
+func (x *DumpStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpStatesResponse.ProtoReflect.Descriptor instead.
+func (*DumpStatesResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{31}
+}
+
+func (m *DumpStatesResponse) GetReply() isDumpStatesResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *DumpStatesResponse) GetLog() string {
+	if x, ok := x.GetReply().(*DumpStatesResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *DumpStatesResponse) GetStates() string {
+	if x, ok := x.GetReply().(*DumpStatesResponse_States); ok {
+		return x.States
+	}
+	return ""
+}
+
+type isDumpStatesResponse_Reply interface {
+	isDumpStatesResponse_Reply()
+}
+
+type DumpStatesResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type DumpStatesResponse_States struct {
+	States string `protobuf:"bytes,2,opt,name=states,proto3,oneof"`
+}
+
+func (*DumpStatesResponse_Log) isDumpStatesResponse_Reply() {}
+
+func (*DumpStatesResponse_States) isDumpStatesResponse_Reply() {}
+
+type LoggingLevelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Logginglevel int32 `protobuf:"varint,1,opt,name=logginglevel,proto3" json:"logginglevel,omitempty"`
+}
+
+func (x *LoggingLevelRequest) Reset() {
+	*x = LoggingLevelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoggingLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoggingLevelRequest) ProtoMessage() {}
+
+func (x *LoggingLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoggingLevelRequest.ProtoReflect.Descriptor instead.
+func (*LoggingLevelRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LoggingLevelRequest) GetLogginglevel() int32 {
+	if x != nil {
+		return x.Logginglevel
+	}
+	return 0
+}
+
+type TraceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Duration int32  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *TraceRequest) Reset() {
+	*x = TraceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceRequest) ProtoMessage() {}
+
+func (x *TraceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceRequest.ProtoReflect.Descriptor instead.
+func (*TraceRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TraceRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TraceRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type TraceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*TraceResponse_Log
+	//	*TraceResponse_Trace
+	Reply isTraceResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *TraceResponse) Reset() {
+	*x = TraceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TraceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceResponse) ProtoMessage() {}
+
+func (x *TraceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceResponse.ProtoReflect.Descriptor instead.
+func (*TraceResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{34}
+}
+
+func (m *TraceResponse) GetReply() isTraceResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *TraceResponse) GetLog() string {
+	if x, ok := x.GetReply().(*TraceResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *TraceResponse) GetTrace() []byte {
+	if x, ok := x.GetReply().(*TraceResponse_Trace); ok {
+		return x.Trace
+	}
+	return nil
+}
+
+type isTraceResponse_Reply interface {
+	isTraceResponse_Reply()
+}
+
+type TraceResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type TraceResponse_Trace struct {
+	Trace []byte `protobuf:"bytes,2,opt,name=trace,proto3,oneof"`
+}
+
+func (*TraceResponse_Log) isTraceResponse_Reply() {}
+
+func (*TraceResponse_Trace) isTraceResponse_Reply() {}
+
+type GCRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	All    bool `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	DryRun bool `protobuf:"varint,2,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+}
+
+func (x *GCRequest) Reset() {
+	*x = GCRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCRequest) ProtoMessage() {}
+
+func (x *GCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCRequest.ProtoReflect.Descriptor instead.
+func (*GCRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GCRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *GCRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type GCResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*GCResponse_Log
+	//	*GCResponse_Decision
+	Reply isGCResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *GCResponse) Reset() {
+	*x = GCResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCResponse) ProtoMessage() {}
+
+func (x *GCResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCResponse.ProtoReflect.Descriptor instead.
+func (*GCResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{36}
+}
+
+func (m *GCResponse) GetReply() isGCResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *GCResponse) GetLog() string {
+	if x, ok := x.GetReply().(*GCResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *GCResponse) GetDecision() *GCDecision {
+	if x, ok := x.GetReply().(*GCResponse_Decision); ok {
+		return x.Decision
+	}
+	return nil
+}
+
+type isGCResponse_Reply interface {
+	isGCResponse_Reply()
+}
+
+type GCResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type GCResponse_Decision struct {
+	Decision *GCDecision `protobuf:"bytes,2,opt,name=decision,proto3,oneof"`
+}
+
+func (*GCResponse_Log) isGCResponse_Reply() {}
+
+func (*GCResponse_Decision) isGCResponse_Reply() {}
+
+type GCDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Machine  string `protobuf:"bytes,1,opt,name=machine,proto3" json:"machine,omitempty"`
+	User     string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	State    string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	LastUsed int64  `protobuf:"varint,4,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	Bucket   string `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Remove   bool   `protobuf:"varint,6,opt,name=remove,proto3" json:"remove,omitempty"`
+	Reason   string `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *GCDecision) Reset() {
+	*x = GCDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCDecision) ProtoMessage() {}
+
+func (x *GCDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCDecision.ProtoReflect.Descriptor instead.
+func (*GCDecision) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GCDecision) GetMachine() string {
+	if x != nil {
+		return x.Machine
+	}
+	return ""
+}
+
+func (x *GCDecision) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GCDecision) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GCDecision) GetLastUsed() int64 {
+	if x != nil {
+		return x.LastUsed
+	}
+	return 0
+}
+
+func (x *GCDecision) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GCDecision) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+func (x *GCDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GCSimulateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days int32   `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	Rate float64 `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+}
+
+func (x *GCSimulateRequest) Reset() {
+	*x = GCSimulateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCSimulateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCSimulateRequest) ProtoMessage() {}
+
+func (x *GCSimulateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCSimulateRequest.ProtoReflect.Descriptor instead.
+func (*GCSimulateRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GCSimulateRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GCSimulateRequest) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+type GCSimulateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*GCSimulateResponse_Log
+	//	*GCSimulateResponse_Simulation
+	Reply isGCSimulateResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *GCSimulateResponse) Reset() {
+	*x = GCSimulateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCSimulateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCSimulateResponse) ProtoMessage() {}
+
+func (x *GCSimulateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCSimulateResponse.ProtoReflect.Descriptor instead.
+func (*GCSimulateResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{39}
+}
+
+func (m *GCSimulateResponse) GetReply() isGCSimulateResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *GCSimulateResponse) GetLog() string {
+	if x, ok := x.GetReply().(*GCSimulateResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *GCSimulateResponse) GetSimulation() *GCSimulation {
+	if x, ok := x.GetReply().(*GCSimulateResponse_Simulation); ok {
+		return x.Simulation
+	}
+	return nil
+}
+
+type isGCSimulateResponse_Reply interface {
+	isGCSimulateResponse_Reply()
+}
+
+type GCSimulateResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type GCSimulateResponse_Simulation struct {
+	Simulation *GCSimulation `protobuf:"bytes,2,opt,name=simulation,proto3,oneof"`
+}
+
+func (*GCSimulateResponse_Log) isGCSimulateResponse_Reply() {}
+
+func (*GCSimulateResponse_Simulation) isGCSimulateResponse_Reply() {}
+
+type GCSimulation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days     []*GCSimulatedDay     `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	Removals []*GCSimulatedRemoval `protobuf:"bytes,2,rep,name=removals,proto3" json:"removals,omitempty"`
+	Buckets  []*GCSimulatedBucket  `protobuf:"bytes,3,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *GCSimulation) Reset() {
+	*x = GCSimulation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCSimulation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCSimulation) ProtoMessage() {}
+
+func (x *GCSimulation) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCSimulation.ProtoReflect.Descriptor instead.
+func (*GCSimulation) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GCSimulation) GetDays() []*GCSimulatedDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GCSimulation) GetRemovals() []*GCSimulatedRemoval {
+	if x != nil {
+		return x.Removals
+	}
+	return nil
+}
+
+func (x *GCSimulation) GetBuckets() []*GCSimulatedBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type GCSimulatedDay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date    int64            `protobuf:"varint,1,opt,name=date,proto3" json:"date,omitempty"`
+	Saved   int32            `protobuf:"varint,2,opt,name=saved,proto3" json:"saved,omitempty"`
+	Removed int32            `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+	System  int32            `protobuf:"varint,4,opt,name=system,proto3" json:"system,omitempty"`
+	Users   map[string]int32 `protobuf:"bytes,5,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *GCSimulatedDay) Reset() {
+	*x = GCSimulatedDay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCSimulatedDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCSimulatedDay) ProtoMessage() {}
+
+func (x *GCSimulatedDay) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCSimulatedDay.ProtoReflect.Descriptor instead.
+func (*GCSimulatedDay) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GCSimulatedDay) GetDate() int64 {
+	if x != nil {
+		return x.Date
+	}
+	return 0
+}
+
+func (x *GCSimulatedDay) GetSaved() int32 {
+	if x != nil {
+		return x.Saved
+	}
+	return 0
+}
+
+func (x *GCSimulatedDay) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+func (x *GCSimulatedDay) GetSystem() int32 {
+	if x != nil {
+		return x.System
+	}
+	return 0
+}
+
+func (x *GCSimulatedDay) GetUsers() map[string]int32 {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GCSimulatedRemoval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Machine   string `protobuf:"bytes,1,opt,name=machine,proto3" json:"machine,omitempty"`
+	User      string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	State     string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	LastUsed  int64  `protobuf:"varint,4,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	RemovedOn int64  `protobuf:"varint,5,opt,name=removedOn,proto3" json:"removedOn,omitempty"`
+	Reason    string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *GCSimulatedRemoval) Reset() {
+	*x = GCSimulatedRemoval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCSimulatedRemoval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCSimulatedRemoval) ProtoMessage() {}
+
+func (x *GCSimulatedRemoval) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCSimulatedRemoval.ProtoReflect.Descriptor instead.
+func (*GCSimulatedRemoval) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GCSimulatedRemoval) GetMachine() string {
+	if x != nil {
+		return x.Machine
+	}
+	return ""
+}
+
+func (x *GCSimulatedRemoval) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GCSimulatedRemoval) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GCSimulatedRemoval) GetLastUsed() int64 {
+	if x != nil {
+		return x.LastUsed
+	}
+	return 0
+}
+
+func (x *GCSimulatedRemoval) GetRemovedOn() int64 {
+	if x != nil {
+		return x.RemovedOn
+	}
+	return 0
+}
+
+func (x *GCSimulatedRemoval) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type GCSimulatedBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User   string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	States int32  `protobuf:"varint,3,opt,name=states,proto3" json:"states,omitempty"`
+}
+
+func (x *GCSimulatedBucket) Reset() {
+	*x = GCSimulatedBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCSimulatedBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCSimulatedBucket) ProtoMessage() {}
+
+func (x *GCSimulatedBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCSimulatedBucket.ProtoReflect.Descriptor instead.
+func (*GCSimulatedBucket) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GCSimulatedBucket) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GCSimulatedBucket) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GCSimulatedBucket) GetStates() int32 {
+	if x != nil {
+		return x.States
+	}
+	return 0
+}
+
+type AuditRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Since int64  `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	User  string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+}
+
+func (x *AuditRequest) Reset() {
+	*x = AuditRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRequest) ProtoMessage() {}
+
+func (x *AuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRequest.ProtoReflect.Descriptor instead.
+func (*AuditRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AuditRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *AuditRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type AuditResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*AuditResponse_Log
+	//	*AuditResponse_Entry
+	Reply isAuditResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *AuditResponse) Reset() {
+	*x = AuditResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditResponse) ProtoMessage() {}
+
+func (x *AuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditResponse.ProtoReflect.Descriptor instead.
+func (*AuditResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{45}
+}
+
+func (m *AuditResponse) GetReply() isAuditResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *AuditResponse) GetLog() string {
+	if x, ok := x.GetReply().(*AuditResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *AuditResponse) GetEntry() *AuditEntry {
+	if x, ok := x.GetReply().(*AuditResponse_Entry); ok {
+		return x.Entry
+	}
+	return nil
+}
+
+type isAuditResponse_Reply interface {
+	isAuditResponse_Reply()
+}
+
+type AuditResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type AuditResponse_Entry struct {
+	Entry *AuditEntry `protobuf:"bytes,2,opt,name=entry,proto3,oneof"`
+}
+
+func (*AuditResponse_Log) isAuditResponse_Reply() {}
+
+func (*AuditResponse_Entry) isAuditResponse_Reply() {}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time      int64                 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	RequestId string                `protobuf:"bytes,2,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	Uid       uint32                `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Pid       int32                 `protobuf:"varint,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	Action    string                `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Operation string                `protobuf:"bytes,6,opt,name=operation,proto3" json:"operation,omitempty"`
+	Arguments map[string]string     `protobuf:"bytes,7,rep,name=arguments,proto3" json:"arguments,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Datasets  []*AuditDatasetChange `protobuf:"bytes,8,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	Success   bool                  `protobuf:"varint,9,opt,name=success,proto3" json:"success,omitempty"`
+	Error     string                `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AuditEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetArguments() map[string]string {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetDatasets() []*AuditDatasetChange {
+	if x != nil {
+		return x.Datasets
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AuditDatasetChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Change string `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+}
+
+func (x *AuditDatasetChange) Reset() {
+	*x = AuditDatasetChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditDatasetChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditDatasetChange) ProtoMessage() {}
+
+func (x *AuditDatasetChange) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditDatasetChange.ProtoReflect.Descriptor instead.
+func (*AuditDatasetChange) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AuditDatasetChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuditDatasetChange) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId string `protobuf:"bytes,1,opt,name=machineId,proto3" json:"machineId,omitempty"`
+	UserName  string `protobuf:"bytes,2,opt,name=userName,proto3" json:"userName,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SubscribeRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+type SubscribeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*SubscribeResponse_Log
+	//	*SubscribeResponse_Event
+	Reply isSubscribeResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *SubscribeResponse) Reset() {
+	*x = SubscribeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeResponse) ProtoMessage() {}
+
+func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{49}
+}
+
+func (m *SubscribeResponse) GetReply() isSubscribeResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *SubscribeResponse) GetLog() string {
+	if x, ok := x.GetReply().(*SubscribeResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *SubscribeResponse) GetEvent() *Event {
+	if x, ok := x.GetReply().(*SubscribeResponse_Event); ok {
+		return x.Event
+	}
+	return nil
+}
+
+type isSubscribeResponse_Reply interface {
+	isSubscribeResponse_Reply()
+}
+
+type SubscribeResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type SubscribeResponse_Event struct {
+	Event *Event `protobuf:"bytes,2,opt,name=event,proto3,oneof"`
+}
+
+func (*SubscribeResponse_Log) isSubscribeResponse_Reply() {}
+
+func (*SubscribeResponse_Event) isSubscribeResponse_Reply() {}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    Event_Type `protobuf:"varint,1,opt,name=type,proto3,enum=zsys.Event_Type" json:"type,omitempty"`
+	Time    int64      `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Machine string     `protobuf:"bytes,3,opt,name=machine,proto3" json:"machine,omitempty"`
+	User    string     `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	State   string     `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Event) GetType() Event_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Event_UNKNOWN
+}
+
+func (x *Event) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Event) GetMachine() string {
+	if x != nil {
+		return x.Machine
+	}
+	return ""
+}
+
+func (x *Event) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Event) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type MachineShowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineId  string `protobuf:"bytes,1,opt,name=machineId,proto3" json:"machineId,omitempty"`
+	Full       bool   `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	Structured bool   `protobuf:"varint,3,opt,name=structured,proto3" json:"structured,omitempty"`
+	Sort       string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+}
+
+func (x *MachineShowRequest) Reset() {
+	*x = MachineShowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineShowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineShowRequest) ProtoMessage() {}
+
+func (x *MachineShowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineShowRequest.ProtoReflect.Descriptor instead.
+func (*MachineShowRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *MachineShowRequest) GetMachineId() string {
+	if x != nil {
+		return x.MachineId
+	}
+	return ""
+}
+
+func (x *MachineShowRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *MachineShowRequest) GetStructured() bool {
+	if x != nil {
+		return x.Structured
+	}
+	return false
+}
+
+func (x *MachineShowRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type MachineShowResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*MachineShowResponse_Log
+	//	*MachineShowResponse_MachineInfo
+	//	*MachineShowResponse_Machine
+	Reply isMachineShowResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *MachineShowResponse) Reset() {
+	*x = MachineShowResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineShowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineShowResponse) ProtoMessage() {}
+
+func (x *MachineShowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineShowResponse.ProtoReflect.Descriptor instead.
+func (*MachineShowResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{52}
+}
+
+func (m *MachineShowResponse) GetReply() isMachineShowResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *MachineShowResponse) GetLog() string {
+	if x, ok := x.GetReply().(*MachineShowResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *MachineShowResponse) GetMachineInfo() string {
+	if x, ok := x.GetReply().(*MachineShowResponse_MachineInfo); ok {
+		return x.MachineInfo
+	}
+	return ""
+}
+
+func (x *MachineShowResponse) GetMachine() *Machine {
+	if x, ok := x.GetReply().(*MachineShowResponse_Machine); ok {
+		return x.Machine
+	}
+	return nil
+}
+
+type isMachineShowResponse_Reply interface {
+	isMachineShowResponse_Reply()
+}
+
+type MachineShowResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type MachineShowResponse_MachineInfo struct {
+	MachineInfo string `protobuf:"bytes,2,opt,name=machineInfo,proto3,oneof"`
+}
+
+type MachineShowResponse_Machine struct {
+	Machine *Machine `protobuf:"bytes,3,opt,name=machine,proto3,oneof"`
+}
+
+func (*MachineShowResponse_Log) isMachineShowResponse_Reply() {}
+
+func (*MachineShowResponse_MachineInfo) isMachineShowResponse_Reply() {}
+
+func (*MachineShowResponse_Machine) isMachineShowResponse_Reply() {}
+
+type MachineListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Structured bool `protobuf:"varint,1,opt,name=structured,proto3" json:"structured,omitempty"`
+}
+
+func (x *MachineListRequest) Reset() {
+	*x = MachineListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineListRequest) ProtoMessage() {}
+
+func (x *MachineListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineListRequest.ProtoReflect.Descriptor instead.
+func (*MachineListRequest) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *MachineListRequest) GetStructured() bool {
+	if x != nil {
+		return x.Structured
+	}
+	return false
+}
+
+type MachineListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Reply:
+	//	*MachineListResponse_Log
+	//	*MachineListResponse_MachineList
+	//	*MachineListResponse_Machines
+	Reply isMachineListResponse_Reply `protobuf_oneof:"reply"`
+}
+
+func (x *MachineListResponse) Reset() {
+	*x = MachineListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineListResponse) ProtoMessage() {}
+
+func (x *MachineListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineListResponse.ProtoReflect.Descriptor instead.
+func (*MachineListResponse) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{54}
+}
+
+func (m *MachineListResponse) GetReply() isMachineListResponse_Reply {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+func (x *MachineListResponse) GetLog() string {
+	if x, ok := x.GetReply().(*MachineListResponse_Log); ok {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *MachineListResponse) GetMachineList() string {
+	if x, ok := x.GetReply().(*MachineListResponse_MachineList); ok {
+		return x.MachineList
+	}
+	return ""
+}
+
+func (x *MachineListResponse) GetMachines() *MachineSummaries {
+	if x, ok := x.GetReply().(*MachineListResponse_Machines); ok {
+		return x.Machines
+	}
+	return nil
+}
+
+type isMachineListResponse_Reply interface {
+	isMachineListResponse_Reply()
+}
+
+type MachineListResponse_Log struct {
+	Log string `protobuf:"bytes,1,opt,name=log,proto3,oneof"`
+}
+
+type MachineListResponse_MachineList struct {
+	MachineList string `protobuf:"bytes,2,opt,name=machineList,proto3,oneof"`
+}
+
+type MachineListResponse_Machines struct {
+	Machines *MachineSummaries `protobuf:"bytes,3,opt,name=machines,proto3,oneof"`
+}
+
+func (*MachineListResponse_Log) isMachineListResponse_Reply() {}
+
+func (*MachineListResponse_MachineList) isMachineListResponse_Reply() {}
+
+func (*MachineListResponse_Machines) isMachineListResponse_Reply() {}
+
+type MachineSummaries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Machines []*MachineSummary `protobuf:"bytes,1,rep,name=machines,proto3" json:"machines,omitempty"`
+}
+
+func (x *MachineSummaries) Reset() {
+	*x = MachineSummaries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineSummaries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineSummaries) ProtoMessage() {}
+
+func (x *MachineSummaries) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineSummaries.ProtoReflect.Descriptor instead.
+func (*MachineSummaries) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *MachineSummaries) GetMachines() []*MachineSummary {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+type MachineSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsZsys   bool   `protobuf:"varint,2,opt,name=isZsys,proto3" json:"isZsys,omitempty"`
+	Current  bool   `protobuf:"varint,3,opt,name=current,proto3" json:"current,omitempty"`
+	LastUsed int64  `protobuf:"varint,4,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+}
+
+func (x *MachineSummary) Reset() {
+	*x = MachineSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MachineSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MachineSummary) ProtoMessage() {}
+
+func (x *MachineSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MachineSummary.ProtoReflect.Descriptor instead.
+func (*MachineSummary) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *MachineSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MachineSummary) GetIsZsys() bool {
+	if x != nil {
+		return x.IsZsys
+	}
+	return false
+}
+
+func (x *MachineSummary) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *MachineSummary) GetLastUsed() int64 {
+	if x != nil {
+		return x.LastUsed
+	}
+	return 0
+}
+
+type Machine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsZsys             bool                   `protobuf:"varint,2,opt,name=isZsys,proto3" json:"isZsys,omitempty"`
+	Current            bool                   `protobuf:"varint,3,opt,name=current,proto3" json:"current,omitempty"`
+	State              *State                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	History            []*State               `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+	PersistentDatasets []*Dataset             `protobuf:"bytes,6,rep,name=persistentDatasets,proto3" json:"persistentDatasets,omitempty"`
+	Users              map[string]*UserStates `protobuf:"bytes,7,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Machine) Reset() {
+	*x = Machine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Machine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Machine) ProtoMessage() {}
+
+func (x *Machine) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Machine.ProtoReflect.Descriptor instead.
+func (*Machine) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Machine) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Machine) GetIsZsys() bool {
+	if x != nil {
+		return x.IsZsys
+	}
+	return false
+}
+
+func (x *Machine) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *Machine) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Machine) GetHistory() []*State {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Machine) GetPersistentDatasets() []*Dataset {
+	if x != nil {
+		return x.PersistentDatasets
+	}
+	return nil
+}
+
+func (x *Machine) GetUsers() map[string]*UserStates {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type UserStates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	States []*State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+}
+
+func (x *UserStates) Reset() {
+	*x = UserStates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserStates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStates) ProtoMessage() {}
+
+func (x *UserStates) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStates.ProtoReflect.Descriptor instead.
+func (*UserStates) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *UserStates) GetStates() []*State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type State struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LastUsed         int64             `protobuf:"varint,2,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	Current          bool              `protobuf:"varint,3,opt,name=current,proto3" json:"current,omitempty"`
+	LastBootedKernel string            `protobuf:"bytes,4,opt,name=lastBootedKernel,proto3" json:"lastBootedKernel,omitempty"`
+	Datasets         []*Dataset        `protobuf:"bytes,5,rep,name=datasets,proto3" json:"datasets,omitempty"`
+	Users            map[string]*State `protobuf:"bytes,6,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Space            *Space            `protobuf:"bytes,7,opt,name=space,proto3" json:"space,omitempty"`
+	Pinned           bool              `protobuf:"varint,8,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Description      string            `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	Labels           map[string]string `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Pools            []*PoolMembership `protobuf:"bytes,11,rep,name=pools,proto3" json:"pools,omitempty"`
+}
+
+func (x *State) Reset() {
+	*x = State{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*State) ProtoMessage() {}
+
+func (x *State) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use State.ProtoReflect.Descriptor instead.
+func (*State) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *State) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *State) GetLastUsed() int64 {
+	if x != nil {
+		return x.LastUsed
+	}
+	return 0
+}
+
+func (x *State) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *State) GetLastBootedKernel() string {
+	if x != nil {
+		return x.LastBootedKernel
+	}
+	return ""
+}
+
+func (x *State) GetDatasets() []*Dataset {
+	if x != nil {
+		return x.Datasets
+	}
+	return nil
+}
+
+func (x *State) GetUsers() map[string]*State {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *State) GetSpace() *Space {
+	if x != nil {
+		return x.Space
+	}
+	return nil
+}
+
+func (x *State) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *State) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *State) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *State) GetPools() []*PoolMembership {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+type PoolMembership struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Roles []string `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+}
+
+func (x *PoolMembership) Reset() {
+	*x = PoolMembership{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolMembership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolMembership) ProtoMessage() {}
+
+func (x *PoolMembership) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolMembership.ProtoReflect.Descriptor instead.
+func (*PoolMembership) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *PoolMembership) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PoolMembership) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type Space struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Used        uint64 `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Referenced  uint64 `protobuf:"varint,2,opt,name=referenced,proto3" json:"referenced,omitempty"`
+	Unique      uint64 `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`
+	Written     uint64 `protobuf:"varint,4,opt,name=written,proto3" json:"written,omitempty"`
+	Reclaimable uint64 `protobuf:"varint,5,opt,name=reclaimable,proto3" json:"reclaimable,omitempty"`
+}
+
+func (x *Space) Reset() {
+	*x = Space{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Space) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Space) ProtoMessage() {}
+
+func (x *Space) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Space.ProtoReflect.Descriptor instead.
+func (*Space) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *Space) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *Space) GetReferenced() uint64 {
+	if x != nil {
+		return x.Referenced
+	}
+	return 0
+}
+
+func (x *Space) GetUnique() uint64 {
+	if x != nil {
+		return x.Unique
+	}
+	return 0
+}
+
+func (x *Space) GetWritten() uint64 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+func (x *Space) GetReclaimable() uint64 {
+	if x != nil {
+		return x.Reclaimable
+	}
+	return 0
+}
+
+type Dataset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name             string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IsSnapshot       bool              `protobuf:"varint,2,opt,name=isSnapshot,proto3" json:"isSnapshot,omitempty"`
+	Mountpoint       string            `protobuf:"bytes,3,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	CanMount         string            `protobuf:"bytes,4,opt,name=canMount,proto3" json:"canMount,omitempty"`
+	Mounted          bool              `protobuf:"varint,5,opt,name=mounted,proto3" json:"mounted,omitempty"`
+	Bootfs           bool              `protobuf:"varint,6,opt,name=bootfs,proto3" json:"bootfs,omitempty"`
+	LastUsed         int64             `protobuf:"varint,7,opt,name=lastUsed,proto3" json:"lastUsed,omitempty"`
+	LastBootedKernel string            `protobuf:"bytes,8,opt,name=lastBootedKernel,proto3" json:"lastBootedKernel,omitempty"`
+	BootfsDatasets   string            `protobuf:"bytes,9,opt,name=bootfsDatasets,proto3" json:"bootfsDatasets,omitempty"`
+	Origin           string            `protobuf:"bytes,10,opt,name=origin,proto3" json:"origin,omitempty"`
+	PendingRevert    string            `protobuf:"bytes,11,opt,name=pendingRevert,proto3" json:"pendingRevert,omitempty"`
+	Used             uint64            `protobuf:"varint,12,opt,name=used,proto3" json:"used,omitempty"`
+	Referenced       uint64            `protobuf:"varint,13,opt,name=referenced,proto3" json:"referenced,omitempty"`
+	UsedBySnapshots  uint64            `protobuf:"varint,14,opt,name=usedBySnapshots,proto3" json:"usedBySnapshots,omitempty"`
+	Written          uint64            `protobuf:"varint,15,opt,name=written,proto3" json:"written,omitempty"`
+	Pinned           bool              `protobuf:"varint,16,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Description      string            `protobuf:"bytes,17,opt,name=description,proto3" json:"description,omitempty"`
+	Labels           map[string]string `protobuf:"bytes,18,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Quota            uint64            `protobuf:"varint,19,opt,name=quota,proto3" json:"quota,omitempty"`
+	Reservation      uint64            `protobuf:"varint,20,opt,name=reservation,proto3" json:"reservation,omitempty"`
+}
+
+func (x *Dataset) Reset() {
+	*x = Dataset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_zsys_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dataset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dataset) ProtoMessage() {}
+
+func (x *Dataset) ProtoReflect() protoreflect.Message {
+	mi := &file_zsys_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dataset.ProtoReflect.Descriptor instead.
+func (*Dataset) Descriptor() ([]byte, []int) {
+	return file_zsys_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *Dataset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dataset) GetIsSnapshot() bool {
+	if x != nil {
+		return x.IsSnapshot
+	}
+	return false
+}
+
+func (x *Dataset) GetMountpoint() string {
+	if x != nil {
+		return x.Mountpoint
+	}
+	return ""
+}
+
+func (x *Dataset) GetCanMount() string {
+	if x != nil {
+		return x.CanMount
+	}
+	return ""
+}
+
+func (x *Dataset) GetMounted() bool {
+	if x != nil {
+		return x.Mounted
+	}
+	return false
+}
+
+func (x *Dataset) GetBootfs() bool {
+	if x != nil {
+		return x.Bootfs
+	}
+	return false
+}
+
+func (x *Dataset) GetLastUsed() int64 {
+	if x != nil {
+		return x.LastUsed
+	}
+	return 0
+}
+
+func (x *Dataset) GetLastBootedKernel() string {
+	if x != nil {
+		return x.LastBootedKernel
+	}
+	return ""
+}
+
+func (x *Dataset) GetBootfsDatasets() string {
+	if x != nil {
+		return x.BootfsDatasets
+	}
+	return ""
+}
+
+func (x *Dataset) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Dataset) GetPendingRevert() string {
+	if x != nil {
+		return x.PendingRevert
+	}
+	return ""
+}
+
+func (x *Dataset) GetUsed() uint64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *Dataset) GetReferenced() uint64 {
+	if x != nil {
+		return x.Referenced
+	}
+	return 0
+}
+
+func (x *Dataset) GetUsedBySnapshots() uint64 {
+	if x != nil {
+		return x.UsedBySnapshots
+	}
+	return 0
+}
+
+func (x *Dataset) GetWritten() uint64 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+func (x *Dataset) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Dataset) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Dataset) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Dataset) GetQuota() uint64 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+func (x *Dataset) GetReservation() uint64 {
+	if x != nil {
+		return x.Reservation
+	}
+	return 0
+}
+
+var File_zsys_proto protoreflect.FileDescriptor
+
+var file_zsys_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x7a, 0x73,
+	0x79, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x0a, 0x0b, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x22, 0x4a, 0x0a, 0x0f,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
+	0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42,
+	0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xec, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x6d, 0x65, 0x70, 0x61,
+	0x74, 0x68, 0x12, 0x46, 0x0a, 0x0a, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61,
+	0x73, 0x73, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a,
+	0x70, 0x61, 0x73, 0x73, 0x70, 0x68, 0x72, 0x61, 0x73, 0x65, 0x22, 0x3b, 0x0a, 0x0a, 0x45, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41,
+	0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x4e, 0x43, 0x52, 0x59, 0x50, 0x54,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4e, 0x43, 0x52,
+	0x59, 0x50, 0x54, 0x45, 0x44, 0x10, 0x02, 0x22, 0x4b, 0x0a, 0x15, 0x55, 0x6e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x70, 0x68, 0x72, 0x61,
+	0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x73, 0x73, 0x70, 0x68,
+	0x72, 0x61, 0x73, 0x65, 0x22, 0x61, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x51,
+	0x75, 0x6f, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x71, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4b, 0x0a, 0x1b, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65,
+	0x77, 0x48, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77,
+	0x48, 0x6f, 0x6d, 0x65, 0x22, 0x4b, 0x0a, 0x15, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61,
+	0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x48, 0x6f, 0x6d,
+	0x65, 0x22, 0x4e, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x4d, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x2b, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65,
+	0x6e, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74,
+	0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x6f, 0x22, 0x99, 0x02,
+	0x0a, 0x16, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x61, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x61, 0x76, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39,
+	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xed, 0x01, 0x0a, 0x14, 0x53, 0x61,
+	0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39,
+	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x56, 0x0a, 0x17, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x1e, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x66, 0x0a, 0x18, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x16, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66,
+	0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x72, 0x75, 0x6e, 0x22, 0x60, 0x0a, 0x18,
+	0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74,
+	0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x22, 0x52,
+	0x0a, 0x16, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x69, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x84, 0x01,
+	0x0a, 0x10, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x69, 0x74, 0x68, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x77, 0x69, 0x74, 0x68, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x22, 0x59, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x66,
+	0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x27, 0x0a,
+	0x04, 0x64, 0x69, 0x66, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x44, 0x69, 0x66, 0x66, 0x48, 0x00,
+	0x52, 0x04, 0x64, 0x69, 0x66, 0x66, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x4b, 0x0a, 0x0f, 0x50, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x4d, 0x0a, 0x11,
+	0x55, 0x6e, 0x70, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x68, 0x0a, 0x12, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6e,
+	0x65, 0x77, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65,
+	0x77, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xc9, 0x01, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x59, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x26, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x2d, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x66, 0x0a, 0x12, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x22, 0x4a, 0x0a, 0x12, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22,
+	0x5d, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x52,
+	0x0a, 0x0a, 0x50, 0x61, 0x74, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x61,
+	0x74, 0x68, 0x22, 0x4b, 0x0a, 0x12, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x18, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x39, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e,
+	0x67, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6c, 0x6f,
+	0x67, 0x67, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x3e, 0x0a, 0x0c, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x0d, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c,
+	0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12,
+	0x16, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x35, 0x0a, 0x09, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x61, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x59, 0x0a, 0x0a, 0x47, 0x43, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x2e, 0x0a, 0x08, 0x64, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x47, 0x43, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0xb4, 0x01, 0x0a, 0x0a, 0x47, 0x43, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3b, 0x0a, 0x11, 0x47, 0x43, 0x53,
+	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x61,
+	0x79, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x67, 0x0a, 0x12, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03,
+	0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67,
+	0x12, 0x34, 0x0a, 0x0a, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x53, 0x69,
+	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0xa1, 0x01, 0x0a, 0x0c, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x28, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65,
+	0x64, 0x44, 0x61, 0x79, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x08, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x73,
+	0x12, 0x31, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x0e, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x65, 0x64, 0x44, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x61,
+	0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x61, 0x76, 0x65, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x12, 0x35, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x79, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xaa, 0x01, 0x0a, 0x12, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61,
+	0x74, 0x65, 0x64, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0x57, 0x0a, 0x11, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x42,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x38, 0x0a, 0x0c, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x22, 0x56, 0x0a, 0x0d, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x48, 0x00, 0x52, 0x05, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0xfb, 0x02, 0x0a, 0x0a,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x41, 0x72, 0x67, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x3c, 0x0a, 0x0e, 0x41,
+	0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x12, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x4c, 0x0a, 0x10, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x55, 0x0a, 0x11, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c,
+	0x6f, 0x67, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0xba, 0x02, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0xb2, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10,
+	0x01, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x56,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x47, 0x43, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53,
+	0x48, 0x45, 0x44, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x4f, 0x4f, 0x54, 0x5f, 0x50, 0x52,
+	0x45, 0x50, 0x41, 0x52, 0x45, 0x44, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x4f, 0x4f, 0x54,
+	0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10,
+	0x55, 0x53, 0x45, 0x52, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x06, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x44, 0x49, 0x53, 0x53, 0x4f,
+	0x43, 0x49, 0x41, 0x54, 0x45, 0x44, 0x10, 0x07, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x4e, 0x46,
+	0x49, 0x47, 0x5f, 0x52, 0x45, 0x4c, 0x4f, 0x41, 0x44, 0x45, 0x44, 0x10, 0x08, 0x22, 0x7a, 0x0a,
+	0x12, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x66, 0x75, 0x6c, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x75, 0x72, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x22, 0x81, 0x01, 0x0a, 0x13, 0x4d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x22, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61,
+	0x63, 0x68, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x29, 0x0a, 0x07, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x34, 0x0a,
+	0x12, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x64, 0x22, 0x8c, 0x01, 0x0a, 0x13, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x6c,
+	0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12,
+	0x22, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x48, 0x00, 0x52,
+	0x08, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x44, 0x0a, 0x10, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x08,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x0e, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73,
+	0x5a, 0x73, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x5a, 0x73,
+	0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x22, 0xd0, 0x02, 0x0a, 0x07, 0x4d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x5a, 0x73, 0x79, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x73, 0x5a, 0x73, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x3d, 0x0a, 0x12, 0x70, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x12, 0x70, 0x65, 0x72,
+	0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x12,
+	0x2e, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x1a,
+	0x4a, 0x0a, 0x0a, 0x55, 0x73, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x31, 0x0a, 0x0a, 0x55,
+	0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x8e,
+	0x04, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74,
+	0x55, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74,
+	0x55, 0x73, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x2a,
+	0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x72, 0x6e,
+	0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6f,
+	0x6f, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x29, 0x0a, 0x08, 0x64, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x08, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52,
+	0x05, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2f, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x2a, 0x0a, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x05, 0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x1a, 0x45, 0x0a,
+	0x0a, 0x55, 0x73, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x3a, 0x0a, 0x0e, 0x50, 0x6f, 0x6f, 0x6c, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x05,
+	0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69,
+	0x71, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x75, 0x6e, 0x69, 0x71, 0x75,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x72, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xb1, 0x05,
+	0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x73, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x69, 0x73, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c,
+	0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x42,
+	0x6f, 0x6f, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x72,
+	0x6e, 0x65, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x62, 0x6f, 0x6f,
+	0x74, 0x66, 0x73, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x76, 0x65, 0x72, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x12, 0x28, 0x0a,
+	0x0f, 0x75, 0x73, 0x65, 0x64, 0x42, 0x79, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x75, 0x73, 0x65, 0x64, 0x42, 0x79, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74,
+	0x65, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x71,
+	0x75, 0x6f, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x32, 0xb3, 0x12, 0x0a, 0x04, 0x5a, 0x73, 0x79, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x15, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x4e, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55,
+	0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x21, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x4f, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x42, 0x0a, 0x0e, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x73, 0x6f, 0x63, 0x69,
+	0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65,
+	0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53,
+	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x70, 0x61,
+	0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x70, 0x61,
+	0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x12, 0x35, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x0b,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x12, 0x1b, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x0e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x0b, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x50, 0x0a, 0x0f, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61,
+	0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x4c, 0x0a, 0x0d, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1a, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x55, 0x73,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x61, 0x76, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x48, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x6d,
+	0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x48, 0x0a, 0x11, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x76, 0x65,
+	0x72, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x76,
+	0x65, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x46, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x44, 0x69, 0x66, 0x66, 0x12, 0x16, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x7a,
+	0x73, 0x79, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x36, 0x0a, 0x08, 0x50, 0x69, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x50, 0x69, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x3a, 0x0a, 0x0a, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0b, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x0a, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0b,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x7a, 0x73,
+	0x79, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x0b, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0a, 0x44, 0x75, 0x6d, 0x70,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x2e, 0x0a, 0x0a, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x0b, 0x2e,
+	0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x3e, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x2b, 0x0a, 0x07, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x05,
+	0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x12, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73,
+	0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x12, 0x2a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x06,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x29, 0x0a, 0x02, 0x47, 0x43, 0x12, 0x0f,
+	0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x0a, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74,
+	0x65, 0x12, 0x17, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x47, 0x43, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2f, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x0b, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x11, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x05, 0x41, 0x75, 0x64, 0x69, 0x74,
+	0x12, 0x12, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x09, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x16, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x12, 0x18, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68,
+	0x69, 0x6e, 0x65, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x44, 0x0a, 0x0b, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x18, 0x2e, 0x7a, 0x73, 0x79, 0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x7a, 0x73, 0x79,
+	0x73, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_zsys_proto_rawDescOnce sync.Once
+	file_zsys_proto_rawDescData = file_zsys_proto_rawDesc
+)
+
+func file_zsys_proto_rawDescGZIP() []byte {
+	file_zsys_proto_rawDescOnce.Do(func() {
+		file_zsys_proto_rawDescData = protoimpl.X.CompressGZIP(file_zsys_proto_rawDescData)
+	})
+	return file_zsys_proto_rawDescData
+}
+
+var file_zsys_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_zsys_proto_msgTypes = make([]protoimpl.MessageInfo, 72)
+var file_zsys_proto_goTypes = []interface{}{
+	(CreateUserDataRequest_Encryption)(0), // 0: zsys.CreateUserDataRequest.Encryption
+	(Event_Type)(0),                       // 1: zsys.Event.Type
+	(*Empty)(nil),                         // 2: zsys.Empty
+	(*LogResponse)(nil),                   // 3: zsys.LogResponse
+	(*VersionResponse)(nil),               // 4: zsys.VersionResponse
+	(*CreateUserDataRequest)(nil),         // 5: zsys.CreateUserDataRequest
+	(*UnlockUserDataRequest)(nil),         // 6: zsys.UnlockUserDataRequest
+	(*SetUserQuotaRequest)(nil),           // 7: zsys.SetUserQuotaRequest
+	(*ChangeHomeOnUserDataRequest)(nil),   // 8: zsys.ChangeHomeOnUserDataRequest
+	(*DissociateUserRequest)(nil),         // 9: zsys.DissociateUserRequest
+	(*PrepareBootResponse)(nil),           // 10: zsys.PrepareBootResponse
+	(*CommitBootResponse)(nil),            // 11: zsys.CommitBootResponse
+	(*UpdateBootMenuRequest)(nil),         // 12: zsys.UpdateBootMenuRequest
+	(*SaveSystemStateRequest)(nil),        // 13: zsys.SaveSystemStateRequest
+	(*SaveUserStateRequest)(nil),          // 14: zsys.SaveUserStateRequest
+	(*CreateSaveStateResponse)(nil),       // 15: zsys.CreateSaveStateResponse
+	(*RemoveSystemStateRequest)(nil),      // 16: zsys.RemoveSystemStateRequest
+	(*RemoveUserStateRequest)(nil),        // 17: zsys.RemoveUserStateRequest
+	(*RevertSystemStateRequest)(nil),      // 18: zsys.RevertSystemStateRequest
+	(*RevertUserStateRequest)(nil),        // 19: zsys.RevertUserStateRequest
+	(*RestoreUserStateRequest)(nil),       // 20: zsys.RestoreUserStateRequest
+	(*StateDiffRequest)(nil),              // 21: zsys.StateDiffRequest
+	(*StateDiffResponse)(nil),             // 22: zsys.StateDiffResponse
+	(*PinStateRequest)(nil),               // 23: zsys.PinStateRequest
+	(*UnpinStateRequest)(nil),             // 24: zsys.UnpinStateRequest
+	(*RenameStateRequest)(nil),            // 25: zsys.RenameStateRequest
+	(*ListStatesRequest)(nil),             // 26: zsys.ListStatesRequest
+	(*ListStatesResponse)(nil),            // 27: zsys.ListStatesResponse
+	(*States)(nil),                        // 28: zsys.States
+	(*ExportStateRequest)(nil),            // 29: zsys.ExportStateRequest
+	(*ImportStateRequest)(nil),            // 30: zsys.ImportStateRequest
+	(*DatasetDiff)(nil),                   // 31: zsys.DatasetDiff
+	(*PathChange)(nil),                    // 32: zsys.PathChange
+	(*DumpStatesResponse)(nil),            // 33: zsys.DumpStatesResponse
+	(*LoggingLevelRequest)(nil),           // 34: zsys.LoggingLevelRequest
+	(*TraceRequest)(nil),                  // 35: zsys.TraceRequest
+	(*TraceResponse)(nil),                 // 36: zsys.TraceResponse
+	(*GCRequest)(nil),                     // 37: zsys.GCRequest
+	(*GCResponse)(nil),                    // 38: zsys.GCResponse
+	(*GCDecision)(nil),                    // 39: zsys.GCDecision
+	(*GCSimulateRequest)(nil),             // 40: zsys.GCSimulateRequest
+	(*GCSimulateResponse)(nil),            // 41: zsys.GCSimulateResponse
+	(*GCSimulation)(nil),                  // 42: zsys.GCSimulation
+	(*GCSimulatedDay)(nil),                // 43: zsys.GCSimulatedDay
+	(*GCSimulatedRemoval)(nil),            // 44: zsys.GCSimulatedRemoval
+	(*GCSimulatedBucket)(nil),             // 45: zsys.GCSimulatedBucket
+	(*AuditRequest)(nil),                  // 46: zsys.AuditRequest
+	(*AuditResponse)(nil),                 // 47: zsys.AuditResponse
+	(*AuditEntry)(nil),                    // 48: zsys.AuditEntry
+	(*AuditDatasetChange)(nil),            // 49: zsys.AuditDatasetChange
+	(*SubscribeRequest)(nil),              // 50: zsys.SubscribeRequest
+	(*SubscribeResponse)(nil),             // 51: zsys.SubscribeResponse
+	(*Event)(nil),                         // 52: zsys.Event
+	(*MachineShowRequest)(nil),            // 53: zsys.MachineShowRequest
+	(*MachineShowResponse)(nil),           // 54: zsys.MachineShowResponse
+	(*MachineListRequest)(nil),            // 55: zsys.MachineListRequest
+	(*MachineListResponse)(nil),           // 56: zsys.MachineListResponse
+	(*MachineSummaries)(nil),              // 57: zsys.MachineSummaries
+	(*MachineSummary)(nil),                // 58: zsys.MachineSummary
+	(*Machine)(nil),                       // 59: zsys.Machine
+	(*UserStates)(nil),                    // 60: zsys.UserStates
+	(*State)(nil),                         // 61: zsys.State
+	(*PoolMembership)(nil),                // 62: zsys.PoolMembership
+	(*Space)(nil),                         // 63: zsys.Space
+	(*Dataset)(nil),                       // 64: zsys.Dataset
+	nil,                                   // 65: zsys.SaveSystemStateRequest.LabelsEntry
+	nil,                                   // 66: zsys.SaveUserStateRequest.LabelsEntry
+	nil,                                   // 67: zsys.ListStatesRequest.LabelsEntry
+	nil,                                   // 68: zsys.GCSimulatedDay.UsersEntry
+	nil,                                   // 69: zsys.AuditEntry.ArgumentsEntry
+	nil,                                   // 70: zsys.Machine.UsersEntry
+	nil,                                   // 71: zsys.State.UsersEntry
+	nil,                                   // 72: zsys.State.LabelsEntry
+	nil,                                   // 73: zsys.Dataset.LabelsEntry
+}
+var file_zsys_proto_depIdxs = []int32{
+	0,  // 0: zsys.CreateUserDataRequest.encryption:type_name -> zsys.CreateUserDataRequest.Encryption
+	65, // 1: zsys.SaveSystemStateRequest.labels:type_name -> zsys.SaveSystemStateRequest.LabelsEntry
+	66, // 2: zsys.SaveUserStateRequest.labels:type_name -> zsys.SaveUserStateRequest.LabelsEntry
+	31, // 3: zsys.StateDiffResponse.diff:type_name -> zsys.DatasetDiff
+	67, // 4: zsys.ListStatesRequest.labels:type_name -> zsys.ListStatesRequest.LabelsEntry
+	28, // 5: zsys.ListStatesResponse.states:type_name -> zsys.States
+	61, // 6: zsys.States.states:type_name -> zsys.State
+	32, // 7: zsys.DatasetDiff.changes:type_name -> zsys.PathChange
+	39, // 8: zsys.GCResponse.decision:type_name -> zsys.GCDecision
+	42, // 9: zsys.GCSimulateResponse.simulation:type_name -> zsys.GCSimulation
+	43, // 10: zsys.GCSimulation.days:type_name -> zsys.GCSimulatedDay
+	44, // 11: zsys.GCSimulation.removals:type_name -> zsys.GCSimulatedRemoval
+	45, // 12: zsys.GCSimulation.buckets:type_name -> zsys.GCSimulatedBucket
+	68, // 13: zsys.GCSimulatedDay.users:type_name -> zsys.GCSimulatedDay.UsersEntry
+	48, // 14: zsys.AuditResponse.entry:type_name -> zsys.AuditEntry
+	69, // 15: zsys.AuditEntry.arguments:type_name -> zsys.AuditEntry.ArgumentsEntry
+	49, // 16: zsys.AuditEntry.datasets:type_name -> zsys.AuditDatasetChange
+	52, // 17: zsys.SubscribeResponse.event:type_name -> zsys.Event
+	1,  // 18: zsys.Event.type:type_name -> zsys.Event.Type
+	59, // 19: zsys.MachineShowResponse.machine:type_name -> zsys.Machine
+	57, // 20: zsys.MachineListResponse.machines:type_name -> zsys.MachineSummaries
+	58, // 21: zsys.MachineSummaries.machines:type_name -> zsys.MachineSummary
+	61, // 22: zsys.Machine.state:type_name -> zsys.State
+	61, // 23: zsys.Machine.history:type_name -> zsys.State
+	64, // 24: zsys.Machine.persistentDatasets:type_name -> zsys.Dataset
+	70, // 25: zsys.Machine.users:type_name -> zsys.Machine.UsersEntry
+	61, // 26: zsys.UserStates.states:type_name -> zsys.State
+	64, // 27: zsys.State.datasets:type_name -> zsys.Dataset
+	71, // 28: zsys.State.users:type_name -> zsys.State.UsersEntry
+	63, // 29: zsys.State.space:type_name -> zsys.Space
+	72, // 30: zsys.State.labels:type_name -> zsys.State.LabelsEntry
+	62, // 31: zsys.State.pools:type_name -> zsys.PoolMembership
+	73, // 32: zsys.Dataset.labels:type_name -> zsys.Dataset.LabelsEntry
+	60, // 33: zsys.Machine.UsersEntry.value:type_name -> zsys.UserStates
+	61, // 34: zsys.State.UsersEntry.value:type_name -> zsys.State
+	2,  // 35: zsys.Zsys.Version:input_type -> zsys.Empty
+	5,  // 36: zsys.Zsys.CreateUserData:input_type -> zsys.CreateUserDataRequest
+	8,  // 37: zsys.Zsys.ChangeHomeOnUserData:input_type -> zsys.ChangeHomeOnUserDataRequest
+	9,  // 38: zsys.Zsys.DissociateUser:input_type -> zsys.DissociateUserRequest
+	6,  // 39: zsys.Zsys.UnlockUserData:input_type -> zsys.UnlockUserDataRequest
+	7,  // 40: zsys.Zsys.SetUserQuota:input_type -> zsys.SetUserQuotaRequest
+	2,  // 41: zsys.Zsys.PrepareBoot:input_type -> zsys.Empty
+	2,  // 42: zsys.Zsys.CommitBoot:input_type -> zsys.Empty
+	12, // 43: zsys.Zsys.UpdateBootMenu:input_type -> zsys.UpdateBootMenuRequest
+	2,  // 44: zsys.Zsys.UpdateLastUsed:input_type -> zsys.Empty
+	13, // 45: zsys.Zsys.SaveSystemState:input_type -> zsys.SaveSystemStateRequest
+	14, // 46: zsys.Zsys.SaveUserState:input_type -> zsys.SaveUserStateRequest
+	16, // 47: zsys.Zsys.RemoveSystemState:input_type -> zsys.RemoveSystemStateRequest
+	17, // 48: zsys.Zsys.RemoveUserState:input_type -> zsys.RemoveUserStateRequest
+	18, // 49: zsys.Zsys.RevertSystemState:input_type -> zsys.RevertSystemStateRequest
+	19, // 50: zsys.Zsys.RevertUserState:input_type -> zsys.RevertUserStateRequest
+	20, // 51: zsys.Zsys.RestoreUserState:input_type -> zsys.RestoreUserStateRequest
+	21, // 52: zsys.Zsys.StateDiff:input_type -> zsys.StateDiffRequest
+	23, // 53: zsys.Zsys.PinState:input_type -> zsys.PinStateRequest
+	24, // 54: zsys.Zsys.UnpinState:input_type -> zsys.UnpinStateRequest
+	25, // 55: zsys.Zsys.RenameState:input_type -> zsys.RenameStateRequest
+	26, // 56: zsys.Zsys.ListStates:input_type -> zsys.ListStatesRequest
+	29, // 57: zsys.Zsys.ExportState:input_type -> zsys.ExportStateRequest
+	30, // 58: zsys.Zsys.ImportState:input_type -> zsys.ImportStateRequest
+	2,  // 59: zsys.Zsys.DumpStates:input_type -> zsys.Empty
+	2,  // 60: zsys.Zsys.DaemonStop:input_type -> zsys.Empty
+	34, // 61: zsys.Zsys.LoggingLevel:input_type -> zsys.LoggingLevelRequest
+	2,  // 62: zsys.Zsys.Refresh:input_type -> zsys.Empty
+	35, // 63: zsys.Zsys.Trace:input_type -> zsys.TraceRequest
+	2,  // 64: zsys.Zsys.Status:input_type -> zsys.Empty
+	2,  // 65: zsys.Zsys.Reload:input_type -> zsys.Empty
+	37, // 66: zsys.Zsys.GC:input_type -> zsys.GCRequest
+	40, // 67: zsys.Zsys.GCSimulate:input_type -> zsys.GCSimulateRequest
+	2,  // 68: zsys.Zsys.RunSchedule:input_type -> zsys.Empty
+	46, // 69: zsys.Zsys.Audit:input_type -> zsys.AuditRequest
+	50, // 70: zsys.Zsys.Subscribe:input_type -> zsys.SubscribeRequest
+	53, // 71: zsys.Zsys.MachineShow:input_type -> zsys.MachineShowRequest
+	55, // 72: zsys.Zsys.MachineList:input_type -> zsys.MachineListRequest
+	4,  // 73: zsys.Zsys.Version:output_type -> zsys.VersionResponse
+	3,  // 74: zsys.Zsys.CreateUserData:output_type -> zsys.LogResponse
+	3,  // 75: zsys.Zsys.ChangeHomeOnUserData:output_type -> zsys.LogResponse
+	3,  // 76: zsys.Zsys.DissociateUser:output_type -> zsys.LogResponse
+	3,  // 77: zsys.Zsys.UnlockUserData:output_type -> zsys.LogResponse
+	3,  // 78: zsys.Zsys.SetUserQuota:output_type -> zsys.LogResponse
+	10, // 79: zsys.Zsys.PrepareBoot:output_type -> zsys.PrepareBootResponse
+	11, // 80: zsys.Zsys.CommitBoot:output_type -> zsys.CommitBootResponse
+	3,  // 81: zsys.Zsys.UpdateBootMenu:output_type -> zsys.LogResponse
+	3,  // 82: zsys.Zsys.UpdateLastUsed:output_type -> zsys.LogResponse
+	15, // 83: zsys.Zsys.SaveSystemState:output_type -> zsys.CreateSaveStateResponse
+	15, // 84: zsys.Zsys.SaveUserState:output_type -> zsys.CreateSaveStateResponse
+	3,  // 85: zsys.Zsys.RemoveSystemState:output_type -> zsys.LogResponse
+	3,  // 86: zsys.Zsys.RemoveUserState:output_type -> zsys.LogResponse
+	3,  // 87: zsys.Zsys.RevertSystemState:output_type -> zsys.LogResponse
+	3,  // 88: zsys.Zsys.RevertUserState:output_type -> zsys.LogResponse
+	3,  // 89: zsys.Zsys.RestoreUserState:output_type -> zsys.LogResponse
+	22, // 90: zsys.Zsys.StateDiff:output_type -> zsys.StateDiffResponse
+	3,  // 91: zsys.Zsys.PinState:output_type -> zsys.LogResponse
+	3,  // 92: zsys.Zsys.UnpinState:output_type -> zsys.LogResponse
+	3,  // 93: zsys.Zsys.RenameState:output_type -> zsys.LogResponse
+	27, // 94: zsys.Zsys.ListStates:output_type -> zsys.ListStatesResponse
+	3,  // 95: zsys.Zsys.ExportState:output_type -> zsys.LogResponse
+	3,  // 96: zsys.Zsys.ImportState:output_type -> zsys.LogResponse
+	33, // 97: zsys.Zsys.DumpStates:output_type -> zsys.DumpStatesResponse
+	3,  // 98: zsys.Zsys.DaemonStop:output_type -> zsys.LogResponse
+	3,  // 99: zsys.Zsys.LoggingLevel:output_type -> zsys.LogResponse
+	3,  // 100: zsys.Zsys.Refresh:output_type -> zsys.LogResponse
+	36, // 101: zsys.Zsys.Trace:output_type -> zsys.TraceResponse
+	3,  // 102: zsys.Zsys.Status:output_type -> zsys.LogResponse
+	3,  // 103: zsys.Zsys.Reload:output_type -> zsys.LogResponse
+	38, // 104: zsys.Zsys.GC:output_type -> zsys.GCResponse
+	41, // 105: zsys.Zsys.GCSimulate:output_type -> zsys.GCSimulateResponse
+	3,  // 106: zsys.Zsys.RunSchedule:output_type -> zsys.LogResponse
+	47, // 107: zsys.Zsys.Audit:output_type -> zsys.AuditResponse
+	51, // 108: zsys.Zsys.Subscribe:output_type -> zsys.SubscribeResponse
+	54, // 109: zsys.Zsys.MachineShow:output_type -> zsys.MachineShowResponse
+	56, // 110: zsys.Zsys.MachineList:output_type -> zsys.MachineListResponse
+	73, // [73:111] is the sub-list for method output_type
+	35, // [35:73] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
+}
+
+func init() { file_zsys_proto_init() }
+func file_zsys_proto_init() {
+	if File_zsys_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_zsys_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateUserDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnlockUserDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetUserQuotaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeHomeOnUserDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DissociateUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareBootResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitBootResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateBootMenuRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSaveStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevertSystemStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevertUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreUserStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateDiffRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateDiffResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PinStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnpinStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*States); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatasetDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DumpStatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoggingLevelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCSimulateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCSimulateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCSimulation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCSimulatedDay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCSimulatedRemoval); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCSimulatedBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		file_zsys_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DissociateUserRequest); i {
+		file_zsys_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1781,8 +6018,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrepareBootResponse); i {
+		file_zsys_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1793,8 +6030,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitBootResponse); i {
+		file_zsys_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditDatasetChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1805,8 +6042,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateBootMenuRequest); i {
+		file_zsys_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1817,8 +6054,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SaveSystemStateRequest); i {
+		file_zsys_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1829,8 +6066,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SaveUserStateRequest); i {
+		file_zsys_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1841,8 +6078,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateSaveStateResponse); i {
+		file_zsys_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineShowRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1853,8 +6090,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveSystemStateRequest); i {
+		file_zsys_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineShowResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1865,8 +6102,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveUserStateRequest); i {
+		file_zsys_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1877,8 +6114,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DumpStatesResponse); i {
+		file_zsys_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1889,8 +6126,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoggingLevelRequest); i {
+		file_zsys_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineSummaries); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1901,8 +6138,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TraceRequest); i {
+		file_zsys_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MachineSummary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1913,8 +6150,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TraceResponse); i {
+		file_zsys_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Machine); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1925,8 +6162,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GCRequest); i {
+		file_zsys_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserStates); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1937,8 +6174,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachineShowRequest); i {
+		file_zsys_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*State); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1949,8 +6186,8 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachineShowResponse); i {
+		file_zsys_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolMembership); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1961,8 +6198,20 @@ func file_zsys_proto_init() {
 				return nil
 			}
 		}
-		file_zsys_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MachineListResponse); i {
+		file_zsys_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Space); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_zsys_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dataset); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1974,108 +6223,535 @@ func file_zsys_proto_init() {
 			}
 		}
 	}
-	file_zsys_proto_msgTypes[2].OneofWrappers = []interface{}{
-		(*VersionResponse_Log)(nil),
-		(*VersionResponse_Version)(nil),
+	file_zsys_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*VersionResponse_Log)(nil),
+		(*VersionResponse_Version)(nil),
+	}
+	file_zsys_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*PrepareBootResponse_Log)(nil),
+		(*PrepareBootResponse_Changed)(nil),
+	}
+	file_zsys_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*CommitBootResponse_Log)(nil),
+		(*CommitBootResponse_Changed)(nil),
+	}
+	file_zsys_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*CreateSaveStateResponse_Log)(nil),
+		(*CreateSaveStateResponse_StateName)(nil),
+	}
+	file_zsys_proto_msgTypes[20].OneofWrappers = []interface{}{
+		(*StateDiffResponse_Log)(nil),
+		(*StateDiffResponse_Diff)(nil),
+	}
+	file_zsys_proto_msgTypes[25].OneofWrappers = []interface{}{
+		(*ListStatesResponse_Log)(nil),
+		(*ListStatesResponse_States)(nil),
+	}
+	file_zsys_proto_msgTypes[31].OneofWrappers = []interface{}{
+		(*DumpStatesResponse_Log)(nil),
+		(*DumpStatesResponse_States)(nil),
+	}
+	file_zsys_proto_msgTypes[34].OneofWrappers = []interface{}{
+		(*TraceResponse_Log)(nil),
+		(*TraceResponse_Trace)(nil),
+	}
+	file_zsys_proto_msgTypes[36].OneofWrappers = []interface{}{
+		(*GCResponse_Log)(nil),
+		(*GCResponse_Decision)(nil),
+	}
+	file_zsys_proto_msgTypes[39].OneofWrappers = []interface{}{
+		(*GCSimulateResponse_Log)(nil),
+		(*GCSimulateResponse_Simulation)(nil),
+	}
+	file_zsys_proto_msgTypes[45].OneofWrappers = []interface{}{
+		(*AuditResponse_Log)(nil),
+		(*AuditResponse_Entry)(nil),
+	}
+	file_zsys_proto_msgTypes[49].OneofWrappers = []interface{}{
+		(*SubscribeResponse_Log)(nil),
+		(*SubscribeResponse_Event)(nil),
+	}
+	file_zsys_proto_msgTypes[52].OneofWrappers = []interface{}{
+		(*MachineShowResponse_Log)(nil),
+		(*MachineShowResponse_MachineInfo)(nil),
+		(*MachineShowResponse_Machine)(nil),
+	}
+	file_zsys_proto_msgTypes[54].OneofWrappers = []interface{}{
+		(*MachineListResponse_Log)(nil),
+		(*MachineListResponse_MachineList)(nil),
+		(*MachineListResponse_Machines)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_zsys_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   72,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_zsys_proto_goTypes,
+		DependencyIndexes: file_zsys_proto_depIdxs,
+		EnumInfos:         file_zsys_proto_enumTypes,
+		MessageInfos:      file_zsys_proto_msgTypes,
+	}.Build()
+	File_zsys_proto = out.File
+	file_zsys_proto_rawDesc = nil
+	file_zsys_proto_goTypes = nil
+	file_zsys_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ZsysClient is the client API for Zsys service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ZsysClient interface {
+	Version(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_VersionClient, error)
+	CreateUserData(ctx context.Context, in *CreateUserDataRequest, opts ...grpc.CallOption) (Zsys_CreateUserDataClient, error)
+	ChangeHomeOnUserData(ctx context.Context, in *ChangeHomeOnUserDataRequest, opts ...grpc.CallOption) (Zsys_ChangeHomeOnUserDataClient, error)
+	DissociateUser(ctx context.Context, in *DissociateUserRequest, opts ...grpc.CallOption) (Zsys_DissociateUserClient, error)
+	UnlockUserData(ctx context.Context, in *UnlockUserDataRequest, opts ...grpc.CallOption) (Zsys_UnlockUserDataClient, error)
+	SetUserQuota(ctx context.Context, in *SetUserQuotaRequest, opts ...grpc.CallOption) (Zsys_SetUserQuotaClient, error)
+	PrepareBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_PrepareBootClient, error)
+	CommitBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_CommitBootClient, error)
+	UpdateBootMenu(ctx context.Context, in *UpdateBootMenuRequest, opts ...grpc.CallOption) (Zsys_UpdateBootMenuClient, error)
+	UpdateLastUsed(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_UpdateLastUsedClient, error)
+	SaveSystemState(ctx context.Context, in *SaveSystemStateRequest, opts ...grpc.CallOption) (Zsys_SaveSystemStateClient, error)
+	SaveUserState(ctx context.Context, in *SaveUserStateRequest, opts ...grpc.CallOption) (Zsys_SaveUserStateClient, error)
+	RemoveSystemState(ctx context.Context, in *RemoveSystemStateRequest, opts ...grpc.CallOption) (Zsys_RemoveSystemStateClient, error)
+	RemoveUserState(ctx context.Context, in *RemoveUserStateRequest, opts ...grpc.CallOption) (Zsys_RemoveUserStateClient, error)
+	RevertSystemState(ctx context.Context, in *RevertSystemStateRequest, opts ...grpc.CallOption) (Zsys_RevertSystemStateClient, error)
+	RevertUserState(ctx context.Context, in *RevertUserStateRequest, opts ...grpc.CallOption) (Zsys_RevertUserStateClient, error)
+	RestoreUserState(ctx context.Context, in *RestoreUserStateRequest, opts ...grpc.CallOption) (Zsys_RestoreUserStateClient, error)
+	StateDiff(ctx context.Context, in *StateDiffRequest, opts ...grpc.CallOption) (Zsys_StateDiffClient, error)
+	PinState(ctx context.Context, in *PinStateRequest, opts ...grpc.CallOption) (Zsys_PinStateClient, error)
+	UnpinState(ctx context.Context, in *UnpinStateRequest, opts ...grpc.CallOption) (Zsys_UnpinStateClient, error)
+	RenameState(ctx context.Context, in *RenameStateRequest, opts ...grpc.CallOption) (Zsys_RenameStateClient, error)
+	ListStates(ctx context.Context, in *ListStatesRequest, opts ...grpc.CallOption) (Zsys_ListStatesClient, error)
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (Zsys_ExportStateClient, error)
+	ImportState(ctx context.Context, in *ImportStateRequest, opts ...grpc.CallOption) (Zsys_ImportStateClient, error)
+	DumpStates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DumpStatesClient, error)
+	DaemonStop(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DaemonStopClient, error)
+	LoggingLevel(ctx context.Context, in *LoggingLevelRequest, opts ...grpc.CallOption) (Zsys_LoggingLevelClient, error)
+	Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_RefreshClient, error)
+	Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (Zsys_TraceClient, error)
+	Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_StatusClient, error)
+	Reload(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReloadClient, error)
+	GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (Zsys_GCClient, error)
+	GCSimulate(ctx context.Context, in *GCSimulateRequest, opts ...grpc.CallOption) (Zsys_GCSimulateClient, error)
+	RunSchedule(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_RunScheduleClient, error)
+	Audit(ctx context.Context, in *AuditRequest, opts ...grpc.CallOption) (Zsys_AuditClient, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Zsys_SubscribeClient, error)
+	MachineShow(ctx context.Context, in *MachineShowRequest, opts ...grpc.CallOption) (Zsys_MachineShowClient, error)
+	MachineList(ctx context.Context, in *MachineListRequest, opts ...grpc.CallOption) (Zsys_MachineListClient, error)
+}
+
+type zsysClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewZsysClient(cc grpc.ClientConnInterface) ZsysClient {
+	return &zsysClient{cc}
+}
+
+func (c *zsysClient) Version(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_VersionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[0], "/zsys.Zsys/Version", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysVersionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_VersionClient interface {
+	Recv() (*VersionResponse, error)
+	grpc.ClientStream
+}
+
+type zsysVersionClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysVersionClient) Recv() (*VersionResponse, error) {
+	m := new(VersionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) CreateUserData(ctx context.Context, in *CreateUserDataRequest, opts ...grpc.CallOption) (Zsys_CreateUserDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[1], "/zsys.Zsys/CreateUserData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysCreateUserDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_CreateUserDataClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysCreateUserDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysCreateUserDataClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) ChangeHomeOnUserData(ctx context.Context, in *ChangeHomeOnUserDataRequest, opts ...grpc.CallOption) (Zsys_ChangeHomeOnUserDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[2], "/zsys.Zsys/ChangeHomeOnUserData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysChangeHomeOnUserDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_ChangeHomeOnUserDataClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysChangeHomeOnUserDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysChangeHomeOnUserDataClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) DissociateUser(ctx context.Context, in *DissociateUserRequest, opts ...grpc.CallOption) (Zsys_DissociateUserClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[3], "/zsys.Zsys/DissociateUser", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysDissociateUserClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_DissociateUserClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysDissociateUserClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysDissociateUserClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) UnlockUserData(ctx context.Context, in *UnlockUserDataRequest, opts ...grpc.CallOption) (Zsys_UnlockUserDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[4], "/zsys.Zsys/UnlockUserData", opts...)
+	if err != nil {
+		return nil, err
 	}
-	file_zsys_proto_msgTypes[6].OneofWrappers = []interface{}{
-		(*PrepareBootResponse_Log)(nil),
-		(*PrepareBootResponse_Changed)(nil),
+	x := &zsysUnlockUserDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	file_zsys_proto_msgTypes[7].OneofWrappers = []interface{}{
-		(*CommitBootResponse_Log)(nil),
-		(*CommitBootResponse_Changed)(nil),
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	file_zsys_proto_msgTypes[11].OneofWrappers = []interface{}{
-		(*CreateSaveStateResponse_Log)(nil),
-		(*CreateSaveStateResponse_StateName)(nil),
+	return x, nil
+}
+
+type Zsys_UnlockUserDataClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysUnlockUserDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysUnlockUserDataClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	file_zsys_proto_msgTypes[14].OneofWrappers = []interface{}{
-		(*DumpStatesResponse_Log)(nil),
-		(*DumpStatesResponse_States)(nil),
+	return m, nil
+}
+
+func (c *zsysClient) SetUserQuota(ctx context.Context, in *SetUserQuotaRequest, opts ...grpc.CallOption) (Zsys_SetUserQuotaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[5], "/zsys.Zsys/SetUserQuota", opts...)
+	if err != nil {
+		return nil, err
 	}
-	file_zsys_proto_msgTypes[17].OneofWrappers = []interface{}{
-		(*TraceResponse_Log)(nil),
-		(*TraceResponse_Trace)(nil),
+	x := &zsysSetUserQuotaClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	file_zsys_proto_msgTypes[20].OneofWrappers = []interface{}{
-		(*MachineShowResponse_Log)(nil),
-		(*MachineShowResponse_MachineInfo)(nil),
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	file_zsys_proto_msgTypes[21].OneofWrappers = []interface{}{
-		(*MachineListResponse_Log)(nil),
-		(*MachineListResponse_MachineList)(nil),
+	return x, nil
+}
+
+type Zsys_SetUserQuotaClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysSetUserQuotaClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysSetUserQuotaClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_zsys_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   22,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_zsys_proto_goTypes,
-		DependencyIndexes: file_zsys_proto_depIdxs,
-		MessageInfos:      file_zsys_proto_msgTypes,
-	}.Build()
-	File_zsys_proto = out.File
-	file_zsys_proto_rawDesc = nil
-	file_zsys_proto_goTypes = nil
-	file_zsys_proto_depIdxs = nil
+	return m, nil
+}
+
+func (c *zsysClient) PrepareBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_PrepareBootClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[6], "/zsys.Zsys/PrepareBoot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysPrepareBootClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_PrepareBootClient interface {
+	Recv() (*PrepareBootResponse, error)
+	grpc.ClientStream
+}
+
+type zsysPrepareBootClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysPrepareBootClient) Recv() (*PrepareBootResponse, error) {
+	m := new(PrepareBootResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) CommitBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_CommitBootClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[7], "/zsys.Zsys/CommitBoot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysCommitBootClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_CommitBootClient interface {
+	Recv() (*CommitBootResponse, error)
+	grpc.ClientStream
+}
+
+type zsysCommitBootClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysCommitBootClient) Recv() (*CommitBootResponse, error) {
+	m := new(CommitBootResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) UpdateBootMenu(ctx context.Context, in *UpdateBootMenuRequest, opts ...grpc.CallOption) (Zsys_UpdateBootMenuClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[8], "/zsys.Zsys/UpdateBootMenu", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysUpdateBootMenuClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_UpdateBootMenuClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysUpdateBootMenuClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysUpdateBootMenuClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) UpdateLastUsed(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_UpdateLastUsedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[9], "/zsys.Zsys/UpdateLastUsed", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysUpdateLastUsedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_UpdateLastUsedClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysUpdateLastUsedClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysUpdateLastUsedClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) SaveSystemState(ctx context.Context, in *SaveSystemStateRequest, opts ...grpc.CallOption) (Zsys_SaveSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[10], "/zsys.Zsys/SaveSystemState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysSaveSystemStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_SaveSystemStateClient interface {
+	Recv() (*CreateSaveStateResponse, error)
+	grpc.ClientStream
+}
+
+type zsysSaveSystemStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysSaveSystemStateClient) Recv() (*CreateSaveStateResponse, error) {
+	m := new(CreateSaveStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) SaveUserState(ctx context.Context, in *SaveUserStateRequest, opts ...grpc.CallOption) (Zsys_SaveUserStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[11], "/zsys.Zsys/SaveUserState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysSaveUserStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
-
-// ZsysClient is the client API for Zsys service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ZsysClient interface {
-	Version(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_VersionClient, error)
-	CreateUserData(ctx context.Context, in *CreateUserDataRequest, opts ...grpc.CallOption) (Zsys_CreateUserDataClient, error)
-	ChangeHomeOnUserData(ctx context.Context, in *ChangeHomeOnUserDataRequest, opts ...grpc.CallOption) (Zsys_ChangeHomeOnUserDataClient, error)
-	DissociateUser(ctx context.Context, in *DissociateUserRequest, opts ...grpc.CallOption) (Zsys_DissociateUserClient, error)
-	PrepareBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_PrepareBootClient, error)
-	CommitBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_CommitBootClient, error)
-	UpdateBootMenu(ctx context.Context, in *UpdateBootMenuRequest, opts ...grpc.CallOption) (Zsys_UpdateBootMenuClient, error)
-	UpdateLastUsed(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_UpdateLastUsedClient, error)
-	SaveSystemState(ctx context.Context, in *SaveSystemStateRequest, opts ...grpc.CallOption) (Zsys_SaveSystemStateClient, error)
-	SaveUserState(ctx context.Context, in *SaveUserStateRequest, opts ...grpc.CallOption) (Zsys_SaveUserStateClient, error)
-	RemoveSystemState(ctx context.Context, in *RemoveSystemStateRequest, opts ...grpc.CallOption) (Zsys_RemoveSystemStateClient, error)
-	RemoveUserState(ctx context.Context, in *RemoveUserStateRequest, opts ...grpc.CallOption) (Zsys_RemoveUserStateClient, error)
-	DumpStates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DumpStatesClient, error)
-	DaemonStop(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DaemonStopClient, error)
-	LoggingLevel(ctx context.Context, in *LoggingLevelRequest, opts ...grpc.CallOption) (Zsys_LoggingLevelClient, error)
-	Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_RefreshClient, error)
-	Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (Zsys_TraceClient, error)
-	Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_StatusClient, error)
-	Reload(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReloadClient, error)
-	GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (Zsys_GCClient, error)
-	MachineShow(ctx context.Context, in *MachineShowRequest, opts ...grpc.CallOption) (Zsys_MachineShowClient, error)
-	MachineList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_MachineListClient, error)
+type Zsys_SaveUserStateClient interface {
+	Recv() (*CreateSaveStateResponse, error)
+	grpc.ClientStream
 }
 
-type zsysClient struct {
-	cc grpc.ClientConnInterface
+type zsysSaveUserStateClient struct {
+	grpc.ClientStream
 }
 
-func NewZsysClient(cc grpc.ClientConnInterface) ZsysClient {
-	return &zsysClient{cc}
+func (x *zsysSaveUserStateClient) Recv() (*CreateSaveStateResponse, error) {
+	m := new(CreateSaveStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *zsysClient) Version(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_VersionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[0], "/zsys.Zsys/Version", opts...)
+func (c *zsysClient) RemoveSystemState(ctx context.Context, in *RemoveSystemStateRequest, opts ...grpc.CallOption) (Zsys_RemoveSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[12], "/zsys.Zsys/RemoveSystemState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysVersionClient{stream}
+	x := &zsysRemoveSystemStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2085,29 +6761,29 @@ func (c *zsysClient) Version(ctx context.Context, in *Empty, opts ...grpc.CallOp
 	return x, nil
 }
 
-type Zsys_VersionClient interface {
-	Recv() (*VersionResponse, error)
+type Zsys_RemoveSystemStateClient interface {
+	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysVersionClient struct {
+type zsysRemoveSystemStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysVersionClient) Recv() (*VersionResponse, error) {
-	m := new(VersionResponse)
+func (x *zsysRemoveSystemStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) CreateUserData(ctx context.Context, in *CreateUserDataRequest, opts ...grpc.CallOption) (Zsys_CreateUserDataClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[1], "/zsys.Zsys/CreateUserData", opts...)
+func (c *zsysClient) RemoveUserState(ctx context.Context, in *RemoveUserStateRequest, opts ...grpc.CallOption) (Zsys_RemoveUserStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[13], "/zsys.Zsys/RemoveUserState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysCreateUserDataClient{stream}
+	x := &zsysRemoveUserStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2117,16 +6793,16 @@ func (c *zsysClient) CreateUserData(ctx context.Context, in *CreateUserDataReque
 	return x, nil
 }
 
-type Zsys_CreateUserDataClient interface {
+type Zsys_RemoveUserStateClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysCreateUserDataClient struct {
+type zsysRemoveUserStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysCreateUserDataClient) Recv() (*LogResponse, error) {
+func (x *zsysRemoveUserStateClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2134,12 +6810,12 @@ func (x *zsysCreateUserDataClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) ChangeHomeOnUserData(ctx context.Context, in *ChangeHomeOnUserDataRequest, opts ...grpc.CallOption) (Zsys_ChangeHomeOnUserDataClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[2], "/zsys.Zsys/ChangeHomeOnUserData", opts...)
+func (c *zsysClient) RevertSystemState(ctx context.Context, in *RevertSystemStateRequest, opts ...grpc.CallOption) (Zsys_RevertSystemStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[14], "/zsys.Zsys/RevertSystemState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysChangeHomeOnUserDataClient{stream}
+	x := &zsysRevertSystemStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2149,16 +6825,16 @@ func (c *zsysClient) ChangeHomeOnUserData(ctx context.Context, in *ChangeHomeOnU
 	return x, nil
 }
 
-type Zsys_ChangeHomeOnUserDataClient interface {
+type Zsys_RevertSystemStateClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysChangeHomeOnUserDataClient struct {
+type zsysRevertSystemStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysChangeHomeOnUserDataClient) Recv() (*LogResponse, error) {
+func (x *zsysRevertSystemStateClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2166,12 +6842,12 @@ func (x *zsysChangeHomeOnUserDataClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) DissociateUser(ctx context.Context, in *DissociateUserRequest, opts ...grpc.CallOption) (Zsys_DissociateUserClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[3], "/zsys.Zsys/DissociateUser", opts...)
+func (c *zsysClient) RevertUserState(ctx context.Context, in *RevertUserStateRequest, opts ...grpc.CallOption) (Zsys_RevertUserStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[15], "/zsys.Zsys/RevertUserState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysDissociateUserClient{stream}
+	x := &zsysRevertUserStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2181,16 +6857,16 @@ func (c *zsysClient) DissociateUser(ctx context.Context, in *DissociateUserReque
 	return x, nil
 }
 
-type Zsys_DissociateUserClient interface {
+type Zsys_RevertUserStateClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysDissociateUserClient struct {
+type zsysRevertUserStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysDissociateUserClient) Recv() (*LogResponse, error) {
+func (x *zsysRevertUserStateClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2198,12 +6874,12 @@ func (x *zsysDissociateUserClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) PrepareBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_PrepareBootClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[4], "/zsys.Zsys/PrepareBoot", opts...)
+func (c *zsysClient) RestoreUserState(ctx context.Context, in *RestoreUserStateRequest, opts ...grpc.CallOption) (Zsys_RestoreUserStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[16], "/zsys.Zsys/RestoreUserState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysPrepareBootClient{stream}
+	x := &zsysRestoreUserStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2213,29 +6889,29 @@ func (c *zsysClient) PrepareBoot(ctx context.Context, in *Empty, opts ...grpc.Ca
 	return x, nil
 }
 
-type Zsys_PrepareBootClient interface {
-	Recv() (*PrepareBootResponse, error)
+type Zsys_RestoreUserStateClient interface {
+	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysPrepareBootClient struct {
+type zsysRestoreUserStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysPrepareBootClient) Recv() (*PrepareBootResponse, error) {
-	m := new(PrepareBootResponse)
+func (x *zsysRestoreUserStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) CommitBoot(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_CommitBootClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[5], "/zsys.Zsys/CommitBoot", opts...)
+func (c *zsysClient) StateDiff(ctx context.Context, in *StateDiffRequest, opts ...grpc.CallOption) (Zsys_StateDiffClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[17], "/zsys.Zsys/StateDiff", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysCommitBootClient{stream}
+	x := &zsysStateDiffClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2245,29 +6921,29 @@ func (c *zsysClient) CommitBoot(ctx context.Context, in *Empty, opts ...grpc.Cal
 	return x, nil
 }
 
-type Zsys_CommitBootClient interface {
-	Recv() (*CommitBootResponse, error)
+type Zsys_StateDiffClient interface {
+	Recv() (*StateDiffResponse, error)
 	grpc.ClientStream
 }
 
-type zsysCommitBootClient struct {
+type zsysStateDiffClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysCommitBootClient) Recv() (*CommitBootResponse, error) {
-	m := new(CommitBootResponse)
+func (x *zsysStateDiffClient) Recv() (*StateDiffResponse, error) {
+	m := new(StateDiffResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) UpdateBootMenu(ctx context.Context, in *UpdateBootMenuRequest, opts ...grpc.CallOption) (Zsys_UpdateBootMenuClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[6], "/zsys.Zsys/UpdateBootMenu", opts...)
+func (c *zsysClient) PinState(ctx context.Context, in *PinStateRequest, opts ...grpc.CallOption) (Zsys_PinStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[18], "/zsys.Zsys/PinState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysUpdateBootMenuClient{stream}
+	x := &zsysPinStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2277,16 +6953,16 @@ func (c *zsysClient) UpdateBootMenu(ctx context.Context, in *UpdateBootMenuReque
 	return x, nil
 }
 
-type Zsys_UpdateBootMenuClient interface {
+type Zsys_PinStateClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysUpdateBootMenuClient struct {
+type zsysPinStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysUpdateBootMenuClient) Recv() (*LogResponse, error) {
+func (x *zsysPinStateClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2294,12 +6970,12 @@ func (x *zsysUpdateBootMenuClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) UpdateLastUsed(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_UpdateLastUsedClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[7], "/zsys.Zsys/UpdateLastUsed", opts...)
+func (c *zsysClient) UnpinState(ctx context.Context, in *UnpinStateRequest, opts ...grpc.CallOption) (Zsys_UnpinStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[19], "/zsys.Zsys/UnpinState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysUpdateLastUsedClient{stream}
+	x := &zsysUnpinStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2309,16 +6985,16 @@ func (c *zsysClient) UpdateLastUsed(ctx context.Context, in *Empty, opts ...grpc
 	return x, nil
 }
 
-type Zsys_UpdateLastUsedClient interface {
+type Zsys_UnpinStateClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysUpdateLastUsedClient struct {
+type zsysUnpinStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysUpdateLastUsedClient) Recv() (*LogResponse, error) {
+func (x *zsysUnpinStateClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2326,12 +7002,12 @@ func (x *zsysUpdateLastUsedClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) SaveSystemState(ctx context.Context, in *SaveSystemStateRequest, opts ...grpc.CallOption) (Zsys_SaveSystemStateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[8], "/zsys.Zsys/SaveSystemState", opts...)
+func (c *zsysClient) RenameState(ctx context.Context, in *RenameStateRequest, opts ...grpc.CallOption) (Zsys_RenameStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[20], "/zsys.Zsys/RenameState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysSaveSystemStateClient{stream}
+	x := &zsysRenameStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2341,29 +7017,29 @@ func (c *zsysClient) SaveSystemState(ctx context.Context, in *SaveSystemStateReq
 	return x, nil
 }
 
-type Zsys_SaveSystemStateClient interface {
-	Recv() (*CreateSaveStateResponse, error)
+type Zsys_RenameStateClient interface {
+	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysSaveSystemStateClient struct {
+type zsysRenameStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysSaveSystemStateClient) Recv() (*CreateSaveStateResponse, error) {
-	m := new(CreateSaveStateResponse)
+func (x *zsysRenameStateClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) SaveUserState(ctx context.Context, in *SaveUserStateRequest, opts ...grpc.CallOption) (Zsys_SaveUserStateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[9], "/zsys.Zsys/SaveUserState", opts...)
+func (c *zsysClient) ListStates(ctx context.Context, in *ListStatesRequest, opts ...grpc.CallOption) (Zsys_ListStatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[21], "/zsys.Zsys/ListStates", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysSaveUserStateClient{stream}
+	x := &zsysListStatesClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2373,29 +7049,29 @@ func (c *zsysClient) SaveUserState(ctx context.Context, in *SaveUserStateRequest
 	return x, nil
 }
 
-type Zsys_SaveUserStateClient interface {
-	Recv() (*CreateSaveStateResponse, error)
+type Zsys_ListStatesClient interface {
+	Recv() (*ListStatesResponse, error)
 	grpc.ClientStream
 }
 
-type zsysSaveUserStateClient struct {
+type zsysListStatesClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysSaveUserStateClient) Recv() (*CreateSaveStateResponse, error) {
-	m := new(CreateSaveStateResponse)
+func (x *zsysListStatesClient) Recv() (*ListStatesResponse, error) {
+	m := new(ListStatesResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) RemoveSystemState(ctx context.Context, in *RemoveSystemStateRequest, opts ...grpc.CallOption) (Zsys_RemoveSystemStateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[10], "/zsys.Zsys/RemoveSystemState", opts...)
+func (c *zsysClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (Zsys_ExportStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[22], "/zsys.Zsys/ExportState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysRemoveSystemStateClient{stream}
+	x := &zsysExportStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2405,16 +7081,16 @@ func (c *zsysClient) RemoveSystemState(ctx context.Context, in *RemoveSystemStat
 	return x, nil
 }
 
-type Zsys_RemoveSystemStateClient interface {
+type Zsys_ExportStateClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysRemoveSystemStateClient struct {
+type zsysExportStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysRemoveSystemStateClient) Recv() (*LogResponse, error) {
+func (x *zsysExportStateClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2422,12 +7098,12 @@ func (x *zsysRemoveSystemStateClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) RemoveUserState(ctx context.Context, in *RemoveUserStateRequest, opts ...grpc.CallOption) (Zsys_RemoveUserStateClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[11], "/zsys.Zsys/RemoveUserState", opts...)
+func (c *zsysClient) ImportState(ctx context.Context, in *ImportStateRequest, opts ...grpc.CallOption) (Zsys_ImportStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[23], "/zsys.Zsys/ImportState", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysRemoveUserStateClient{stream}
+	x := &zsysImportStateClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2437,16 +7113,16 @@ func (c *zsysClient) RemoveUserState(ctx context.Context, in *RemoveUserStateReq
 	return x, nil
 }
 
-type Zsys_RemoveUserStateClient interface {
+type Zsys_ImportStateClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysRemoveUserStateClient struct {
+type zsysImportStateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysRemoveUserStateClient) Recv() (*LogResponse, error) {
+func (x *zsysImportStateClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2455,7 +7131,7 @@ func (x *zsysRemoveUserStateClient) Recv() (*LogResponse, error) {
 }
 
 func (c *zsysClient) DumpStates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DumpStatesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[12], "/zsys.Zsys/DumpStates", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[24], "/zsys.Zsys/DumpStates", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2487,7 +7163,7 @@ func (x *zsysDumpStatesClient) Recv() (*DumpStatesResponse, error) {
 }
 
 func (c *zsysClient) DaemonStop(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_DaemonStopClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[13], "/zsys.Zsys/DaemonStop", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[25], "/zsys.Zsys/DaemonStop", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2519,7 +7195,7 @@ func (x *zsysDaemonStopClient) Recv() (*LogResponse, error) {
 }
 
 func (c *zsysClient) LoggingLevel(ctx context.Context, in *LoggingLevelRequest, opts ...grpc.CallOption) (Zsys_LoggingLevelClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[14], "/zsys.Zsys/LoggingLevel", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[26], "/zsys.Zsys/LoggingLevel", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2551,7 +7227,7 @@ func (x *zsysLoggingLevelClient) Recv() (*LogResponse, error) {
 }
 
 func (c *zsysClient) Refresh(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_RefreshClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[15], "/zsys.Zsys/Refresh", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[27], "/zsys.Zsys/Refresh", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2583,7 +7259,7 @@ func (x *zsysRefreshClient) Recv() (*LogResponse, error) {
 }
 
 func (c *zsysClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (Zsys_TraceClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[16], "/zsys.Zsys/Trace", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[28], "/zsys.Zsys/Trace", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2602,24 +7278,152 @@ type Zsys_TraceClient interface {
 	grpc.ClientStream
 }
 
-type zsysTraceClient struct {
+type zsysTraceClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysTraceClient) Recv() (*TraceResponse, error) {
+	m := new(TraceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_StatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[29], "/zsys.Zsys/Status", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_StatusClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysStatusClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) Reload(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[30], "/zsys.Zsys/Reload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysReloadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_ReloadClient interface {
+	Recv() (*LogResponse, error)
+	grpc.ClientStream
+}
+
+type zsysReloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysReloadClient) Recv() (*LogResponse, error) {
+	m := new(LogResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (Zsys_GCClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[31], "/zsys.Zsys/GC", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysGCClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_GCClient interface {
+	Recv() (*GCResponse, error)
+	grpc.ClientStream
+}
+
+type zsysGCClient struct {
+	grpc.ClientStream
+}
+
+func (x *zsysGCClient) Recv() (*GCResponse, error) {
+	m := new(GCResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *zsysClient) GCSimulate(ctx context.Context, in *GCSimulateRequest, opts ...grpc.CallOption) (Zsys_GCSimulateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[32], "/zsys.Zsys/GCSimulate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &zsysGCSimulateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Zsys_GCSimulateClient interface {
+	Recv() (*GCSimulateResponse, error)
+	grpc.ClientStream
+}
+
+type zsysGCSimulateClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysTraceClient) Recv() (*TraceResponse, error) {
-	m := new(TraceResponse)
+func (x *zsysGCSimulateClient) Recv() (*GCSimulateResponse, error) {
+	m := new(GCSimulateResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_StatusClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[17], "/zsys.Zsys/Status", opts...)
+func (c *zsysClient) RunSchedule(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_RunScheduleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[33], "/zsys.Zsys/RunSchedule", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysStatusClient{stream}
+	x := &zsysRunScheduleClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2629,16 +7433,16 @@ func (c *zsysClient) Status(ctx context.Context, in *Empty, opts ...grpc.CallOpt
 	return x, nil
 }
 
-type Zsys_StatusClient interface {
+type Zsys_RunScheduleClient interface {
 	Recv() (*LogResponse, error)
 	grpc.ClientStream
 }
 
-type zsysStatusClient struct {
+type zsysRunScheduleClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysStatusClient) Recv() (*LogResponse, error) {
+func (x *zsysRunScheduleClient) Recv() (*LogResponse, error) {
 	m := new(LogResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2646,12 +7450,12 @@ func (x *zsysStatusClient) Recv() (*LogResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) Reload(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_ReloadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[18], "/zsys.Zsys/Reload", opts...)
+func (c *zsysClient) Audit(ctx context.Context, in *AuditRequest, opts ...grpc.CallOption) (Zsys_AuditClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[34], "/zsys.Zsys/Audit", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysReloadClient{stream}
+	x := &zsysAuditClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2661,29 +7465,29 @@ func (c *zsysClient) Reload(ctx context.Context, in *Empty, opts ...grpc.CallOpt
 	return x, nil
 }
 
-type Zsys_ReloadClient interface {
-	Recv() (*LogResponse, error)
+type Zsys_AuditClient interface {
+	Recv() (*AuditResponse, error)
 	grpc.ClientStream
 }
 
-type zsysReloadClient struct {
+type zsysAuditClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysReloadClient) Recv() (*LogResponse, error) {
-	m := new(LogResponse)
+func (x *zsysAuditClient) Recv() (*AuditResponse, error) {
+	m := new(AuditResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *zsysClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (Zsys_GCClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[19], "/zsys.Zsys/GC", opts...)
+func (c *zsysClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (Zsys_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[35], "/zsys.Zsys/Subscribe", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &zsysGCClient{stream}
+	x := &zsysSubscribeClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2693,17 +7497,17 @@ func (c *zsysClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOpt
 	return x, nil
 }
 
-type Zsys_GCClient interface {
-	Recv() (*LogResponse, error)
+type Zsys_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
 	grpc.ClientStream
 }
 
-type zsysGCClient struct {
+type zsysSubscribeClient struct {
 	grpc.ClientStream
 }
 
-func (x *zsysGCClient) Recv() (*LogResponse, error) {
-	m := new(LogResponse)
+func (x *zsysSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -2711,7 +7515,7 @@ func (x *zsysGCClient) Recv() (*LogResponse, error) {
 }
 
 func (c *zsysClient) MachineShow(ctx context.Context, in *MachineShowRequest, opts ...grpc.CallOption) (Zsys_MachineShowClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[20], "/zsys.Zsys/MachineShow", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[36], "/zsys.Zsys/MachineShow", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2742,8 +7546,8 @@ func (x *zsysMachineShowClient) Recv() (*MachineShowResponse, error) {
 	return m, nil
 }
 
-func (c *zsysClient) MachineList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Zsys_MachineListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[21], "/zsys.Zsys/MachineList", opts...)
+func (c *zsysClient) MachineList(ctx context.Context, in *MachineListRequest, opts ...grpc.CallOption) (Zsys_MachineListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Zsys_serviceDesc.Streams[37], "/zsys.Zsys/MachineList", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2780,6 +7584,8 @@ type ZsysServer interface {
 	CreateUserData(*CreateUserDataRequest, Zsys_CreateUserDataServer) error
 	ChangeHomeOnUserData(*ChangeHomeOnUserDataRequest, Zsys_ChangeHomeOnUserDataServer) error
 	DissociateUser(*DissociateUserRequest, Zsys_DissociateUserServer) error
+	UnlockUserData(*UnlockUserDataRequest, Zsys_UnlockUserDataServer) error
+	SetUserQuota(*SetUserQuotaRequest, Zsys_SetUserQuotaServer) error
 	PrepareBoot(*Empty, Zsys_PrepareBootServer) error
 	CommitBoot(*Empty, Zsys_CommitBootServer) error
 	UpdateBootMenu(*UpdateBootMenuRequest, Zsys_UpdateBootMenuServer) error
@@ -2788,6 +7594,16 @@ type ZsysServer interface {
 	SaveUserState(*SaveUserStateRequest, Zsys_SaveUserStateServer) error
 	RemoveSystemState(*RemoveSystemStateRequest, Zsys_RemoveSystemStateServer) error
 	RemoveUserState(*RemoveUserStateRequest, Zsys_RemoveUserStateServer) error
+	RevertSystemState(*RevertSystemStateRequest, Zsys_RevertSystemStateServer) error
+	RevertUserState(*RevertUserStateRequest, Zsys_RevertUserStateServer) error
+	RestoreUserState(*RestoreUserStateRequest, Zsys_RestoreUserStateServer) error
+	StateDiff(*StateDiffRequest, Zsys_StateDiffServer) error
+	PinState(*PinStateRequest, Zsys_PinStateServer) error
+	UnpinState(*UnpinStateRequest, Zsys_UnpinStateServer) error
+	RenameState(*RenameStateRequest, Zsys_RenameStateServer) error
+	ListStates(*ListStatesRequest, Zsys_ListStatesServer) error
+	ExportState(*ExportStateRequest, Zsys_ExportStateServer) error
+	ImportState(*ImportStateRequest, Zsys_ImportStateServer) error
 	DumpStates(*Empty, Zsys_DumpStatesServer) error
 	DaemonStop(*Empty, Zsys_DaemonStopServer) error
 	LoggingLevel(*LoggingLevelRequest, Zsys_LoggingLevelServer) error
@@ -2796,8 +7612,12 @@ type ZsysServer interface {
 	Status(*Empty, Zsys_StatusServer) error
 	Reload(*Empty, Zsys_ReloadServer) error
 	GC(*GCRequest, Zsys_GCServer) error
+	GCSimulate(*GCSimulateRequest, Zsys_GCSimulateServer) error
+	RunSchedule(*Empty, Zsys_RunScheduleServer) error
+	Audit(*AuditRequest, Zsys_AuditServer) error
+	Subscribe(*SubscribeRequest, Zsys_SubscribeServer) error
 	MachineShow(*MachineShowRequest, Zsys_MachineShowServer) error
-	MachineList(*Empty, Zsys_MachineListServer) error
+	MachineList(*MachineListRequest, Zsys_MachineListServer) error
 }
 
 // UnimplementedZsysServer can be embedded to have forward compatible implementations.
@@ -2816,6 +7636,12 @@ func (*UnimplementedZsysServer) ChangeHomeOnUserData(*ChangeHomeOnUserDataReques
 func (*UnimplementedZsysServer) DissociateUser(*DissociateUserRequest, Zsys_DissociateUserServer) error {
 	return status.Errorf(codes.Unimplemented, "method DissociateUser not implemented")
 }
+func (*UnimplementedZsysServer) UnlockUserData(*UnlockUserDataRequest, Zsys_UnlockUserDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method UnlockUserData not implemented")
+}
+func (*UnimplementedZsysServer) SetUserQuota(*SetUserQuotaRequest, Zsys_SetUserQuotaServer) error {
+	return status.Errorf(codes.Unimplemented, "method SetUserQuota not implemented")
+}
 func (*UnimplementedZsysServer) PrepareBoot(*Empty, Zsys_PrepareBootServer) error {
 	return status.Errorf(codes.Unimplemented, "method PrepareBoot not implemented")
 }
@@ -2840,290 +7666,584 @@ func (*UnimplementedZsysServer) RemoveSystemState(*RemoveSystemStateRequest, Zsy
 func (*UnimplementedZsysServer) RemoveUserState(*RemoveUserStateRequest, Zsys_RemoveUserStateServer) error {
 	return status.Errorf(codes.Unimplemented, "method RemoveUserState not implemented")
 }
+func (*UnimplementedZsysServer) RevertSystemState(*RevertSystemStateRequest, Zsys_RevertSystemStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method RevertSystemState not implemented")
+}
+func (*UnimplementedZsysServer) RevertUserState(*RevertUserStateRequest, Zsys_RevertUserStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method RevertUserState not implemented")
+}
+func (*UnimplementedZsysServer) RestoreUserState(*RestoreUserStateRequest, Zsys_RestoreUserStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method RestoreUserState not implemented")
+}
+func (*UnimplementedZsysServer) StateDiff(*StateDiffRequest, Zsys_StateDiffServer) error {
+	return status.Errorf(codes.Unimplemented, "method StateDiff not implemented")
+}
+func (*UnimplementedZsysServer) PinState(*PinStateRequest, Zsys_PinStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method PinState not implemented")
+}
+func (*UnimplementedZsysServer) UnpinState(*UnpinStateRequest, Zsys_UnpinStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method UnpinState not implemented")
+}
+func (*UnimplementedZsysServer) RenameState(*RenameStateRequest, Zsys_RenameStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method RenameState not implemented")
+}
+func (*UnimplementedZsysServer) ListStates(*ListStatesRequest, Zsys_ListStatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListStates not implemented")
+}
+func (*UnimplementedZsysServer) ExportState(*ExportStateRequest, Zsys_ExportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
+func (*UnimplementedZsysServer) ImportState(*ImportStateRequest, Zsys_ImportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportState not implemented")
+}
 func (*UnimplementedZsysServer) DumpStates(*Empty, Zsys_DumpStatesServer) error {
 	return status.Errorf(codes.Unimplemented, "method DumpStates not implemented")
 }
 func (*UnimplementedZsysServer) DaemonStop(*Empty, Zsys_DaemonStopServer) error {
 	return status.Errorf(codes.Unimplemented, "method DaemonStop not implemented")
 }
-func (*UnimplementedZsysServer) LoggingLevel(*LoggingLevelRequest, Zsys_LoggingLevelServer) error {
-	return status.Errorf(codes.Unimplemented, "method LoggingLevel not implemented")
+func (*UnimplementedZsysServer) LoggingLevel(*LoggingLevelRequest, Zsys_LoggingLevelServer) error {
+	return status.Errorf(codes.Unimplemented, "method LoggingLevel not implemented")
+}
+func (*UnimplementedZsysServer) Refresh(*Empty, Zsys_RefreshServer) error {
+	return status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
+func (*UnimplementedZsysServer) Trace(*TraceRequest, Zsys_TraceServer) error {
+	return status.Errorf(codes.Unimplemented, "method Trace not implemented")
+}
+func (*UnimplementedZsysServer) Status(*Empty, Zsys_StatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (*UnimplementedZsysServer) Reload(*Empty, Zsys_ReloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Reload not implemented")
+}
+func (*UnimplementedZsysServer) GC(*GCRequest, Zsys_GCServer) error {
+	return status.Errorf(codes.Unimplemented, "method GC not implemented")
+}
+func (*UnimplementedZsysServer) GCSimulate(*GCSimulateRequest, Zsys_GCSimulateServer) error {
+	return status.Errorf(codes.Unimplemented, "method GCSimulate not implemented")
+}
+func (*UnimplementedZsysServer) RunSchedule(*Empty, Zsys_RunScheduleServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunSchedule not implemented")
+}
+func (*UnimplementedZsysServer) Audit(*AuditRequest, Zsys_AuditServer) error {
+	return status.Errorf(codes.Unimplemented, "method Audit not implemented")
+}
+func (*UnimplementedZsysServer) Subscribe(*SubscribeRequest, Zsys_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedZsysServer) MachineShow(*MachineShowRequest, Zsys_MachineShowServer) error {
+	return status.Errorf(codes.Unimplemented, "method MachineShow not implemented")
+}
+func (*UnimplementedZsysServer) MachineList(*MachineListRequest, Zsys_MachineListServer) error {
+	return status.Errorf(codes.Unimplemented, "method MachineList not implemented")
+}
+
+func RegisterZsysServer(s *grpc.Server, srv ZsysServer) {
+	s.RegisterService(&_Zsys_serviceDesc, srv)
+}
+
+func _Zsys_Version_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).Version(m, &zsysVersionServer{stream})
+}
+
+type Zsys_VersionServer interface {
+	Send(*VersionResponse) error
+	grpc.ServerStream
+}
+
+type zsysVersionServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysVersionServer) Send(m *VersionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_CreateUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).CreateUserData(m, &zsysCreateUserDataServer{stream})
+}
+
+type Zsys_CreateUserDataServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysCreateUserDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysCreateUserDataServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_ChangeHomeOnUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChangeHomeOnUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).ChangeHomeOnUserData(m, &zsysChangeHomeOnUserDataServer{stream})
+}
+
+type Zsys_ChangeHomeOnUserDataServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysChangeHomeOnUserDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysChangeHomeOnUserDataServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_DissociateUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DissociateUserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).DissociateUser(m, &zsysDissociateUserServer{stream})
+}
+
+type Zsys_DissociateUserServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysDissociateUserServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysDissociateUserServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_UnlockUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UnlockUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).UnlockUserData(m, &zsysUnlockUserDataServer{stream})
+}
+
+type Zsys_UnlockUserDataServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysUnlockUserDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysUnlockUserDataServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_SetUserQuota_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SetUserQuotaRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).SetUserQuota(m, &zsysSetUserQuotaServer{stream})
+}
+
+type Zsys_SetUserQuotaServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysSetUserQuotaServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysSetUserQuotaServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_PrepareBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).PrepareBoot(m, &zsysPrepareBootServer{stream})
+}
+
+type Zsys_PrepareBootServer interface {
+	Send(*PrepareBootResponse) error
+	grpc.ServerStream
+}
+
+type zsysPrepareBootServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysPrepareBootServer) Send(m *PrepareBootResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_CommitBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).CommitBoot(m, &zsysCommitBootServer{stream})
+}
+
+type Zsys_CommitBootServer interface {
+	Send(*CommitBootResponse) error
+	grpc.ServerStream
+}
+
+type zsysCommitBootServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysCommitBootServer) Send(m *CommitBootResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_UpdateBootMenu_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateBootMenuRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).UpdateBootMenu(m, &zsysUpdateBootMenuServer{stream})
+}
+
+type Zsys_UpdateBootMenuServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysUpdateBootMenuServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysUpdateBootMenuServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_UpdateLastUsed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).UpdateLastUsed(m, &zsysUpdateLastUsedServer{stream})
+}
+
+type Zsys_UpdateLastUsedServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysUpdateLastUsedServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysUpdateLastUsedServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedZsysServer) Refresh(*Empty, Zsys_RefreshServer) error {
-	return status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+
+func _Zsys_SaveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SaveSystemStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).SaveSystemState(m, &zsysSaveSystemStateServer{stream})
 }
-func (*UnimplementedZsysServer) Trace(*TraceRequest, Zsys_TraceServer) error {
-	return status.Errorf(codes.Unimplemented, "method Trace not implemented")
+
+type Zsys_SaveSystemStateServer interface {
+	Send(*CreateSaveStateResponse) error
+	grpc.ServerStream
 }
-func (*UnimplementedZsysServer) Status(*Empty, Zsys_StatusServer) error {
-	return status.Errorf(codes.Unimplemented, "method Status not implemented")
+
+type zsysSaveSystemStateServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedZsysServer) Reload(*Empty, Zsys_ReloadServer) error {
-	return status.Errorf(codes.Unimplemented, "method Reload not implemented")
+
+func (x *zsysSaveSystemStateServer) Send(m *CreateSaveStateResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedZsysServer) GC(*GCRequest, Zsys_GCServer) error {
-	return status.Errorf(codes.Unimplemented, "method GC not implemented")
+
+func _Zsys_SaveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SaveUserStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).SaveUserState(m, &zsysSaveUserStateServer{stream})
 }
-func (*UnimplementedZsysServer) MachineShow(*MachineShowRequest, Zsys_MachineShowServer) error {
-	return status.Errorf(codes.Unimplemented, "method MachineShow not implemented")
+
+type Zsys_SaveUserStateServer interface {
+	Send(*CreateSaveStateResponse) error
+	grpc.ServerStream
 }
-func (*UnimplementedZsysServer) MachineList(*Empty, Zsys_MachineListServer) error {
-	return status.Errorf(codes.Unimplemented, "method MachineList not implemented")
+
+type zsysSaveUserStateServer struct {
+	grpc.ServerStream
 }
 
-func RegisterZsysServer(s *grpc.Server, srv ZsysServer) {
-	s.RegisterService(&_Zsys_serviceDesc, srv)
+func (x *zsysSaveUserStateServer) Send(m *CreateSaveStateResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_Version_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Zsys_RemoveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RemoveSystemStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).Version(m, &zsysVersionServer{stream})
+	return srv.(ZsysServer).RemoveSystemState(m, &zsysRemoveSystemStateServer{stream})
 }
 
-type Zsys_VersionServer interface {
-	Send(*VersionResponse) error
+type Zsys_RemoveSystemStateServer interface {
+	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysVersionServer struct {
+type zsysRemoveSystemStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysVersionServer) Send(m *VersionResponse) error {
+func (x *zsysRemoveSystemStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_CreateUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CreateUserDataRequest)
+func _Zsys_RemoveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RemoveUserStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).CreateUserData(m, &zsysCreateUserDataServer{stream})
+	return srv.(ZsysServer).RemoveUserState(m, &zsysRemoveUserStateServer{stream})
 }
 
-type Zsys_CreateUserDataServer interface {
+type Zsys_RemoveUserStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysCreateUserDataServer struct {
+type zsysRemoveUserStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysCreateUserDataServer) Send(m *LogResponse) error {
+func (x *zsysRemoveUserStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_ChangeHomeOnUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(ChangeHomeOnUserDataRequest)
+func _Zsys_RevertSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RevertSystemStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).ChangeHomeOnUserData(m, &zsysChangeHomeOnUserDataServer{stream})
+	return srv.(ZsysServer).RevertSystemState(m, &zsysRevertSystemStateServer{stream})
 }
 
-type Zsys_ChangeHomeOnUserDataServer interface {
+type Zsys_RevertSystemStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysChangeHomeOnUserDataServer struct {
+type zsysRevertSystemStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysChangeHomeOnUserDataServer) Send(m *LogResponse) error {
+func (x *zsysRevertSystemStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_DissociateUser_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(DissociateUserRequest)
+func _Zsys_RevertUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RevertUserStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).DissociateUser(m, &zsysDissociateUserServer{stream})
+	return srv.(ZsysServer).RevertUserState(m, &zsysRevertUserStateServer{stream})
 }
 
-type Zsys_DissociateUserServer interface {
+type Zsys_RevertUserStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysDissociateUserServer struct {
+type zsysRevertUserStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysDissociateUserServer) Send(m *LogResponse) error {
+func (x *zsysRevertUserStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_PrepareBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Zsys_RestoreUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RestoreUserStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).PrepareBoot(m, &zsysPrepareBootServer{stream})
+	return srv.(ZsysServer).RestoreUserState(m, &zsysRestoreUserStateServer{stream})
 }
 
-type Zsys_PrepareBootServer interface {
-	Send(*PrepareBootResponse) error
+type Zsys_RestoreUserStateServer interface {
+	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysPrepareBootServer struct {
+type zsysRestoreUserStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysPrepareBootServer) Send(m *PrepareBootResponse) error {
+func (x *zsysRestoreUserStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_CommitBoot_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Zsys_StateDiff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StateDiffRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).CommitBoot(m, &zsysCommitBootServer{stream})
+	return srv.(ZsysServer).StateDiff(m, &zsysStateDiffServer{stream})
 }
 
-type Zsys_CommitBootServer interface {
-	Send(*CommitBootResponse) error
+type Zsys_StateDiffServer interface {
+	Send(*StateDiffResponse) error
 	grpc.ServerStream
 }
 
-type zsysCommitBootServer struct {
+type zsysStateDiffServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysCommitBootServer) Send(m *CommitBootResponse) error {
+func (x *zsysStateDiffServer) Send(m *StateDiffResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_UpdateBootMenu_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UpdateBootMenuRequest)
+func _Zsys_PinState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PinStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).UpdateBootMenu(m, &zsysUpdateBootMenuServer{stream})
+	return srv.(ZsysServer).PinState(m, &zsysPinStateServer{stream})
 }
 
-type Zsys_UpdateBootMenuServer interface {
+type Zsys_PinStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysUpdateBootMenuServer struct {
+type zsysPinStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysUpdateBootMenuServer) Send(m *LogResponse) error {
+func (x *zsysPinStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_UpdateLastUsed_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+func _Zsys_UnpinState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UnpinStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).UpdateLastUsed(m, &zsysUpdateLastUsedServer{stream})
+	return srv.(ZsysServer).UnpinState(m, &zsysUnpinStateServer{stream})
 }
 
-type Zsys_UpdateLastUsedServer interface {
+type Zsys_UnpinStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysUpdateLastUsedServer struct {
+type zsysUnpinStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysUpdateLastUsedServer) Send(m *LogResponse) error {
+func (x *zsysUnpinStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_SaveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SaveSystemStateRequest)
+func _Zsys_RenameState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RenameStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).SaveSystemState(m, &zsysSaveSystemStateServer{stream})
+	return srv.(ZsysServer).RenameState(m, &zsysRenameStateServer{stream})
 }
 
-type Zsys_SaveSystemStateServer interface {
-	Send(*CreateSaveStateResponse) error
+type Zsys_RenameStateServer interface {
+	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysSaveSystemStateServer struct {
+type zsysRenameStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysSaveSystemStateServer) Send(m *CreateSaveStateResponse) error {
+func (x *zsysRenameStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_SaveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SaveUserStateRequest)
+func _Zsys_ListStates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListStatesRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).SaveUserState(m, &zsysSaveUserStateServer{stream})
+	return srv.(ZsysServer).ListStates(m, &zsysListStatesServer{stream})
 }
 
-type Zsys_SaveUserStateServer interface {
-	Send(*CreateSaveStateResponse) error
+type Zsys_ListStatesServer interface {
+	Send(*ListStatesResponse) error
 	grpc.ServerStream
 }
 
-type zsysSaveUserStateServer struct {
+type zsysListStatesServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysSaveUserStateServer) Send(m *CreateSaveStateResponse) error {
+func (x *zsysListStatesServer) Send(m *ListStatesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_RemoveSystemState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RemoveSystemStateRequest)
+func _Zsys_ExportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).RemoveSystemState(m, &zsysRemoveSystemStateServer{stream})
+	return srv.(ZsysServer).ExportState(m, &zsysExportStateServer{stream})
 }
 
-type Zsys_RemoveSystemStateServer interface {
+type Zsys_ExportStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysRemoveSystemStateServer struct {
+type zsysExportStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysRemoveSystemStateServer) Send(m *LogResponse) error {
+func (x *zsysExportStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Zsys_RemoveUserState_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(RemoveUserStateRequest)
+func _Zsys_ImportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportStateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ZsysServer).RemoveUserState(m, &zsysRemoveUserStateServer{stream})
+	return srv.(ZsysServer).ImportState(m, &zsysImportStateServer{stream})
 }
 
-type Zsys_RemoveUserStateServer interface {
+type Zsys_ImportStateServer interface {
 	Send(*LogResponse) error
 	grpc.ServerStream
 }
 
-type zsysRemoveUserStateServer struct {
+type zsysImportStateServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysRemoveUserStateServer) Send(m *LogResponse) error {
+func (x *zsysImportStateServer) Send(m *LogResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -3283,7 +8403,7 @@ func _Zsys_GC_Handler(srv interface{}, stream grpc.ServerStream) error {
 }
 
 type Zsys_GCServer interface {
-	Send(*LogResponse) error
+	Send(*GCResponse) error
 	grpc.ServerStream
 }
 
@@ -3291,7 +8411,91 @@ type zsysGCServer struct {
 	grpc.ServerStream
 }
 
-func (x *zsysGCServer) Send(m *LogResponse) error {
+func (x *zsysGCServer) Send(m *GCResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_GCSimulate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GCSimulateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).GCSimulate(m, &zsysGCSimulateServer{stream})
+}
+
+type Zsys_GCSimulateServer interface {
+	Send(*GCSimulateResponse) error
+	grpc.ServerStream
+}
+
+type zsysGCSimulateServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysGCSimulateServer) Send(m *GCSimulateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_RunSchedule_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).RunSchedule(m, &zsysRunScheduleServer{stream})
+}
+
+type Zsys_RunScheduleServer interface {
+	Send(*LogResponse) error
+	grpc.ServerStream
+}
+
+type zsysRunScheduleServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysRunScheduleServer) Send(m *LogResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_Audit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuditRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).Audit(m, &zsysAuditServer{stream})
+}
+
+type Zsys_AuditServer interface {
+	Send(*AuditResponse) error
+	grpc.ServerStream
+}
+
+type zsysAuditServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysAuditServer) Send(m *AuditResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Zsys_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZsysServer).Subscribe(m, &zsysSubscribeServer{stream})
+}
+
+type Zsys_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type zsysSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *zsysSubscribeServer) Send(m *SubscribeResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -3317,7 +8521,7 @@ func (x *zsysMachineShowServer) Send(m *MachineShowResponse) error {
 }
 
 func _Zsys_MachineList_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
+	m := new(MachineListRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -3362,6 +8566,16 @@ var _Zsys_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Zsys_DissociateUser_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "UnlockUserData",
+			Handler:       _Zsys_UnlockUserData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SetUserQuota",
+			Handler:       _Zsys_SetUserQuota_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "PrepareBoot",
 			Handler:       _Zsys_PrepareBoot_Handler,
@@ -3402,6 +8616,56 @@ var _Zsys_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Zsys_RemoveUserState_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "RevertSystemState",
+			Handler:       _Zsys_RevertSystemState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RevertUserState",
+			Handler:       _Zsys_RevertUserState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreUserState",
+			Handler:       _Zsys_RestoreUserState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StateDiff",
+			Handler:       _Zsys_StateDiff_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PinState",
+			Handler:       _Zsys_PinState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UnpinState",
+			Handler:       _Zsys_UnpinState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RenameState",
+			Handler:       _Zsys_RenameState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListStates",
+			Handler:       _Zsys_ListStates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportState",
+			Handler:       _Zsys_ExportState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportState",
+			Handler:       _Zsys_ImportState_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "DumpStates",
 			Handler:       _Zsys_DumpStates_Handler,
@@ -3442,6 +8706,26 @@ var _Zsys_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Zsys_GC_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GCSimulate",
+			Handler:       _Zsys_GCSimulate_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RunSchedule",
+			Handler:       _Zsys_RunSchedule_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Audit",
+			Handler:       _Zsys_Audit_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Zsys_Subscribe_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "MachineShow",
 			Handler:       _Zsys_MachineShow_Handler,
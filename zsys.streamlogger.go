@@ -179,6 +179,66 @@ func (z *ZsysLogServer) DissociateUser(req *DissociateUserRequest, stream Zsys_D
 	})
 }
 
+/*
+ * Zsys.UnlockUserData()
+ */
+
+// zsysUnlockUserDataLogStream is a Zsys_UnlockUserDataServer augmented by its own Context containing the log streamer
+type zsysUnlockUserDataLogStream struct {
+	Zsys_UnlockUserDataServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysUnlockUserDataLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnlockUserData overrides ZsysServer UnlockUserData, installing a logger first
+func (z *ZsysLogServer) UnlockUserData(req *UnlockUserDataRequest, stream Zsys_UnlockUserDataServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "UnlockUserData")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.UnlockUserData(req, &zsysUnlockUserDataLogStream{
+		Zsys_UnlockUserDataServer: stream,
+		ctx:                       ctx,
+	})
+}
+
+/*
+ * Zsys.SetUserQuota()
+ */
+
+// zsysSetUserQuotaLogStream is a Zsys_SetUserQuotaServer augmented by its own Context containing the log streamer
+type zsysSetUserQuotaLogStream struct {
+	Zsys_SetUserQuotaServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysSetUserQuotaLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// SetUserQuota overrides ZsysServer SetUserQuota, installing a logger first
+func (z *ZsysLogServer) SetUserQuota(req *SetUserQuotaRequest, stream Zsys_SetUserQuotaServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "SetUserQuota")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.SetUserQuota(req, &zsysSetUserQuotaLogStream{
+		Zsys_SetUserQuotaServer: stream,
+		ctx:                     ctx,
+	})
+}
+
 /*
  * Zsys.PrepareBoot()
  */
@@ -419,6 +479,306 @@ func (z *ZsysLogServer) RemoveUserState(req *RemoveUserStateRequest, stream Zsys
 	})
 }
 
+/*
+ * Zsys.RevertSystemState()
+ */
+
+// zsysRevertSystemStateLogStream is a Zsys_RevertSystemStateServer augmented by its own Context containing the log streamer
+type zsysRevertSystemStateLogStream struct {
+	Zsys_RevertSystemStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysRevertSystemStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// RevertSystemState overrides ZsysServer RevertSystemState, installing a logger first
+func (z *ZsysLogServer) RevertSystemState(req *RevertSystemStateRequest, stream Zsys_RevertSystemStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "RevertSystemState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.RevertSystemState(req, &zsysRevertSystemStateLogStream{
+		Zsys_RevertSystemStateServer: stream,
+		ctx:                          ctx,
+	})
+}
+
+/*
+ * Zsys.RevertUserState()
+ */
+
+// zsysRevertUserStateLogStream is a Zsys_RevertUserStateServer augmented by its own Context containing the log streamer
+type zsysRevertUserStateLogStream struct {
+	Zsys_RevertUserStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysRevertUserStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// RevertUserState overrides ZsysServer RevertUserState, installing a logger first
+func (z *ZsysLogServer) RevertUserState(req *RevertUserStateRequest, stream Zsys_RevertUserStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "RevertUserState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.RevertUserState(req, &zsysRevertUserStateLogStream{
+		Zsys_RevertUserStateServer: stream,
+		ctx:                        ctx,
+	})
+}
+
+/*
+ * Zsys.RestoreUserState()
+ */
+
+// zsysRestoreUserStateLogStream is a Zsys_RestoreUserStateServer augmented by its own Context containing the log streamer
+type zsysRestoreUserStateLogStream struct {
+	Zsys_RestoreUserStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysRestoreUserStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// RestoreUserState overrides ZsysServer RestoreUserState, installing a logger first
+func (z *ZsysLogServer) RestoreUserState(req *RestoreUserStateRequest, stream Zsys_RestoreUserStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "RestoreUserState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.RestoreUserState(req, &zsysRestoreUserStateLogStream{
+		Zsys_RestoreUserStateServer: stream,
+		ctx:                         ctx,
+	})
+}
+
+/*
+ * Zsys.StateDiff()
+ */
+
+// zsysStateDiffLogStream is a Zsys_StateDiffServer augmented by its own Context containing the log streamer
+type zsysStateDiffLogStream struct {
+	Zsys_StateDiffServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysStateDiffLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// StateDiff overrides ZsysServer StateDiff, installing a logger first
+func (z *ZsysLogServer) StateDiff(req *StateDiffRequest, stream Zsys_StateDiffServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "StateDiff")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.StateDiff(req, &zsysStateDiffLogStream{
+		Zsys_StateDiffServer: stream,
+		ctx:                  ctx,
+	})
+}
+
+/*
+ * Zsys.PinState()
+ */
+
+// zsysPinStateLogStream is a Zsys_PinStateServer augmented by its own Context containing the log streamer
+type zsysPinStateLogStream struct {
+	Zsys_PinStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysPinStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// PinState overrides ZsysServer PinState, installing a logger first
+func (z *ZsysLogServer) PinState(req *PinStateRequest, stream Zsys_PinStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "PinState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.PinState(req, &zsysPinStateLogStream{
+		Zsys_PinStateServer: stream,
+		ctx:                 ctx,
+	})
+}
+
+/*
+ * Zsys.UnpinState()
+ */
+
+// zsysUnpinStateLogStream is a Zsys_UnpinStateServer augmented by its own Context containing the log streamer
+type zsysUnpinStateLogStream struct {
+	Zsys_UnpinStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysUnpinStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnpinState overrides ZsysServer UnpinState, installing a logger first
+func (z *ZsysLogServer) UnpinState(req *UnpinStateRequest, stream Zsys_UnpinStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "UnpinState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.UnpinState(req, &zsysUnpinStateLogStream{
+		Zsys_UnpinStateServer: stream,
+		ctx:                   ctx,
+	})
+}
+
+/*
+ * Zsys.RenameState()
+ */
+
+// zsysRenameStateLogStream is a Zsys_RenameStateServer augmented by its own Context containing the log streamer
+type zsysRenameStateLogStream struct {
+	Zsys_RenameStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysRenameStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// RenameState overrides ZsysServer RenameState, installing a logger first
+func (z *ZsysLogServer) RenameState(req *RenameStateRequest, stream Zsys_RenameStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "RenameState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.RenameState(req, &zsysRenameStateLogStream{
+		Zsys_RenameStateServer: stream,
+		ctx:                    ctx,
+	})
+}
+
+/*
+ * Zsys.ListStates()
+ */
+
+// zsysListStatesLogStream is a Zsys_ListStatesServer augmented by its own Context containing the log streamer
+type zsysListStatesLogStream struct {
+	Zsys_ListStatesServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysListStatesLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// ListStates overrides ZsysServer ListStates, installing a logger first
+func (z *ZsysLogServer) ListStates(req *ListStatesRequest, stream Zsys_ListStatesServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "ListStates")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.ListStates(req, &zsysListStatesLogStream{
+		Zsys_ListStatesServer: stream,
+		ctx:                   ctx,
+	})
+}
+
+/*
+ * Zsys.ExportState()
+ */
+
+// zsysExportStateLogStream is a Zsys_ExportStateServer augmented by its own Context containing the log streamer
+type zsysExportStateLogStream struct {
+	Zsys_ExportStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysExportStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// ExportState overrides ZsysServer ExportState, installing a logger first
+func (z *ZsysLogServer) ExportState(req *ExportStateRequest, stream Zsys_ExportStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "ExportState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.ExportState(req, &zsysExportStateLogStream{
+		Zsys_ExportStateServer: stream,
+		ctx:                    ctx,
+	})
+}
+
+/*
+ * Zsys.ImportState()
+ */
+
+// zsysImportStateLogStream is a Zsys_ImportStateServer augmented by its own Context containing the log streamer
+type zsysImportStateLogStream struct {
+	Zsys_ImportStateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysImportStateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// ImportState overrides ZsysServer ImportState, installing a logger first
+func (z *ZsysLogServer) ImportState(req *ImportStateRequest, stream Zsys_ImportStateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "ImportState")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.ImportState(req, &zsysImportStateLogStream{
+		Zsys_ImportStateServer: stream,
+		ctx:                    ctx,
+	})
+}
+
 /*
  * Zsys.DumpStates()
  */
@@ -659,6 +1019,126 @@ func (z *ZsysLogServer) GC(req *GCRequest, stream Zsys_GCServer) error {
 	})
 }
 
+/*
+ * Zsys.GCSimulate()
+ */
+
+// zsysGCSimulateLogStream is a Zsys_GCSimulateServer augmented by its own Context containing the log streamer
+type zsysGCSimulateLogStream struct {
+	Zsys_GCSimulateServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysGCSimulateLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// GCSimulate overrides ZsysServer GCSimulate, installing a logger first
+func (z *ZsysLogServer) GCSimulate(req *GCSimulateRequest, stream Zsys_GCSimulateServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "GCSimulate")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.GCSimulate(req, &zsysGCSimulateLogStream{
+		Zsys_GCSimulateServer: stream,
+		ctx:                   ctx,
+	})
+}
+
+/*
+ * Zsys.RunSchedule()
+ */
+
+// zsysRunScheduleLogStream is a Zsys_RunScheduleServer augmented by its own Context containing the log streamer
+type zsysRunScheduleLogStream struct {
+	Zsys_RunScheduleServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysRunScheduleLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// RunSchedule overrides ZsysServer RunSchedule, installing a logger first
+func (z *ZsysLogServer) RunSchedule(req *Empty, stream Zsys_RunScheduleServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "RunSchedule")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.RunSchedule(req, &zsysRunScheduleLogStream{
+		Zsys_RunScheduleServer: stream,
+		ctx:                    ctx,
+	})
+}
+
+/*
+ * Zsys.Audit()
+ */
+
+// zsysAuditLogStream is a Zsys_AuditServer augmented by its own Context containing the log streamer
+type zsysAuditLogStream struct {
+	Zsys_AuditServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysAuditLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// Audit overrides ZsysServer Audit, installing a logger first
+func (z *ZsysLogServer) Audit(req *AuditRequest, stream Zsys_AuditServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Audit")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.Audit(req, &zsysAuditLogStream{
+		Zsys_AuditServer: stream,
+		ctx:              ctx,
+	})
+}
+
+/*
+ * Zsys.Subscribe()
+ */
+
+// zsysSubscribeLogStream is a Zsys_SubscribeServer augmented by its own Context containing the log streamer
+type zsysSubscribeLogStream struct {
+	Zsys_SubscribeServer
+	ctx context.Context
+}
+
+// Context access the log streamer context
+func (s *zsysSubscribeLogStream) Context() context.Context {
+	return s.ctx
+}
+
+// Subscribe overrides ZsysServer Subscribe, installing a logger first
+func (z *ZsysLogServer) Subscribe(req *SubscribeRequest, stream Zsys_SubscribeServer) error {
+	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
+	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "Subscribe")
+	if err != nil {
+		return fmt.Errorf(i18n.G("couldn't attach a logger to request: %w"), err)
+	}
+
+	// wrap the context to access the context with logger
+	return z.ZsysServerIdleTimeout.Subscribe(req, &zsysSubscribeLogStream{
+		Zsys_SubscribeServer: stream,
+		ctx:                  ctx,
+	})
+}
+
 /*
  * Zsys.MachineShow()
  */
@@ -705,7 +1185,7 @@ func (s *zsysMachineListLogStream) Context() context.Context {
 }
 
 // MachineList overrides ZsysServer MachineList, installing a logger first
-func (z *ZsysLogServer) MachineList(req *Empty, stream Zsys_MachineListServer) error {
+func (z *ZsysLogServer) MachineList(req *MachineListRequest, stream Zsys_MachineListServer) error {
 	// it's ok to panic in the assertion as we expect to have generated above the Write() function.
 	ctx, err := streamlogger.AddLogger(stream.(streamlogger.StreamLogger), "MachineList")
 	if err != nil {
@@ -775,6 +1255,32 @@ func (s *zsysDissociateUserServer) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// Write promote zsysUnlockUserDataServer to an io.Writer
+func (s *zsysUnlockUserDataServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysSetUserQuotaServer to an io.Writer
+func (s *zsysSetUserQuotaServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
 // Write promote zsysPrepareBootServer to an io.Writer
 func (s *zsysPrepareBootServer) Write(p []byte) (n int, err error) {
 	err = s.Send(
@@ -879,6 +1385,136 @@ func (s *zsysRemoveUserStateServer) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// Write promote zsysRevertSystemStateServer to an io.Writer
+func (s *zsysRevertSystemStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysRevertUserStateServer to an io.Writer
+func (s *zsysRevertUserStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysRestoreUserStateServer to an io.Writer
+func (s *zsysRestoreUserStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysStateDiffServer to an io.Writer
+func (s *zsysStateDiffServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&StateDiffResponse{
+			Reply: &StateDiffResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysPinStateServer to an io.Writer
+func (s *zsysPinStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysUnpinStateServer to an io.Writer
+func (s *zsysUnpinStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysRenameStateServer to an io.Writer
+func (s *zsysRenameStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysListStatesServer to an io.Writer
+func (s *zsysListStatesServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&ListStatesResponse{
+			Reply: &ListStatesResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysExportStateServer to an io.Writer
+func (s *zsysExportStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysImportStateServer to an io.Writer
+func (s *zsysImportStateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&LogResponse{
+			Log: string(p),
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
 // Write promote zsysDumpStatesServer to an io.Writer
 func (s *zsysDumpStatesServer) Write(p []byte) (n int, err error) {
 	err = s.Send(
@@ -972,6 +1608,32 @@ func (s *zsysReloadServer) Write(p []byte) (n int, err error) {
 
 // Write promote zsysGCServer to an io.Writer
 func (s *zsysGCServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&GCResponse{
+			Reply: &GCResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysGCSimulateServer to an io.Writer
+func (s *zsysGCSimulateServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&GCSimulateResponse{
+			Reply: &GCSimulateResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysRunScheduleServer to an io.Writer
+func (s *zsysRunScheduleServer) Write(p []byte) (n int, err error) {
 	err = s.Send(
 		&LogResponse{
 			Log: string(p),
@@ -983,6 +1645,32 @@ func (s *zsysGCServer) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
+// Write promote zsysAuditServer to an io.Writer
+func (s *zsysAuditServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&AuditResponse{
+			Reply: &AuditResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write promote zsysSubscribeServer to an io.Writer
+func (s *zsysSubscribeServer) Write(p []byte) (n int, err error) {
+	err = s.Send(
+		&SubscribeResponse{
+			Reply: &SubscribeResponse_Log{Log: string(p)},
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
 // Write promote zsysMachineShowServer to an io.Writer
 func (s *zsysMachineShowServer) Write(p []byte) (n int, err error) {
 	err = s.Send(